@@ -0,0 +1,201 @@
+package certs
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"gatehill.io/imposter/library"
+	"gatehill.io/imposter/logging"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	caValidity   = 10 * 365 * 24 * time.Hour
+	certValidity = 825 * 24 * time.Hour
+
+	// renewBefore is how long before expiry a certificate is replaced.
+	renewBefore = 7 * 24 * time.Hour
+
+	caName     = "ca"
+	clientName = "client"
+)
+
+// DefaultHosts are the names and addresses in the certificate for
+// mocks on the local machine.
+var DefaultHosts = []string{"localhost", "127.0.0.1", "::1"}
+
+var logger = logging.GetLogger()
+
+// Paths holds the locations of a certificate and its private key.
+type Paths struct {
+	Cert string
+	Key  string
+}
+
+// GetDir returns the directory holding the local CA and the certificates
+// it has issued.
+func GetDir() (string, error) {
+	return library.EnsureDirUsingConfig("tls.dir", ".imposter/certs")
+}
+
+// GetCAPath returns the path of the local CA certificate, which clients
+// must trust to connect to mocks using TLS.
+func GetCAPath() (string, error) {
+	dir, err := GetDir()
+	if err != nil {
+		return "", err
+	}
+	return getPaths(dir, caName).Cert, nil
+}
+
+// EnsureServerCert returns a certificate for the hosts, issued by the local
+// CA. Both are generated the first time they are needed, then reused until
+// they are close to expiry.
+func EnsureServerCert(hosts []string) (Paths, error) {
+	return ensureCert(hosts[0], hosts, x509.ExtKeyUsageServerAuth)
+}
+
+// EnsureClientCert returns a client certificate issued by the local CA, for
+// connecting to mocks that require mutual TLS.
+func EnsureClientCert() (Paths, error) {
+	return ensureCert(clientName, []string{"imposter-client"}, x509.ExtKeyUsageClientAuth)
+}
+
+func ensureCert(name string, hosts []string, usage x509.ExtKeyUsage) (Paths, error) {
+	dir, err := GetDir()
+	if err != nil {
+		return Paths{}, err
+	}
+	ca, caKey, err := ensureCA(dir)
+	if err != nil {
+		return Paths{}, err
+	}
+	paths := getPaths(dir, name)
+	if isValid(paths, ca) {
+		logger.Tracef("using existing certificate: %s", paths.Cert)
+		return paths, nil
+	}
+
+	logger.Debugf("generating certificate for %v: %s", hosts, paths.Cert)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return Paths{}, fmt.Errorf("failed to generate key: %v", err)
+	}
+	template, err := newTemplate(hosts[0], certValidity)
+	if err != nil {
+		return Paths{}, err
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	template.ExtKeyUsage = []x509.ExtKeyUsage{usage}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		return Paths{}, fmt.Errorf("failed to create certificate: %v", err)
+	}
+	if err = writeKeyPair(paths, der, key); err != nil {
+		return Paths{}, err
+	}
+	return paths, nil
+}
+
+// ensureCA loads the local CA, generating it if it does not exist or is
+// close to expiry.
+func ensureCA(dir string) (*x509.Certificate, crypto.Signer, error) {
+	paths := getPaths(dir, caName)
+	if keyPair, err := tls.LoadX509KeyPair(paths.Cert, paths.Key); err == nil {
+		if ca, err := x509.ParseCertificate(keyPair.Certificate[0]); err == nil && time.Until(ca.NotAfter) > renewBefore {
+			if signer, ok := keyPair.PrivateKey.(crypto.Signer); ok {
+				return ca, signer, nil
+			}
+		}
+	}
+
+	logger.Infof("generating local certificate authority: %s", paths.Cert)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate CA key: %v", err)
+	}
+	template, err := newTemplate("Imposter Local CA", caValidity)
+	if err != nil {
+		return nil, nil, err
+	}
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create CA certificate: %v", err)
+	}
+	if err = writeKeyPair(paths, der, key); err != nil {
+		return nil, nil, err
+	}
+	ca, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse CA certificate: %v", err)
+	}
+	return ca, key, nil
+}
+
+func getPaths(dir string, name string) Paths {
+	return Paths{
+		Cert: filepath.Join(dir, name+".pem"),
+		Key:  filepath.Join(dir, name+"-key.pem"),
+	}
+}
+
+func newTemplate(commonName string, validity time.Duration) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %v", err)
+	}
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{Organization: []string{"Imposter"}, CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(validity),
+	}, nil
+}
+
+// isValid returns true if the certificate exists, was issued by the CA
+// and is not close to expiry.
+func isValid(paths Paths, ca *x509.Certificate) bool {
+	keyPair, err := tls.LoadX509KeyPair(paths.Cert, paths.Key)
+	if err != nil {
+		return false
+	}
+	cert, err := x509.ParseCertificate(keyPair.Certificate[0])
+	if err != nil || time.Until(cert.NotAfter) < renewBefore {
+		return false
+	}
+	return cert.CheckSignatureFrom(ca) == nil
+}
+
+func writeKeyPair(paths Paths, der []byte, key *ecdsa.PrivateKey) error {
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("failed to marshal key: %v", err)
+	}
+	if err = os.WriteFile(paths.Key, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer}), 0600); err != nil {
+		return fmt.Errorf("failed to write key: %s: %v", paths.Key, err)
+	}
+	if err = os.WriteFile(paths.Cert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644); err != nil {
+		return fmt.Errorf("failed to write certificate: %s: %v", paths.Cert, err)
+	}
+	return nil
+}
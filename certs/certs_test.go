@@ -0,0 +1,97 @@
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func setupCertDir(t *testing.T) {
+	viper.Set("tls.dir", t.TempDir())
+	t.Cleanup(func() {
+		viper.Set("tls.dir", "")
+	})
+}
+
+func TestEnsureServerCert(t *testing.T) {
+	setupCertDir(t)
+
+	paths, err := EnsureServerCert(DefaultHosts)
+	require.NoError(t, err)
+	keyPair, err := tls.LoadX509KeyPair(paths.Cert, paths.Key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(keyPair.Certificate[0])
+	require.NoError(t, err)
+	require.Equal(t, []string{"localhost"}, cert.DNSNames)
+	require.Len(t, cert.IPAddresses, 2)
+
+	caPath, err := GetCAPath()
+	require.NoError(t, err)
+	pool, err := loadPool(caPath)
+	require.NoError(t, err)
+	_, err = cert.Verify(x509.VerifyOptions{DNSName: "localhost", Roots: pool})
+	require.NoError(t, err, "certificate should be issued by the local CA")
+
+	original, err := os.ReadFile(paths.Cert)
+	require.NoError(t, err)
+	paths, err = EnsureServerCert(DefaultHosts)
+	require.NoError(t, err)
+	reused, err := os.ReadFile(paths.Cert)
+	require.NoError(t, err)
+	require.Equal(t, original, reused, "certificate should be reused")
+}
+
+func TestEnsureServerCert_NewCA(t *testing.T) {
+	setupCertDir(t)
+
+	paths, err := EnsureServerCert(DefaultHosts)
+	require.NoError(t, err)
+	original, err := os.ReadFile(paths.Cert)
+	require.NoError(t, err)
+
+	caPath, err := GetCAPath()
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(caPath))
+
+	paths, err = EnsureServerCert(DefaultHosts)
+	require.NoError(t, err)
+	replaced, err := os.ReadFile(paths.Cert)
+	require.NoError(t, err)
+	require.NotEqual(t, original, replaced, "certificate should be reissued by the new CA")
+}
+
+func TestServerConfig_MutualTLS(t *testing.T) {
+	setupCertDir(t)
+
+	serverConfig, err := ServerConfig(DefaultHosts, true, "")
+	require.NoError(t, err)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	server.TLS = serverConfig
+	server.StartTLS()
+	defer server.Close()
+	url := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+
+	clientConfig, err := ClientConfig()
+	require.NoError(t, err)
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: clientConfig}}
+	_, err = client.Get(url)
+	require.Error(t, err, "client without certificate should be rejected")
+
+	_, err = EnsureClientCert()
+	require.NoError(t, err)
+	clientConfig, err = ClientConfig()
+	require.NoError(t, err)
+	client = &http.Client{Transport: &http.Transport{TLSClientConfig: clientConfig}}
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
@@ -0,0 +1,81 @@
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// ServerConfig returns the TLS configuration for a mock using a certificate
+// for the hosts issued by the local CA. If mtls is true, clients must present
+// a certificate issued by the CA at clientCAPath, or by the local CA if
+// clientCAPath is empty.
+func ServerConfig(hosts []string, mtls bool, clientCAPath string) (*tls.Config, error) {
+	paths, err := EnsureServerCert(hosts)
+	if err != nil {
+		return nil, err
+	}
+	keyPair, err := tls.LoadX509KeyPair(paths.Cert, paths.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load certificate: %s: %v", paths.Cert, err)
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{keyPair},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if mtls {
+		if clientCAPath == "" {
+			if clientCAPath, err = GetCAPath(); err != nil {
+				return nil, err
+			}
+		}
+		pool, err := loadPool(clientCAPath)
+		if err != nil {
+			return nil, err
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
+
+// ClientConfig returns the TLS configuration for connecting to mocks. It
+// trusts the local CA, in addition to the system roots, and presents the
+// local client certificate if one has been generated.
+func ClientConfig() (*tls.Config, error) {
+	caPath, err := GetCAPath()
+	if err != nil {
+		return nil, err
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if ca, err := os.ReadFile(caPath); err == nil {
+		pool.AppendCertsFromPEM(ca)
+	}
+	config := &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+
+	dir, err := GetDir()
+	if err != nil {
+		return nil, err
+	}
+	paths := getPaths(dir, clientName)
+	if keyPair, err := tls.LoadX509KeyPair(paths.Cert, paths.Key); err == nil {
+		config.Certificates = []tls.Certificate{keyPair}
+	}
+	return config, nil
+}
+
+func loadPool(caPath string) (*x509.CertPool, error) {
+	ca, err := os.ReadFile(caPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate: %s: %v", caPath, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("no certificates found in: %s", caPath)
+	}
+	return pool, nil
+}
@@ -0,0 +1,150 @@
+package chaos
+
+import (
+	"bytes"
+	"gatehill.io/imposter/logging"
+	"gatehill.io/imposter/proxy"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// throttleInterval is the interval between the chunks of a response body
+// sent with limited bandwidth.
+const throttleInterval = 100 * time.Millisecond
+
+var logger = logging.GetLogger()
+
+// random returns a number in the range [0.0,1.0), and can be replaced in tests.
+var random = rand.Float64
+
+// Middleware returns a handler that injects the faults described by the
+// profile into the responses of next. WebSocket and gRPC requests, and
+// the status endpoint, are passed through unchanged.
+func Middleware(profile *Profile, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/system/status" || proxy.IsWebSocketUpgrade(req) || proxy.IsGrpcRequest(req) {
+			next.ServeHTTP(w, req)
+			return
+		}
+		rule := profile.Match(req.Method, req.URL.Path)
+		if rule == nil {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		if delay := rule.delay(); delay > 0 {
+			logger.Debugf("chaos: delaying %s %s by %v", req.Method, req.URL.Path, delay)
+			select {
+			case <-req.Context().Done():
+				return
+			case <-time.After(delay):
+			}
+		}
+		if chance(rule.ResetRate) {
+			logger.Debugf("chaos: resetting connection for %s %s", req.Method, req.URL.Path)
+			resetConnection(w)
+			return
+		}
+		if chance(rule.ErrorRate) {
+			logger.Debugf("chaos: responding to %s %s with status %d", req.Method, req.URL.Path, rule.ErrorStatus)
+			http.Error(w, "fault injected by imposter", rule.ErrorStatus)
+			return
+		}
+
+		truncate := chance(rule.TruncateRate)
+		if (!truncate && rule.bytesPerSecond == 0) || req.Method == http.MethodHead {
+			next.ServeHTTP(w, req)
+			return
+		}
+		buffered := newBufferedWriter()
+		next.ServeHTTP(buffered, req)
+		writeResponse(w, buffered, truncate, rule.bytesPerSecond)
+	})
+}
+
+func (r *Rule) delay() time.Duration {
+	if r.maxLatency <= r.minLatency {
+		return r.minLatency
+	}
+	return r.minLatency + time.Duration(random()*float64(r.maxLatency-r.minLatency))
+}
+
+// chance returns true with the given percentage probability.
+func chance(percentage float64) bool {
+	return percentage > 0 && random()*100 < percentage
+}
+
+// resetConnection closes the client connection without a response. Where
+// possible, the connection is closed with a TCP reset rather than an
+// orderly shutdown.
+func resetConnection(w http.ResponseWriter) {
+	conn, _, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		// abort the response, which closes the connection
+		panic(http.ErrAbortHandler)
+	}
+	if tcpConn, ok := conn.(*net.TCPConn); ok {
+		_ = tcpConn.SetLinger(0)
+	}
+	_ = conn.Close()
+}
+
+// writeResponse sends the buffered response to the client. If truncate is
+// true, only half of the body is sent, after which the connection is closed
+// because the body is shorter than its declared length. If bytesPerSecond
+// is greater than zero, the body is sent in chunks at that rate.
+func writeResponse(w http.ResponseWriter, buffered *bufferedWriter, truncate bool, bytesPerSecond int) {
+	for name, values := range buffered.header {
+		w.Header()[name] = values
+	}
+	body := buffered.body.Bytes()
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	if truncate {
+		body = body[:len(body)/2]
+	}
+	w.WriteHeader(buffered.status)
+
+	if bytesPerSecond <= 0 {
+		_, _ = w.Write(body)
+		return
+	}
+	controller := http.NewResponseController(w)
+	chunkSize := max(bytesPerSecond/int(time.Second/throttleInterval), 1)
+	for offset := 0; offset < len(body); offset += chunkSize {
+		if offset > 0 {
+			time.Sleep(throttleInterval)
+		}
+		end := min(offset+chunkSize, len(body))
+		if _, err := w.Write(body[offset:end]); err != nil {
+			return
+		}
+		_ = controller.Flush()
+	}
+}
+
+// bufferedWriter holds a response so that it can be modified before it
+// is sent to the client.
+type bufferedWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newBufferedWriter() *bufferedWriter {
+	return &bufferedWriter{header: http.Header{}, status: http.StatusOK}
+}
+
+func (b *bufferedWriter) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedWriter) Write(data []byte) (int, error) {
+	return b.body.Write(data)
+}
+
+func (b *bufferedWriter) WriteHeader(statusCode int) {
+	b.status = statusCode
+}
@@ -0,0 +1,79 @@
+package chaos
+
+import (
+	"github.com/stretchr/testify/require"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMiddleware(t *testing.T) {
+	body := strings.Repeat("x", 100)
+	mock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(body))
+	})
+
+	tests := []struct {
+		name        string
+		rule        Rule
+		wantStatus  int
+		wantBody    string
+		wantErr     bool
+		wantLatency time.Duration
+	}{
+		{name: "no faults", rule: Rule{Path: "/other"}, wantStatus: http.StatusCreated, wantBody: body},
+		{name: "latency", rule: Rule{Latency: "200ms"}, wantStatus: http.StatusCreated, wantBody: body, wantLatency: 200 * time.Millisecond},
+		{name: "error", rule: Rule{ErrorRate: 100, ErrorStatus: 502}, wantStatus: http.StatusBadGateway, wantBody: "fault injected by imposter\n"},
+		{name: "reset", rule: Rule{ResetRate: 100}, wantErr: true},
+		{name: "truncate", rule: Rule{TruncateRate: 100}, wantStatus: http.StatusCreated, wantErr: true},
+		{name: "bandwidth", rule: Rule{Bandwidth: "400B"}, wantStatus: http.StatusCreated, wantBody: body, wantLatency: 200 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := &Profile{Rules: []Rule{tt.rule}}
+			require.NoError(t, profile.init())
+			server := httptest.NewServer(Middleware(profile, mock))
+			defer server.Close()
+
+			startTime := time.Now()
+			resp, err := http.Get(server.URL + "/pets")
+			if err != nil {
+				require.True(t, tt.wantErr, "unexpected error: %v", err)
+				return
+			}
+			defer resp.Body.Close()
+			require.Equal(t, tt.wantStatus, resp.StatusCode)
+
+			respBody, err := io.ReadAll(resp.Body)
+			if tt.wantErr {
+				require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+				require.Equal(t, body[:50], string(respBody))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantBody, string(respBody))
+			require.GreaterOrEqual(t, time.Since(startTime), tt.wantLatency)
+		})
+	}
+}
+
+func TestRule_delay(t *testing.T) {
+	random = func() float64 { return 0.5 }
+	t.Cleanup(func() {
+		random = rand.Float64
+	})
+
+	rule := Rule{Latency: "100ms-300ms"}
+	require.NoError(t, rule.init())
+	require.Equal(t, 200*time.Millisecond, rule.delay())
+
+	require.True(t, chance(60))
+	require.False(t, chance(40))
+	require.False(t, chance(0))
+}
@@ -0,0 +1,169 @@
+package chaos
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"sigs.k8s.io/yaml"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultErrorStatus = http.StatusServiceUnavailable
+
+// Profile holds the rules for injecting faults into responses. The first
+// rule matching a request is applied to it.
+type Profile struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Rule describes the faults injected into responses to matching requests.
+type Rule struct {
+	// Path is a glob pattern, in which `*` matches a single path segment
+	// and a trailing `**` matches the remainder of the path. If empty, all
+	// paths match.
+	Path string `json:"path,omitempty"`
+
+	// Method matches requests with this method. If empty, all methods match.
+	Method string `json:"method,omitempty"`
+
+	// Latency is a fixed delay, such as `200ms`, or a range from which
+	// a random delay is chosen, such as `100ms-2s`.
+	Latency string `json:"latency,omitempty"`
+
+	// ErrorRate is the percentage of requests that receive an error
+	// response with ErrorStatus, instead of being passed to the mock.
+	ErrorRate   float64 `json:"errorRate,omitempty"`
+	ErrorStatus int     `json:"errorStatus,omitempty"`
+
+	// ResetRate is the percentage of requests whose connection is reset
+	// without a response.
+	ResetRate float64 `json:"resetRate,omitempty"`
+
+	// TruncateRate is the percentage of responses whose body is cut short
+	// by closing the connection half way through.
+	TruncateRate float64 `json:"truncateRate,omitempty"`
+
+	// Bandwidth limits the rate at which response bodies are sent, such
+	// as `10KB` per second. Supported units are B, KB and MB.
+	Bandwidth string `json:"bandwidth,omitempty"`
+
+	minLatency     time.Duration
+	maxLatency     time.Duration
+	bytesPerSecond int
+}
+
+// LoadProfile reads a profile from a YAML or JSON file.
+func LoadProfile(profilePath string) (*Profile, error) {
+	data, err := os.ReadFile(profilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chaos profile: %s: %v", profilePath, err)
+	}
+	var profile Profile
+	if err = yaml.Unmarshal(data, &profile); err != nil {
+		return nil, fmt.Errorf("failed to parse chaos profile: %s: %v", profilePath, err)
+	}
+	if err = profile.init(); err != nil {
+		return nil, fmt.Errorf("invalid chaos profile: %s: %v", profilePath, err)
+	}
+	return &profile, nil
+}
+
+func (p *Profile) init() error {
+	if len(p.Rules) == 0 {
+		return fmt.Errorf("no rules defined")
+	}
+	for i := range p.Rules {
+		if err := p.Rules[i].init(); err != nil {
+			return fmt.Errorf("rule %d: %v", i+1, err)
+		}
+	}
+	return nil
+}
+
+func (r *Rule) init() error {
+	var err error
+	if r.Latency != "" {
+		if r.minLatency, r.maxLatency, err = parseLatency(r.Latency); err != nil {
+			return err
+		}
+	}
+	if r.Bandwidth != "" {
+		if r.bytesPerSecond, err = parseBandwidth(r.Bandwidth); err != nil {
+			return err
+		}
+	}
+	rates := []struct {
+		name  string
+		value float64
+	}{{"errorRate", r.ErrorRate}, {"resetRate", r.ResetRate}, {"truncateRate", r.TruncateRate}}
+	for _, rate := range rates {
+		if rate.value < 0 || rate.value > 100 {
+			return fmt.Errorf("%s must be a percentage between 0 and 100: %v", rate.name, rate.value)
+		}
+	}
+	if r.ErrorStatus == 0 {
+		r.ErrorStatus = defaultErrorStatus
+	} else if r.ErrorStatus < 500 || r.ErrorStatus > 599 {
+		return fmt.Errorf("errorStatus must be a 5xx status code: %d", r.ErrorStatus)
+	}
+	return nil
+}
+
+func parseLatency(latency string) (minDelay time.Duration, maxDelay time.Duration, err error) {
+	lower, upper, isRange := strings.Cut(latency, "-")
+	if minDelay, err = time.ParseDuration(strings.TrimSpace(lower)); err != nil {
+		return 0, 0, fmt.Errorf("invalid latency: %s - must be a duration, such as 200ms, or a range, such as 100ms-2s", latency)
+	}
+	maxDelay = minDelay
+	if isRange {
+		if maxDelay, err = time.ParseDuration(strings.TrimSpace(upper)); err != nil || maxDelay < minDelay {
+			return 0, 0, fmt.Errorf("invalid latency range: %s", latency)
+		}
+	}
+	return minDelay, maxDelay, nil
+}
+
+func parseBandwidth(bandwidth string) (int, error) {
+	value := strings.ToUpper(strings.TrimSpace(bandwidth))
+	multiplier := 1
+	switch {
+	case strings.HasSuffix(value, "MB"):
+		multiplier = 1024 * 1024
+		value = strings.TrimSuffix(value, "MB")
+	case strings.HasSuffix(value, "KB"):
+		multiplier = 1024
+		value = strings.TrimSuffix(value, "KB")
+	default:
+		value = strings.TrimSuffix(value, "B")
+	}
+	amount, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || amount <= 0 {
+		return 0, fmt.Errorf("invalid bandwidth: %s - must be a number of bytes per second, such as 512B, 10KB or 1MB", bandwidth)
+	}
+	return amount * multiplier, nil
+}
+
+// Match returns the first rule matching the request, or nil.
+func (p *Profile) Match(method string, requestPath string) *Rule {
+	for i, r := range p.Rules {
+		if r.Method != "" && !strings.EqualFold(r.Method, method) {
+			continue
+		}
+		if r.Path != "" && !matchPath(r.Path, requestPath) {
+			continue
+		}
+		return &p.Rules[i]
+	}
+	return nil
+}
+
+func matchPath(pattern string, requestPath string) bool {
+	if prefix, found := strings.CutSuffix(pattern, "**"); found {
+		return strings.HasPrefix(requestPath, prefix)
+	}
+	matched, _ := path.Match(pattern, requestPath)
+	return matched
+}
@@ -0,0 +1,84 @@
+package chaos
+
+import (
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadProfile(t *testing.T) {
+	profilePath := writeProfile(t, `rules:
+  - path: /pets/*
+    method: GET
+    latency: 100ms-2s
+    errorRate: 10
+  - path: /orders/**
+    bandwidth: 10KB
+    truncateRate: 5
+    errorStatus: 500
+`)
+	profile, err := LoadProfile(profilePath)
+	require.NoError(t, err)
+	require.Len(t, profile.Rules, 2)
+
+	pets := profile.Rules[0]
+	require.Equal(t, 100*time.Millisecond, pets.minLatency)
+	require.Equal(t, 2*time.Second, pets.maxLatency)
+	require.Equal(t, defaultErrorStatus, pets.ErrorStatus)
+
+	orders := profile.Rules[1]
+	require.Equal(t, 10*1024, orders.bytesPerSecond)
+	require.Equal(t, 500, orders.ErrorStatus)
+}
+
+func TestLoadProfile_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "no rules", content: "rules: []", wantErr: "no rules defined"},
+		{name: "invalid latency", content: "rules: [{latency: soon}]", wantErr: "invalid latency: soon"},
+		{name: "inverted latency range", content: "rules: [{latency: 2s-1s}]", wantErr: "invalid latency range"},
+		{name: "invalid bandwidth", content: "rules: [{bandwidth: 10GB}]", wantErr: "invalid bandwidth"},
+		{name: "invalid rate", content: "rules: [{resetRate: 150}]", wantErr: "rule 1: resetRate must be a percentage"},
+		{name: "invalid status", content: "rules: [{errorStatus: 404}]", wantErr: "errorStatus must be a 5xx status code"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadProfile(writeProfile(t, tt.content))
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_parseBandwidth(t *testing.T) {
+	tests := map[string]int{"512": 512, "512B": 512, "10KB": 10 * 1024, "1mb": 1024 * 1024}
+	for bandwidth, want := range tests {
+		got, err := parseBandwidth(bandwidth)
+		require.NoError(t, err, bandwidth)
+		require.Equal(t, want, got, bandwidth)
+	}
+}
+
+func TestProfile_Match(t *testing.T) {
+	profile := &Profile{Rules: []Rule{
+		{Path: "/pets/*", Method: "get", Latency: "1s"},
+		{Path: "/orders/**"},
+		{Method: "DELETE"},
+	}}
+
+	require.Equal(t, "1s", profile.Match("GET", "/pets/1").Latency)
+	require.Nil(t, profile.Match("GET", "/pets/1/owner"), "* should match a single segment")
+	require.Nil(t, profile.Match("POST", "/pets/1"))
+	require.Equal(t, "/orders/**", profile.Match("POST", "/orders/1/items").Path)
+	require.Equal(t, "DELETE", profile.Match("DELETE", "/pets/1").Method)
+}
+
+func writeProfile(t *testing.T, content string) string {
+	profilePath := filepath.Join(t.TempDir(), "chaos.yaml")
+	require.NoError(t, os.WriteFile(profilePath, []byte(content), 0644))
+	return profilePath
+}
@@ -20,9 +20,14 @@ import (
 	"fmt"
 	"gatehill.io/imposter/config"
 	"gatehill.io/imposter/engine"
+	"gatehill.io/imposter/lockfile"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -30,6 +35,11 @@ var bundleFlags = struct {
 	engineType    string
 	engineVersion string
 	output        string
+	frozen        bool
+	platforms     []string
+	labels        []string
+	environment   []string
+	oci           bool
 }{}
 
 // bundleCmd represents the bundle command
@@ -42,6 +52,22 @@ appropriate for the specified engine type.
 For example, a Docker image for the Docker engine type, or a ZIP file
 for the AWS Lambda engine type.
 
+Golang bundles are archives holding the engine binary, the configuration
+files and a start script, so the mock can be run without Docker or a JVM.
+Use --platform to bundle the binary for another OS and architecture, such
+as windows/amd64. Bundles for Windows are ZIP files, otherwise they are
+gzipped tarballs.
+
+JVM bundles, for the 'jvm' and 'unpacked' engine types, are ZIP files
+holding the engine JAR or distribution, the configured plugins, the
+configuration files and run.sh and run.bat launcher scripts. A Java runtime
+is found in the PATH or JAVA_HOME when the launcher runs.
+
+Docker images include the configured plugins, and the environment variables
+under the 'env' key in the .imposter.yaml file as defaults. Pass --oci to
+write an OCI image layout tarball instead, which does not need the Docker
+daemon and can hold images for multiple platforms.
+
 If CONFIG_DIR is not specified, the current working directory is used.`,
 	Args: cobra.RangeArgs(0, 1),
 	Run: func(cmd *cobra.Command, args []string) {
@@ -57,44 +83,109 @@ If CONFIG_DIR is not specified, the current working directory is used.`,
 
 		// Search for CLI config files in the mock config dir.
 		config.MergeCliConfigIfExists(configDir)
+		if bundleFlags.frozen {
+			viper.Set("lock.frozen", true)
+		}
 
 		engineType := engine.GetConfiguredType(bundleFlags.engineType)
 		lib := engine.GetLibrary(engineType)
 
-		if lib.IsSealedDistro() {
+		// the unpacked distribution is bundled as-is
+		if lib.IsSealedDistro() && engineType != engine.EngineTypeJvmUnpacked {
 			logger.Fatal("cannot bundle a sealed distribution")
 		}
 
-		version := engine.GetConfiguredVersion(bundleFlags.engineVersion, true)
+		options := buildBundleOptions(engineType)
+
+		var version string
+		if !lib.IsSealedDistro() {
+			// only resolve version if not a sealed distro, to avoid prefs write
+			lock, lockedVersion, err := lockfile.ResolveVersion(configDir, engineType, bundleFlags.engineVersion, true)
+			if err != nil {
+				logger.Fatal(err)
+			}
+			if err = lockfile.VerifyArtifacts(configDir, lock, engineType, lockedVersion); err != nil {
+				logger.Fatal(err)
+			}
+			version = lockedVersion
+		}
 
-		bundle(&lib, version, configDir, getBundleDest(engineType))
+		bundle(&lib, version, configDir, getBundleDest(engineType, options), options)
 	},
 }
 
 func init() {
-	bundleCmd.Flags().StringVarP(&bundleFlags.output, "output", "o", "", "The destination to write the bundle to. If using the 'docker' engine type, this must be a valid image name. Otherwise, this must be a path to a writeable file. If not specified, a name is generated.")
-	bundleCmd.Flags().StringVarP(&bundleFlags.engineType, "engine-type", "t", "", "Imposter engine type (valid: awslambda,docker,jvm)")
+	bundleCmd.Flags().StringVarP(&bundleFlags.output, "output", "o", "", "The destination to write the bundle to. If using the 'docker' engine type without --oci, this must be a valid image name. Otherwise, this must be a path to a writeable file. If not specified, a name is generated.")
+	bundleCmd.Flags().StringVarP(&bundleFlags.engineType, "engine-type", "t", "", "Imposter engine type (valid: awslambda,docker,golang,jvm,unpacked)")
 	bundleCmd.Flags().StringVarP(&bundleFlags.engineVersion, "version", "v", "", "Imposter engine version (default \"latest\")")
+	bundleCmd.Flags().BoolVar(&bundleFlags.frozen, "frozen", false, "Fail if the lockfile is missing or does not match the configuration")
+	bundleCmd.Flags().StringSliceVar(&bundleFlags.platforms, "platform", nil, "(Docker and golang engine types only) Target platforms (e.g. linux/amd64,linux/arm64 - multiple platforms require --oci)")
+	bundleCmd.Flags().StringArrayVar(&bundleFlags.labels, "label", nil, "(Docker engine type only) Label to add to the image in the form KEY=VALUE")
+	bundleCmd.Flags().StringArrayVarP(&bundleFlags.environment, "env", "e", []string{}, "(Not supported for the awslambda engine type) Default environment variables to set in the image or launcher script")
+	bundleCmd.Flags().BoolVar(&bundleFlags.oci, "oci", false, "(Docker engine type only) Write an OCI image layout tarball to the output path, without using the Docker daemon")
 
 	_ = bundleCmd.MarkFlagRequired("engine-type")
-	registerEngineTypeCompletions(bundleCmd, engine.EngineTypeAwsLambda)
+	registerEngineTypeCompletions(bundleCmd, engine.EngineTypeAwsLambda, engine.EngineTypeJvmUnpacked)
 	rootCmd.AddCommand(bundleCmd)
 }
 
-func getBundleDest(engineType engine.EngineType) string {
+// buildBundleOptions returns the options for the bundle, validating they
+// apply to the engine type.
+func buildBundleOptions(engineType engine.EngineType) engine.BundleOptions {
+	options := engine.BundleOptions{
+		Platforms: bundleFlags.platforms,
+		OCI:       bundleFlags.oci,
+	}
+	switch {
+	case isDockerEngineType(engineType):
+		// all options are supported
+	case engineType == engine.EngineTypeGolang:
+		if len(bundleFlags.labels) > 0 || bundleFlags.oci {
+			logger.Fatalf("--label and --oci are only supported for Docker engine types")
+		}
+	case engineType == engine.EngineTypeJvmSingleJar || engineType == engine.EngineTypeJvmUnpacked:
+		if len(bundleFlags.platforms) > 0 || len(bundleFlags.labels) > 0 || bundleFlags.oci {
+			logger.Fatalf("--platform, --label and --oci are not supported for JVM engine types")
+		}
+	default:
+		if len(bundleFlags.platforms) > 0 || len(bundleFlags.labels) > 0 || len(bundleFlags.environment) > 0 || bundleFlags.oci {
+			logger.Fatalf("--platform, --label, --env and --oci are not supported for the %s engine type", engineType)
+		}
+		return options
+	}
+	options.Environment = buildStartEnvironment(bundleFlags.environment)
+	sort.Strings(options.Environment)
+	if len(bundleFlags.labels) > 0 {
+		options.Labels = make(map[string]string)
+		for _, label := range bundleFlags.labels {
+			key, value, found := strings.Cut(label, "=")
+			if !found || key == "" {
+				logger.Fatalf("invalid label: %s - must be in the form KEY=VALUE", label)
+			}
+			options.Labels[key] = value
+		}
+	}
+	return options
+}
+
+func getBundleDest(engineType engine.EngineType, options engine.BundleOptions) string {
 	var dest string
 	if bundleFlags.output != "" {
 		dest = bundleFlags.output
 	} else {
-		if engineType == engine.EngineTypeDockerCore ||
-			engineType == engine.EngineTypeDockerAll ||
-			engineType == engine.EngineTypeDockerDistroless {
+		if isDockerEngineType(engineType) && !options.OCI {
 
 			imageTag := time.Now().Format("20060102150405")
 			dest = "imposter-bundle:" + imageTag
 
 		} else {
-			temp, err := os.CreateTemp(os.TempDir(), "imposter-bundle-*.zip")
+			pattern := "imposter-bundle-*.zip"
+			if options.OCI {
+				pattern = "imposter-bundle-*.tar"
+			} else if engineType == engine.EngineTypeGolang && !isWindowsPlatform(options.Platforms) {
+				pattern = "imposter-bundle-*.tar.gz"
+			}
+			temp, err := os.CreateTemp(os.TempDir(), pattern)
 			if err != nil {
 				logger.Fatal(fmt.Errorf("failed to create temporary file: %w", err))
 			}
@@ -105,16 +196,27 @@ func getBundleDest(engineType engine.EngineType) string {
 	return dest
 }
 
-func bundle(lib *engine.EngineLibrary, version string, configDir string, dest string) {
+// isWindowsPlatform returns true if the bundle targets Windows, either
+// explicitly or because no platform is specified and the host is Windows.
+func isWindowsPlatform(platforms []string) bool {
+	if len(platforms) == 0 {
+		return runtime.GOOS == "windows"
+	}
+	return strings.HasPrefix(platforms[0], "windows/")
+}
+
+func bundle(lib *engine.EngineLibrary, version string, configDir string, dest string, options engine.BundleOptions) {
 	provider := (*lib).GetProvider(version)
 	logger.Debugf("creating %s bundle %s using version %s", provider.GetEngineType(), configDir, version)
 
-	err := provider.Provide(engine.PullIfNotPresent)
-	if err != nil {
-		logger.Fatal(err)
+	// OCI bundles are built from the engine image in its registry
+	if !options.OCI {
+		if err := provider.Provide(engine.PullIfNotPresent); err != nil {
+			logger.Fatal(err)
+		}
 	}
 
-	err = provider.Bundle(configDir, dest)
+	err := provider.Bundle(configDir, dest, options)
 	if err != nil {
 		logger.Fatal(err)
 	}
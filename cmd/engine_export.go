@@ -0,0 +1,68 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"gatehill.io/imposter/engine"
+	"github.com/spf13/cobra"
+)
+
+var engineExportFlags = struct {
+	engineTypes   []string
+	engineVersion string
+	plugins       []string
+	output        string
+}{}
+
+// engineExportCmd represents the engineExport command
+var engineExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export engines and plugins to an archive",
+	Long: `Exports the specified engines and plugins from the cache to a single
+archive, for import on a machine without network access.
+
+Engines and plugins that are not in the cache are pulled first.
+If version is not specified, it defaults to 'latest'.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		version := engine.GetConfiguredVersion(engineExportFlags.engineVersion, true)
+
+		var engineTypes []engine.EngineType
+		for _, engineType := range engineExportFlags.engineTypes {
+			engineTypes = append(engineTypes, engine.EngineType(engineType))
+		}
+
+		outputFile := engineExportFlags.output
+		if outputFile == "" {
+			outputFile = fmt.Sprintf("imposter-engines-%s.tar.gz", version)
+		}
+		manifest, err := engine.ExportArchive(engineTypes, version, engineExportFlags.plugins, outputFile)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		logger.Infof("exported %d file(s) for engine version %s to %s", len(manifest.Files), version, outputFile)
+	},
+}
+
+func init() {
+	engineExportCmd.Flags().StringSliceVar(&engineExportFlags.engineTypes, "types", []string{string(engine.EngineTypeJvmSingleJar)}, "Engine types to export (valid: jvm,golang)")
+	engineExportCmd.Flags().StringVarP(&engineExportFlags.engineVersion, "version", "v", "", "Imposter engine version (default \"latest\")")
+	engineExportCmd.Flags().StringSliceVar(&engineExportFlags.plugins, "plugins", nil, "Plugins to export")
+	engineExportCmd.Flags().StringVarP(&engineExportFlags.output, "output", "o", "", "Path to the archive (default \"imposter-engines-VERSION.tar.gz\")")
+	engineCmd.AddCommand(engineExportCmd)
+}
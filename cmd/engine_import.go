@@ -0,0 +1,45 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"gatehill.io/imposter/engine"
+	"github.com/spf13/cobra"
+)
+
+// engineImportCmd represents the engineImport command
+var engineImportCmd = &cobra.Command{
+	Use:   "import ARCHIVE",
+	Short: "Import engines and plugins from an archive",
+	Long: `Imports the engines and plugins in an archive created by
+'imposter engine export' into the cache.
+
+No network access is required.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		manifest, err := engine.ImportArchive(args[0])
+		if err != nil {
+			logger.Fatal(err)
+		}
+		logger.Infof("imported %d file(s) for engine version %s", len(manifest.Files), manifest.Version)
+		logger.Infof("to use this version without network access, set the version to %s, for example with IMPOSTER_VERSION=%s", manifest.Version, manifest.Version)
+	},
+}
+
+func init() {
+	engineCmd.AddCommand(engineImportCmd)
+}
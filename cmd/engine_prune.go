@@ -0,0 +1,153 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"gatehill.io/imposter/engine"
+	"gatehill.io/imposter/plugin"
+	"github.com/spf13/cobra"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var enginePruneFlags = struct {
+	engineType          string
+	keep                int
+	olderThan           string
+	dryRun              bool
+	includePlugins      bool
+	includeDockerImages bool
+}{}
+
+// enginePruneCmd represents the enginePrune command
+var enginePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove old engines from the cache",
+	Long: `Removes old versions of engine binaries from the cache, and optionally
+plugins and Docker images.
+
+At least one of --keep or --older-than must be specified. If both are
+specified, only versions matching both are removed. The age of a Docker
+image is the time it was created.
+
+If engine type is not specified, it defaults to all.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		keep := -1
+		if cmd.Flags().Changed("keep") {
+			keep = enginePruneFlags.keep
+			if keep < 0 {
+				logger.Fatalf("--keep must not be negative")
+			}
+		}
+		var olderThan time.Duration
+		if enginePruneFlags.olderThan != "" {
+			var err error
+			if olderThan, err = parseAge(enginePruneFlags.olderThan); err != nil {
+				logger.Fatal(err)
+			}
+		}
+		if keep < 0 && olderThan == 0 {
+			logger.Fatalf("at least one of --keep or --older-than must be specified")
+		}
+
+		// unspecified type is valid
+		engineType := engine.GetConfiguredTypeWithDefault(enginePruneFlags.engineType, engine.EngineTypeNone)
+		var engineTypes []engine.EngineType
+		if engine.EngineTypeNone == engineType {
+			for _, t := range engine.EnumerateLibraries() {
+				if enginePruneFlags.includeDockerImages || !isDockerEngineType(t) {
+					engineTypes = append(engineTypes, t)
+				}
+			}
+		} else {
+			engineTypes = []engine.EngineType{engineType}
+		}
+		pruneEngines(engineTypes, keep, olderThan, enginePruneFlags.includePlugins, enginePruneFlags.dryRun)
+	},
+}
+
+func init() {
+	enginePruneCmd.Flags().StringVarP(&enginePruneFlags.engineType, "engine-type", "t", "", "Imposter engine type (valid: docker,jvm,golang - default is all")
+	enginePruneCmd.Flags().IntVar(&enginePruneFlags.keep, "keep", 0, "Number of most recent versions to keep per engine type")
+	enginePruneCmd.Flags().StringVar(&enginePruneFlags.olderThan, "older-than", "", "Only remove versions older than this age (e.g. 30d, 12h)")
+	enginePruneCmd.Flags().BoolVar(&enginePruneFlags.dryRun, "dry-run", false, "Show what would be removed without removing anything")
+	enginePruneCmd.Flags().BoolVar(&enginePruneFlags.includePlugins, "include-plugins", false, "Also remove old plugin versions")
+	enginePruneCmd.Flags().BoolVar(&enginePruneFlags.includeDockerImages, "include-docker-images", false, "Also remove old Docker engine images")
+	registerEngineTypeCompletions(enginePruneCmd)
+	engineCmd.AddCommand(enginePruneCmd)
+}
+
+func pruneEngines(engineTypes []engine.EngineType, keep int, olderThan time.Duration, includePlugins bool, dryRun bool) {
+	usage := collectEngineUsage(engineTypes)
+	if includePlugins {
+		usage = append(usage, collectPluginUsage()...)
+	}
+	selected := engine.SelectForPruning(usage, keep, olderThan, time.Now())
+	if len(selected) == 0 {
+		logger.Info("nothing to prune")
+		return
+	}
+
+	renderUsage(selected)
+	if dryRun {
+		logger.Infof("dry run - %d version(s) would be removed", len(selected))
+		return
+	}
+
+	removed := 0
+	for _, e := range selected {
+		var err error
+		if e.EngineType == usageTypePlugins {
+			err = plugin.RemoveVersionDir(e.Version)
+		} else {
+			err = engine.GetLibrary(e.EngineType).(engine.PrunableLibrary).Remove(e.Version)
+		}
+		if err != nil {
+			logger.Warnf("failed to remove %s version %s: %v", e.EngineType, e.Version, err)
+			continue
+		}
+		logger.Debugf("removed %s version %s", e.EngineType, e.Version)
+		removed++
+	}
+	logger.Infof("removed %d version(s)", removed)
+}
+
+func isDockerEngineType(engineType engine.EngineType) bool {
+	return engineType == engine.EngineTypeDockerCore ||
+		engineType == engine.EngineTypeDockerAll ||
+		engineType == engine.EngineTypeDockerDistroless
+}
+
+// parseAge parses a duration, additionally supporting a number
+// of days with the 'd' suffix, such as '30d'.
+func parseAge(age string) (time.Duration, error) {
+	if days, found := strings.CutSuffix(age, "d"); found {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid age: %s", age)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(age)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid age: %s", age)
+	}
+	return d, nil
+}
@@ -0,0 +1,153 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"gatehill.io/imposter/engine"
+	"gatehill.io/imposter/fileutil"
+	"gatehill.io/imposter/plugin"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"os"
+	"sort"
+)
+
+// usageTypePlugins and usageTypeFileCache label the plugin and file
+// cache directories in disk usage reports.
+const (
+	usageTypePlugins   engine.EngineType = "plugins"
+	usageTypeFileCache engine.EngineType = "filecache"
+)
+
+var engineUsageFlags = struct {
+	engineType string
+}{}
+
+// engineUsageCmd represents the engineUsage command
+var engineUsageCmd = &cobra.Command{
+	Use:     "usage",
+	Aliases: []string{"du"},
+	Short:   "Show disk usage of cached engines",
+	Long: `Shows the disk space used by each version of the engine binaries/images,
+plugins and the file cache.
+
+If engine type is not specified, it defaults to all.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		// unspecified type is valid
+		engineType := engine.GetConfiguredTypeWithDefault(engineUsageFlags.engineType, engine.EngineTypeNone)
+
+		var usage []engine.CachedEngine
+		if engine.EngineTypeNone == engineType {
+			usage = collectEngineUsage(engine.EnumerateLibraries())
+			usage = append(usage, collectPluginUsage()...)
+			usage = append(usage, collectFileCacheUsage()...)
+		} else {
+			usage = collectEngineUsage([]engine.EngineType{engineType})
+		}
+		renderUsage(usage)
+	},
+}
+
+func init() {
+	engineUsageCmd.Flags().StringVarP(&engineUsageFlags.engineType, "engine-type", "t", "", "Imposter engine type (valid: docker,jvm,golang - default is all")
+	registerEngineTypeCompletions(engineUsageCmd)
+	engineCmd.AddCommand(engineUsageCmd)
+}
+
+// collectEngineUsage lists the engine versions held locally for each of
+// the given engine types that supports it.
+func collectEngineUsage(engineTypes []engine.EngineType) []engine.CachedEngine {
+	sort.Slice(engineTypes, func(i, j int) bool {
+		return engineTypes[i] < engineTypes[j]
+	})
+	var usage []engine.CachedEngine
+	for _, engineType := range engineTypes {
+		library, ok := engine.GetLibrary(engineType).(engine.PrunableLibrary)
+		if !ok {
+			logger.Tracef("engine type %s does not report disk usage", engineType)
+			continue
+		}
+		engines, err := library.ListUsage()
+		if err != nil {
+			// such as the Docker daemon not running
+			logger.Debugf("could not list %s engines: %v", engineType, err)
+			continue
+		}
+		usage = append(usage, engines...)
+	}
+	return usage
+}
+
+func collectPluginUsage() []engine.CachedEngine {
+	basePluginDir, err := plugin.GetBasePluginDir()
+	if err != nil {
+		logger.Fatal(err)
+	}
+	usage, err := engine.ListCachedVersionDirs(usageTypePlugins, basePluginDir, nil)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	return usage
+}
+
+func collectFileCacheUsage() []engine.CachedEngine {
+	fileCacheDir, err := engine.EnsureFileCacheDir()
+	if err != nil {
+		logger.Fatal(err)
+	}
+	size, modified, err := fileutil.DirUsage(fileCacheDir)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	return []engine.CachedEngine{{
+		EngineMetadata: engine.EngineMetadata{EngineType: usageTypeFileCache, Version: "-"},
+		Size:           size,
+		Modified:       modified,
+	}}
+}
+
+func renderUsage(usage []engine.CachedEngine) {
+	var total int64
+	var rows [][]string
+	for _, e := range usage {
+		total += e.Size
+		rows = append(rows, []string{string(e.EngineType), e.Version, formatSize(e.Size), e.Modified.Format("2006-01-02 15:04")})
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Type", "Version", "Size", "Last Modified"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	table.AppendBulk(rows)
+	table.Render()
+	logger.Infof("total disk usage: %s", formatSize(total))
+}
+
+// formatSize returns a human-readable representation of a number of bytes.
+func formatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
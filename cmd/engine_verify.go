@@ -0,0 +1,91 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"gatehill.io/imposter/library"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"os"
+	"strconv"
+)
+
+// engineVerifyCmd represents the engineVerify command
+var engineVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify the checksums of cached downloads",
+	Long: `Re-checks the SHA-256 hash of each downloaded engine binary and plugin
+against the hash recorded when it was downloaded.
+
+Files that no longer match are moved to the quarantine directory.
+Records for files that have been removed from the cache are discarded.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if mismatches := verifyDownloads(); mismatches > 0 {
+			logger.Fatalf("%d file(s) failed verification", mismatches)
+		}
+	},
+}
+
+func init() {
+	engineCmd.AddCommand(engineVerifyCmd)
+}
+
+// verifyDownloads checks each recorded download, returning the number
+// of files that failed verification.
+func verifyDownloads() int {
+	records, err := library.ListChecksumRecords()
+	if err != nil {
+		logger.Fatal(err)
+	}
+	if len(records) == 0 {
+		logger.Info("no downloads recorded")
+		return 0
+	}
+
+	mismatches := 0
+	var rows [][]string
+	for _, record := range records {
+		status, err := library.VerifyChecksumRecord(record)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		switch status {
+		case library.ChecksumMismatch:
+			mismatches++
+			if quarantinePath, err := library.QuarantineFile(record.Path); err != nil {
+				logger.Warn(err)
+			} else {
+				logger.Warnf("quarantined %s to %s", record.Path, quarantinePath)
+			}
+		case library.ChecksumMissing:
+			if err := library.ForgetChecksum(record.Path); err != nil {
+				logger.Warn(err)
+			}
+		}
+		rows = append(rows, []string{record.Path, string(status), strconv.FormatBool(record.Verified)})
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Path", "Status", "Verified"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	table.AppendBulk(rows)
+	table.Render()
+
+	return mismatches
+}
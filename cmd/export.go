@@ -0,0 +1,159 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"gatehill.io/imposter/config"
+	"gatehill.io/imposter/engine"
+	"gatehill.io/imposter/engine/docker"
+	"gatehill.io/imposter/export"
+	"gatehill.io/imposter/fileutil"
+	"gatehill.io/imposter/library"
+	"gatehill.io/imposter/lockfile"
+	"gatehill.io/imposter/plugin"
+	"github.com/spf13/cobra"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+var exportFlags = struct {
+	engineType     string
+	engineVersion  string
+	image          string
+	name           string
+	port           int
+	environment    []string
+	output         string
+	forceOverwrite bool
+}{}
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export deployment manifests for a mock",
+	Long: `Exports the manifests to deploy a mock, such as a Docker Compose file,
+Kubernetes manifests or a Helm chart.
+
+The manifests use the engine image for the resolved version, and include
+the environment variables and plugins from the .imposter.yaml file in the
+config dir, as well as a health check on the status endpoint.`,
+}
+
+func init() {
+	exportCmd.PersistentFlags().StringVarP(&exportFlags.engineType, "engine-type", "t", "", "Imposter engine type (valid: docker,docker-all,docker-distroless - default \"docker\")")
+	exportCmd.PersistentFlags().StringVarP(&exportFlags.engineVersion, "version", "v", "", "Imposter engine version (default \"latest\")")
+	exportCmd.PersistentFlags().StringVar(&exportFlags.image, "image", "", "Image containing the configuration, such as one built with 'imposter bundle', instead of mounting it")
+	exportCmd.PersistentFlags().StringVar(&exportFlags.name, "name", "", "Name of the service (default: the name of the config dir)")
+	exportCmd.PersistentFlags().IntVarP(&exportFlags.port, "port", "p", 8080, "Port on which the mock listens")
+	exportCmd.PersistentFlags().StringArrayVarP(&exportFlags.environment, "env", "e", []string{}, "Explicit environment variables to set")
+	exportCmd.PersistentFlags().BoolVarP(&exportFlags.forceOverwrite, "force-overwrite", "f", false, "Force overwrite of destination file(s) if already exist")
+	registerEngineTypeCompletions(exportCmd)
+	rootCmd.AddCommand(exportCmd)
+}
+
+// buildExportProject resolves the engine image, environment and plugins
+// for the mock in the config dir. If installPlugins is true, the plugins
+// are installed, so their files can be mounted.
+func buildExportProject(args []string, installPlugins bool) export.Project {
+	var configDir string
+	if len(args) == 0 {
+		configDir, _ = os.Getwd()
+	} else {
+		configDir, _ = filepath.Abs(args[0])
+	}
+	if err := config.ValidateConfigExists(configDir, false); err != nil {
+		logger.Fatal(err)
+	}
+
+	// Search for CLI config files in the mock config dir.
+	config.MergeCliConfigIfExists(configDir)
+
+	engineType := lockfile.GetConfiguredType(configDir, exportFlags.engineType)
+	if !isDockerEngineType(engineType) {
+		if exportFlags.engineType != "" {
+			logger.Fatalf("unsupported engine type for export: %s - must be a Docker engine type", engineType)
+		}
+		logger.Debugf("configured engine type %s is not a Docker engine type - using %s", engineType, engine.EngineTypeDockerCore)
+		engineType = engine.EngineTypeDockerCore
+	}
+	_, version, err := lockfile.ResolveVersion(configDir, engineType, exportFlags.engineVersion, true)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	project := export.Project{
+		Name:       export.SanitiseName(filepath.Base(configDir)),
+		ConfigDir:  configDir,
+		Image:      docker.GetImage(engineType, version),
+		Distroless: engineType == engine.EngineTypeDockerDistroless,
+		Port:       exportFlags.port,
+		Env:        buildStartEnvironment(exportFlags.environment),
+	}
+	sort.Strings(project.Env)
+	if exportFlags.name != "" {
+		project.Name = export.SanitiseName(exportFlags.name)
+	}
+	if exportFlags.image != "" {
+		project.Image = exportFlags.image
+		project.Baked = true
+	}
+
+	if project.Baked {
+		// bundle images include the plugins
+		return project
+	}
+	plugins := plugin.ListMockPlugins(configDir)
+	if installPlugins {
+		if _, err := plugin.EnsurePlugins(plugins, version, false); err != nil {
+			logger.Fatal(err)
+		}
+	}
+	for _, pluginName := range plugins {
+		pluginFilePath, err := plugin.GetPluginFilePath(pluginName, version)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		fileName := filepath.Base(pluginFilePath)
+		p := export.Plugin{
+			FileName: fileName,
+			Url:      library.GetDownloadUrl(fileName, version),
+		}
+		if installPlugins {
+			// linked plugins are mounted from the file they point to
+			if p.LocalPath, err = filepath.EvalSymlinks(pluginFilePath); err != nil {
+				logger.Fatalf("failed to resolve plugin file: %s: %v", pluginFilePath, err)
+			}
+		}
+		project.Plugins = append(project.Plugins, p)
+	}
+	return project
+}
+
+// writeExport writes the manifest to the output file, or stdout if the
+// output file is not set.
+func writeExport(manifest []byte, outputFile string) {
+	if outputFile == "" {
+		_, _ = os.Stdout.Write(manifest)
+		return
+	}
+	fileutil.MustNotExist(outputFile, exportFlags.forceOverwrite)
+	if err := os.WriteFile(outputFile, manifest, 0644); err != nil {
+		logger.Fatalf("failed to write manifest: %s: %v", outputFile, err)
+	}
+	logger.Infof("wrote manifest: %s", outputFile)
+}
@@ -0,0 +1,54 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"gatehill.io/imposter/export"
+	"github.com/spf13/cobra"
+	"os"
+	"path/filepath"
+)
+
+// exportComposeCmd represents the export compose command
+var exportComposeCmd = &cobra.Command{
+	Use:   "compose [CONFIG_DIR]",
+	Short: "Export a Docker Compose file for a mock",
+	Long: `Exports a Docker Compose file that runs the mock, mounting the
+configuration and plugin files, unless --image is set.
+
+If CONFIG_DIR is not specified, the current working directory is used.`,
+	Args: cobra.RangeArgs(0, 1),
+	Run: func(cmd *cobra.Command, args []string) {
+		project := buildExportProject(args, true)
+
+		// mounts are relative to the directory holding the Compose file
+		baseDir, _ := os.Getwd()
+		if exportFlags.output != "" {
+			baseDir, _ = filepath.Abs(filepath.Dir(exportFlags.output))
+		}
+		manifest, err := export.Compose(project, baseDir)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		writeExport(manifest, exportFlags.output)
+	},
+}
+
+func init() {
+	exportComposeCmd.Flags().StringVarP(&exportFlags.output, "output", "o", "", "File to write the Compose file to (default: stdout)")
+	exportCmd.AddCommand(exportComposeCmd)
+}
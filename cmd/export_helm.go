@@ -0,0 +1,51 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"gatehill.io/imposter/export"
+	"github.com/spf13/cobra"
+)
+
+// exportHelmCmd represents the export helm command
+var exportHelmCmd = &cobra.Command{
+	Use:   "helm [CONFIG_DIR]",
+	Short: "Export a Helm chart for a mock",
+	Long: `Exports a Helm chart that runs the mock. Unless --image is set, the
+configuration files are copied into the chart and held in a ConfigMap.
+Plugins are downloaded by an init container.
+
+If CONFIG_DIR is not specified, the current working directory is used.`,
+	Args: cobra.RangeArgs(0, 1),
+	Run: func(cmd *cobra.Command, args []string) {
+		project := buildExportProject(args, false)
+		chartDir := exportFlags.output
+		if chartDir == "" {
+			chartDir = project.Name
+		}
+		written, err := export.WriteHelmChart(project, chartDir, exportFlags.forceOverwrite)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		logger.Infof("wrote Helm chart with %d file(s): %s", len(written), chartDir)
+	},
+}
+
+func init() {
+	exportHelmCmd.Flags().StringVarP(&exportFlags.output, "output", "o", "", "Directory to write the chart to (default: the name of the service)")
+	exportCmd.AddCommand(exportHelmCmd)
+}
@@ -0,0 +1,48 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"gatehill.io/imposter/export"
+	"github.com/spf13/cobra"
+)
+
+// exportK8sCmd represents the export k8s command
+var exportK8sCmd = &cobra.Command{
+	Use:     "k8s [CONFIG_DIR]",
+	Aliases: []string{"kubernetes"},
+	Short:   "Export Kubernetes manifests for a mock",
+	Long: `Exports the Kubernetes manifests for a Deployment and Service that
+run the mock. Unless --image is set, the configuration files are held
+in a ConfigMap. Plugins are downloaded by an init container.
+
+If CONFIG_DIR is not specified, the current working directory is used.`,
+	Args: cobra.RangeArgs(0, 1),
+	Run: func(cmd *cobra.Command, args []string) {
+		project := buildExportProject(args, false)
+		manifest, err := export.Kubernetes(project)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		writeExport(manifest, exportFlags.output)
+	},
+}
+
+func init() {
+	exportK8sCmd.Flags().StringVarP(&exportFlags.output, "output", "o", "", "File to write the manifests to (default: stdout)")
+	exportCmd.AddCommand(exportK8sCmd)
+}
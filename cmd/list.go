@@ -67,7 +67,7 @@ func listMocks(engineType engine.EngineType, quiet bool) {
 		if quiet {
 			os.Stdout.WriteString(mock.ID + "\n")
 		} else {
-			rows = append(rows, []string{mock.ID, mock.Name, strconv.Itoa(mock.Port), string(mock.Health)})
+			rows = append(rows, []string{mock.ID, mock.Name, strconv.Itoa(mock.Port), mock.URL, string(mock.Health)})
 		}
 		if mock.Health != engine.MockHealthHealthy {
 			anyFailed = true
@@ -89,7 +89,7 @@ func listMocks(engineType engine.EngineType, quiet bool) {
 
 func renderMocks(rows [][]string) {
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"ID", "Name", "Port", "Health"})
+	table.SetHeader([]string{"ID", "Name", "Port", "URL", "Health"})
 	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
 	table.SetCenterSeparator("|")
 	table.AppendBulk(rows)
@@ -0,0 +1,86 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"gatehill.io/imposter/config"
+	"gatehill.io/imposter/engine"
+	"gatehill.io/imposter/lockfile"
+	"gatehill.io/imposter/plugin"
+	"github.com/spf13/cobra"
+	"os"
+	"path/filepath"
+)
+
+var lockFlags = struct {
+	engineType    string
+	engineVersion string
+}{}
+
+// lockCmd represents the lock command
+var lockCmd = &cobra.Command{
+	Use:   "lock [CONFIG_DIR]",
+	Short: "Lock engine and plugin versions",
+	Long: `Resolves the engine version and plugins for the configuration directory
+and records them, with the checksums of their files, in an imposter.lock file.
+
+The 'up', 'bundle' and 'remote deploy' commands use the versions in the
+lockfile. With the --frozen flag, they fail if the lockfile is missing or
+does not match the configuration.
+
+If CONFIG_DIR is not specified, the current working directory is used.`,
+	Args: cobra.RangeArgs(0, 1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var configDir string
+		if len(args) == 0 {
+			configDir, _ = os.Getwd()
+		} else {
+			configDir, _ = filepath.Abs(args[0])
+		}
+
+		// Search for CLI config files in the mock config dir.
+		config.MergeCliConfigIfExists(configDir)
+
+		engineType := engine.GetConfiguredType(lockFlags.engineType)
+		lib := engine.GetLibrary(engineType)
+		if lib.IsSealedDistro() {
+			logger.Fatal("cannot lock a sealed distribution")
+		}
+		version := engine.GetConfiguredVersionForType(lockFlags.engineVersion, engineType, true)
+
+		var plugins []string
+		if lib.ShouldEnsurePlugins() {
+			plugins = plugin.ListMockPlugins(configDir)
+		}
+		lock, err := lockfile.Generate(engineType, version, plugins)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		lockPath, err := lockfile.Save(configDir, lock)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		logger.Infof("locked %s engine version %s with %d plugin(s) in: %s", engineType, version, len(lock.Plugins), lockPath)
+	},
+}
+
+func init() {
+	lockCmd.Flags().StringVarP(&lockFlags.engineType, "engine-type", "t", "", "Imposter engine type (valid: docker,jvm,golang - default \"docker\")")
+	lockCmd.Flags().StringVarP(&lockFlags.engineVersion, "version", "v", "", "Imposter engine version (default \"latest\")")
+	registerEngineTypeCompletions(lockCmd)
+	rootCmd.AddCommand(lockCmd)
+}
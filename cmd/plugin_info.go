@@ -0,0 +1,102 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"gatehill.io/imposter/plugin"
+	"gatehill.io/imposter/stringutil"
+	"github.com/spf13/cobra"
+	"strings"
+)
+
+// pluginInfoCmd represents the pluginInfo command
+var pluginInfoCmd = &cobra.Command{
+	Use:   "info PLUGIN_NAME",
+	Short: "Show plugin details",
+	Long: `Shows the details of a plugin from the index of available plugins,
+and the engine versions for which it is installed.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		showPluginInfo(args[0])
+	},
+}
+
+func init() {
+	pluginCmd.AddCommand(pluginInfoCmd)
+}
+
+func showPluginInfo(pluginName string) {
+	index, err := plugin.LoadIndex(false)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	entry, found := index.Find(pluginName)
+	if !found {
+		if suggestion := index.Suggest(pluginName); suggestion != "" {
+			logger.Fatalf("unknown plugin: %s - did you mean %s?", pluginName, suggestion)
+		}
+		logger.Fatalf("unknown plugin: %s", pluginName)
+	}
+
+	engineVersions := entry.EngineVersions
+	if engineVersions == "" {
+		engineVersions = "any"
+	}
+	installed := findInstalledVersions(entry.Name)
+	if len(installed) == 0 {
+		installed = []string{"none"}
+	}
+	defaults, err := plugin.ListDefaultPlugins()
+	if err != nil {
+		logger.Warnf("failed to load default plugins: %s", err)
+	}
+	isDefault := stringutil.Contains(defaults, entry.Name) || stringutil.Contains(defaults, entry.InstallName())
+
+	fmt.Printf(`Name: %s
+Description: %s
+Engine versions: %s
+Installed for: %s
+Default plugin: %v
+Install: imposter plugin install %s
+`, entry.Name, entry.Description, engineVersions, strings.Join(installed, ", "), isDefault, entry.InstallName())
+}
+
+// findInstalledVersions returns the engine versions for which the
+// plugin is installed.
+func findInstalledVersions(pluginName string) []string {
+	versions, err := plugin.ListVersionDirs()
+	if err != nil {
+		logger.Debugf("could not list plugin versions: %s", err)
+		return nil
+	}
+	var installed []string
+	for _, version := range versions {
+		plugins, err := plugin.List(version)
+		if err != nil {
+			logger.Debugf("could not list plugins for version: %s: %s", version, err)
+			continue
+		}
+		for _, metadata := range plugins {
+			if metadata.Name == pluginName {
+				installed = append(installed, version)
+				break
+			}
+		}
+	}
+	return installed
+}
@@ -26,6 +26,7 @@ import (
 var pluginInstallFlags = struct {
 	engineVersion string
 	saveDefault   bool
+	link          bool
 }{}
 
 // pluginInstallCmd represents the pluginInstall command
@@ -42,17 +43,37 @@ Example 1: Install named plugin
 
 Example 2: Install all plugins in config file
 
-	imposter plugin install`,
+	imposter plugin install
+
+Example 3: Install plugin from a local file or URL
+
+	imposter plugin install ./target/my-plugin.jar
+	imposter plugin install https://example.com/my-plugin.zip
+
+Example 4: Link to a local plugin file, so it is used after each rebuild
+
+	imposter plugin install --link ./target/my-plugin.jar`,
 	Args: cobra.ArbitraryArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		version := engine.GetConfiguredVersion(pluginInstallFlags.engineVersion, true)
-		installPlugins(args, version, pluginInstallFlags.saveDefault)
+		var names []string
+		for _, arg := range args {
+			if plugin.IsLocalOrRemoteSource(arg) {
+				installPluginFromSource(arg, version, pluginInstallFlags.link, pluginInstallFlags.saveDefault)
+			} else {
+				names = append(names, arg)
+			}
+		}
+		if len(names) > 0 || len(args) == 0 {
+			installPlugins(names, version, pluginInstallFlags.saveDefault)
+		}
 	},
 }
 
 func init() {
 	pluginInstallCmd.Flags().StringVarP(&pluginInstallFlags.engineVersion, "version", "v", "", "Imposter engine version (default \"latest\")")
 	pluginInstallCmd.Flags().BoolVarP(&pluginInstallFlags.saveDefault, "save-default", "d", false, "Whether to save the plugin as a default")
+	pluginInstallCmd.Flags().BoolVar(&pluginInstallFlags.link, "link", false, "Link to a local plugin file instead of copying it")
 	pluginCmd.AddCommand(pluginInstallCmd)
 }
 
@@ -79,3 +100,13 @@ To change this behaviour, pass the --save-default (-d) flag.`, version))
 		logger.Infof("%d plugin(s) installed", ensured)
 	}
 }
+
+func installPluginFromSource(source string, version string, link bool, saveDefault bool) {
+	pluginName, err := plugin.InstallFromSource(source, version, link)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	if saveDefault {
+		logger.Warnf("plugin %s is installed from a file or URL, so cannot be saved as a default", pluginName)
+	}
+}
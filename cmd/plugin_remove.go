@@ -0,0 +1,91 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"gatehill.io/imposter/plugin"
+	"github.com/spf13/cobra"
+)
+
+var pluginRemoveFlags = struct {
+	engineVersion string
+}{}
+
+// pluginRemoveCmd represents the pluginRemove command
+var pluginRemoveCmd = &cobra.Command{
+	Use:     "remove PLUGIN_NAME",
+	Aliases: []string{"rm"},
+	Short:   "Remove a plugin",
+	Long: `Removes an installed plugin.
+
+If version is not specified, the plugin is removed for all engine
+versions, and from the default plugins, so it is not installed for
+future engine versions.
+
+If version is specified, the plugin is removed only for that engine
+version, and the default plugins are unchanged.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		removePlugin(args[0], pluginRemoveFlags.engineVersion)
+	},
+}
+
+func init() {
+	pluginRemoveCmd.Flags().StringVarP(&pluginRemoveFlags.engineVersion, "version", "v", "", "Only remove the plugin for a specific engine version (default all versions)")
+	pluginCmd.AddCommand(pluginRemoveCmd)
+}
+
+func removePlugin(pluginName string, version string) {
+	var versions []string
+	if version != "" {
+		versions = []string{version}
+	} else {
+		v, err := plugin.ListVersionDirs()
+		if err != nil {
+			logger.Fatal(err)
+		}
+		versions = v
+	}
+
+	var removed int
+	for _, v := range versions {
+		count, err := plugin.Remove(pluginName, v)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		if count > 0 {
+			logger.Debugf("removed plugin %s version %s", pluginName, v)
+			removed++
+		}
+	}
+
+	if version == "" {
+		wasDefault, err := plugin.RemoveDefaultPlugin(pluginName)
+		if err != nil {
+			logger.Fatalf("failed to remove default plugin: %s", err)
+		}
+		if wasDefault {
+			logger.Infof("removed %s from default plugins", pluginName)
+		}
+	}
+
+	if removed == 0 {
+		logger.Infof("plugin %s is not installed", pluginName)
+	} else {
+		logger.Infof("removed plugin %s for %d engine version(s)", pluginName, removed)
+	}
+}
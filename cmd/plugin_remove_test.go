@@ -0,0 +1,61 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"gatehill.io/imposter/plugin"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_removePlugin(t *testing.T) {
+	baseDir := t.TempDir()
+	viper.Set("plugin.baseDir", baseDir)
+	t.Cleanup(func() {
+		viper.Set("plugin.baseDir", "")
+	})
+	for _, version := range []string{"4.2.1", "4.2.2"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(baseDir, version), 0700))
+		require.NoError(t, os.WriteFile(filepath.Join(baseDir, version, "imposter-plugin-store-redis.jar"), []byte("test"), 0644))
+	}
+
+	// already installed, so this only saves the plugin as a default
+	_, err := plugin.EnsurePlugins([]string{"store-redis"}, "4.2.2", true)
+	require.NoError(t, err)
+
+	t.Run("remove for version keeps defaults", func(t *testing.T) {
+		removePlugin("store-redis", "4.2.1")
+		require.NoFileExists(t, filepath.Join(baseDir, "4.2.1", "imposter-plugin-store-redis.jar"))
+		require.FileExists(t, filepath.Join(baseDir, "4.2.2", "imposter-plugin-store-redis.jar"))
+
+		defaults, err := plugin.ListDefaultPlugins()
+		require.NoError(t, err)
+		require.Contains(t, defaults, "store-redis")
+	})
+
+	t.Run("remove for all versions removes default", func(t *testing.T) {
+		removePlugin("store-redis", "")
+		require.NoFileExists(t, filepath.Join(baseDir, "4.2.2", "imposter-plugin-store-redis.jar"))
+
+		defaults, err := plugin.ListDefaultPlugins()
+		require.NoError(t, err)
+		require.NotContains(t, defaults, "store-redis")
+	})
+}
@@ -0,0 +1,76 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"gatehill.io/imposter/plugin"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var pluginSearchFlags = struct {
+	refresh bool
+}{}
+
+// pluginSearchCmd represents the pluginSearch command
+var pluginSearchCmd = &cobra.Command{
+	Use:   "search [QUERY]",
+	Short: "Search available plugins",
+	Long: `Searches the index of available plugins by name and description.
+
+If QUERY is not specified, all available plugins are listed.
+
+If an index URL is configured, the index is cached for a day. Pass
+--refresh to fetch the latest index.`,
+	Args: cobra.RangeArgs(0, 1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var query string
+		if len(args) > 0 {
+			query = args[0]
+		}
+		searchPlugins(query, pluginSearchFlags.refresh)
+	},
+}
+
+func init() {
+	pluginSearchCmd.Flags().BoolVar(&pluginSearchFlags.refresh, "refresh", false, "Fetch the latest plugin index instead of using the cached copy")
+	pluginCmd.AddCommand(pluginSearchCmd)
+}
+
+func searchPlugins(query string, refresh bool) {
+	index, err := plugin.LoadIndex(refresh)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	matches := index.Search(query)
+	if len(matches) == 0 {
+		logger.Infof("no plugins found matching: %s", query)
+		return
+	}
+
+	var rows [][]string
+	for _, entry := range matches {
+		rows = append(rows, []string{entry.Name, entry.Description, entry.EngineVersions})
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Name", "Description", "Engine versions"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	table.AppendBulk(rows)
+	table.Render()
+}
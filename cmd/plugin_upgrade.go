@@ -0,0 +1,48 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"gatehill.io/imposter/engine"
+	"github.com/spf13/cobra"
+)
+
+var pluginUpgradeFlags = struct {
+	engineVersion string
+}{}
+
+// pluginUpgradeCmd represents the pluginUpgrade command
+var pluginUpgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Install default plugins for a new engine version",
+	Long: `Installs the configured default plugins for an engine version,
+such as after upgrading the engine.
+
+If version is not specified, it defaults to the configured version,
+resolving 'latest' or a version constraint against the published releases.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		version := engine.GetConfiguredVersion(pluginUpgradeFlags.engineVersion, false)
+		logger.Infof("installing default plugins for engine version %s", version)
+		installPlugins(nil, version, false)
+	},
+}
+
+func init() {
+	pluginUpgradeCmd.Flags().StringVarP(&pluginUpgradeFlags.engineVersion, "version", "v", "", "Imposter engine version (default \"latest\")")
+	pluginCmd.AddCommand(pluginUpgradeCmd)
+}
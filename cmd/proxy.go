@@ -18,8 +18,12 @@ package cmd
 
 import (
 	"fmt"
+	"gatehill.io/imposter/chaos"
+	"gatehill.io/imposter/protobuf"
 	"gatehill.io/imposter/proxy"
 	"github.com/spf13/cobra"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 	"net/http"
 	"os"
 )
@@ -33,14 +37,24 @@ var proxyFlags = struct {
 	ignoreDuplicateRequests   bool
 	recordOnlyResponseHeaders []string
 	flatResponseFileStructure bool
+	protoFiles                []string
+	protoImportPaths          []string
+	chaosProfile              string
 }{}
 
 // proxyCmd represents the up command
 var proxyCmd = &cobra.Command{
 	Use:   "proxy [URL]",
 	Short: "Proxy an endpoint and record HTTP exchanges",
-	Long:  `Proxies an endpoint and records HTTP exchanges to file, in Imposter format.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Proxies an endpoint and records HTTP exchanges to file, in Imposter format.
+
+WebSocket connections are relayed to the upstream, and the messages
+exchanged over each connection are recorded to their own file.
+
+gRPC calls are proxied over HTTP/2 and recorded to a separate grpc plugin
+config file. If protobuf files are provided with --proto, recorded messages
+are decoded to JSON.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		upstream := args[0]
 		var outputDir string
@@ -60,7 +74,18 @@ var proxyCmd = &cobra.Command{
 			RecordOnlyResponseHeaders: proxyFlags.recordOnlyResponseHeaders,
 			FlatResponseFileStructure: proxyFlags.flatResponseFileStructure,
 		}
-		proxyUpstream(upstream, proxyFlags.port, outputDir, proxyFlags.rewrite, options)
+		registry, err := protobuf.LoadRegistry(proxyFlags.protoFiles, proxyFlags.protoImportPaths)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		var profile *chaos.Profile
+		if proxyFlags.chaosProfile != "" {
+			if profile, err = chaos.LoadProfile(proxyFlags.chaosProfile); err != nil {
+				logger.Fatal(err)
+			}
+			logger.Infof("injecting faults using chaos profile: %s", proxyFlags.chaosProfile)
+		}
+		proxyUpstream(upstream, proxyFlags.port, outputDir, proxyFlags.rewrite, options, registry, profile)
 	},
 }
 
@@ -73,21 +98,49 @@ func init() {
 	proxyCmd.Flags().BoolVarP(&proxyFlags.ignoreDuplicateRequests, "ignore-duplicate-requests", "i", true, "Ignore duplicate requests with same method and URI")
 	proxyCmd.Flags().StringSliceVarP(&proxyFlags.recordOnlyResponseHeaders, "response-headers", "H", nil, "Record only these response headers")
 	proxyCmd.Flags().BoolVar(&proxyFlags.flatResponseFileStructure, "flat", false, "Flatten the response file structure")
+	proxyCmd.Flags().StringSliceVar(&proxyFlags.protoFiles, "proto", nil, "Protobuf file(s) used to decode recorded gRPC messages")
+	proxyCmd.Flags().StringSliceVar(&proxyFlags.protoImportPaths, "proto-import-path", nil, "Additional directories in which to resolve protobuf imports")
+	proxyCmd.Flags().StringVar(&proxyFlags.chaosProfile, "chaos", "", "Inject faults into responses using the rules in this chaos profile file")
 	rootCmd.AddCommand(proxyCmd)
 }
 
-func proxyUpstream(upstream string, port int, dir string, rewrite bool, options proxy.RecorderOptions) {
+func proxyUpstream(upstream string, port int, dir string, rewrite bool, options proxy.RecorderOptions, registry *protobuf.Registry, profile *chaos.Profile) {
 	logger.Infof("starting proxy for upstream %s on port %v", upstream, port)
 	recorderC, err := proxy.StartRecorder(upstream, dir, options)
 	if err != nil {
 		logger.Fatal(err)
 	}
+	wsRecorderC, err := proxy.StartWebSocketRecorder(upstream, dir, options)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	grpcRecorderC, err := proxy.StartGrpcRecorder(upstream, dir, options, registry)
+	if err != nil {
+		logger.Fatal(err)
+	}
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("/system/status", func(writer http.ResponseWriter, request *http.Request) {
 		_, _ = fmt.Fprintf(writer, "ok\n")
 	})
-	mux.HandleFunc("/", func(writer http.ResponseWriter, request *http.Request) {
+	var handler http.Handler = http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if proxy.IsWebSocketUpgrade(request) {
+			proxy.HandleWebSocket(upstream, writer, request, wsRecorderC)
+			return
+		}
+		if proxy.IsGrpcRequest(request) {
+			proxy.HandleGrpc(upstream, writer, request, func(reqBody *[]byte, statusCode int, respBody *[]byte, respHeaders *http.Header) (*[]byte, *http.Header) {
+				grpcRecorderC <- proxy.HttpExchange{
+					Request:         request,
+					RequestBody:     reqBody,
+					StatusCode:      statusCode,
+					ResponseBody:    respBody,
+					ResponseHeaders: respHeaders,
+				}
+				return respBody, respHeaders
+			})
+			return
+		}
 		proxy.Handle(upstream, writer, request, func(reqBody *[]byte, statusCode int, respBody *[]byte, respHeaders *http.Header) (*[]byte, *http.Header) {
 			if rewrite {
 				respBody = proxy.Rewrite(respHeaders, respBody, upstream, port)
@@ -103,7 +156,13 @@ func proxyUpstream(upstream string, port int, dir string, rewrite bool, options
 		})
 	})
 
-	err = http.ListenAndServe(fmt.Sprintf(":%d", port), mux)
+	if profile != nil {
+		handler = chaos.Middleware(profile, handler)
+	}
+	mux.Handle("/", handler)
+
+	// accept HTTP/2 without TLS, as used by gRPC clients
+	err = http.ListenAndServe(fmt.Sprintf(":%d", port), h2c.NewHandler(mux, &http2.Server{}))
 	if err != nil {
 		logger.Fatal(err)
 	}
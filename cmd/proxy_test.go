@@ -40,8 +40,10 @@ func Test_proxyUpstream(t *testing.T) {
 		options proxy.RecorderOptions
 	}
 	tests := []struct {
-		name string
-		args args
+		name        string
+		args        args
+		requestPath string
+		wantStatus  int
 	}{
 		{
 			name: "proxy example.com, hierarchical response files",
@@ -61,6 +63,17 @@ func Test_proxyUpstream(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "proxy upstream error status",
+			args: args{
+				rewrite: false,
+				options: proxy.RecorderOptions{
+					FlatResponseFileStructure: false,
+				},
+			},
+			requestPath: "/missing",
+			wantStatus:  http.StatusNotFound,
+		},
 	}
 	for _, tt := range tests {
 		server, upstream, upstreamPort, err := startUpstream()
@@ -79,15 +92,26 @@ func Test_proxyUpstream(t *testing.T) {
 			}
 
 			go func() {
-				proxyUpstream(upstream, port, outputDir, tt.args.rewrite, tt.args.options)
+				proxyUpstream(upstream, port, outputDir, tt.args.rewrite, tt.args.options, nil, nil)
 			}()
 			if up := engine.WaitUntilUp(port, nil); !up {
 				t.Fatalf("proxy did not come up on port %d", port)
 			}
 
-			if err := sendRequestToProxy(port); err != nil {
+			statusCode, err := sendRequestToProxy(port, tt.requestPath)
+			if err != nil {
 				t.Fatal(err)
 			}
+			wantStatus := tt.wantStatus
+			if wantStatus == 0 {
+				wantStatus = http.StatusOK
+			}
+			if statusCode != wantStatus {
+				t.Fatalf("expected status %d from proxy, but was %d", wantStatus, statusCode)
+			}
+			if tt.requestPath != "" {
+				return
+			}
 
 			upstreamHostAndPort := fmt.Sprintf("localhost-%d", upstreamPort)
 			cfgFileName := upstreamHostAndPort + "-config.yaml"
@@ -121,6 +145,9 @@ func Test_proxyUpstream(t *testing.T) {
 
 func startUpstream() (server *http.Server, url string, port int, err error) {
 	mux := http.NewServeMux()
+	mux.HandleFunc("/missing", func(writer http.ResponseWriter, request *http.Request) {
+		http.Error(writer, "not found", http.StatusNotFound)
+	})
 	mux.HandleFunc("/", func(writer http.ResponseWriter, request *http.Request) {
 		writer.Header().Set("Content-Disposition", "filename=hello.txt")
 		writer.Write([]byte("hello world"))
@@ -137,22 +164,19 @@ func startUpstream() (server *http.Server, url string, port int, err error) {
 	return server, url, port, nil
 }
 
-func sendRequestToProxy(port int) error {
+func sendRequestToProxy(port int, requestPath string) (int, error) {
 	client := http.Client{
 		Timeout: 2 * time.Second,
 	}
-	url := fmt.Sprintf("http://localhost:%d", port)
+	url := fmt.Sprintf("http://localhost:%d%s", port, requestPath)
 	resp, err := client.Get(url)
 	if err != nil {
-		return fmt.Errorf("request failed for proxy at %s: %s", url, err)
+		return 0, fmt.Errorf("request failed for proxy at %s: %s", url, err)
 	}
 	if _, err := io.ReadAll(resp.Body); err != nil {
-		return fmt.Errorf("body read failed for proxy at %s: %s", url, err)
+		return 0, fmt.Errorf("body read failed for proxy at %s: %s", url, err)
 	}
 	_ = resp.Body.Close()
-	if resp.StatusCode == 200 {
-		logger.Tracef("proxy up at %s", url)
-		return nil
-	}
-	return nil
+	logger.Tracef("proxy at %s responded with status %d", url, resp.StatusCode)
+	return resp.StatusCode, nil
 }
@@ -19,15 +19,23 @@ package cmd
 import (
 	"gatehill.io/imposter/remote"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"os"
 )
 
+var remoteDeployFlags = struct {
+	frozen bool
+}{}
+
 // remoteDeployCmd represents the remoteDeploy command
 var remoteDeployCmd = &cobra.Command{
 	Use:   "deploy",
 	Short: "Deploy active workspace",
 	Long:  `Deploys the active workspace to the remote.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if remoteDeployFlags.frozen {
+			viper.Set("lock.frozen", true)
+		}
 		var dir string
 		if remoteFlags.path != "" {
 			dir = remoteFlags.path
@@ -39,6 +47,7 @@ var remoteDeployCmd = &cobra.Command{
 }
 
 func init() {
+	remoteDeployCmd.Flags().BoolVar(&remoteDeployFlags.frozen, "frozen", false, "Fail if the lockfile is missing or does not match the configuration")
 	remoteCmd.AddCommand(remoteDeployCmd)
 }
 
@@ -54,6 +54,9 @@ Power users can control mock responses with JavaScript or Java/Groovy script eng
 Advanced users can write their own plugins in a JVM language of their choice.
 
 Learn more at www.imposter.sh`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		notifyCliUpdate(cmd)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		if rootFlags.printVersion {
 			engineType := engine.GetConfiguredType("")
@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"gatehill.io/imposter/impostermodel"
+	"gatehill.io/imposter/templates"
 	"github.com/spf13/cobra"
 	"os"
 	"path/filepath"
@@ -27,6 +28,9 @@ var scaffoldFlags = struct {
 	forceOverwrite    bool
 	generateResources bool
 	scriptEngine      string
+	interactive       bool
+	template          string
+	templateVars      map[string]string
 }{}
 
 // scaffoldCmd represents the up command
@@ -36,9 +40,19 @@ var scaffoldCmd = &cobra.Command{
 	Short:   "Create Imposter configuration",
 	Long: `Creates Imposter configuration files. If one or more OpenAPI/Swagger
 specification files are present, they are used as the basis for the generated
-resources. If no specification files are present, a simple REST mock is created.
+resources. Protobuf (.proto) files are used to generate a gRPC mock, and WSDL
+files a SOAP mock, with a sample response for each service method or binding
+operation. If no specification files are present, a simple REST mock is created.
 
-If DIR is not specified, the current working directory is used.`,
+If DIR is not specified, the current working directory is used.
+
+With --interactive, you are prompted for the plugin type, resources and
+script engine. This requires a terminal; if stdin is not a terminal, the
+configuration is created non-interactively.
+
+With --template, the files of the template are rendered into DIR instead.
+A template is the name of a template in the templates directory (see
+'imposter template list'), a path to a directory, or a git URL.`,
 	Args: cobra.RangeArgs(0, 1),
 	Run: func(cmd *cobra.Command, args []string) {
 		var configDir string
@@ -48,13 +62,44 @@ If DIR is not specified, the current working directory is used.`,
 			configDir, _ = filepath.Abs(args[0])
 		}
 		scriptEngine := impostermodel.ParseScriptEngine(scaffoldFlags.scriptEngine)
+		if scaffoldFlags.template != "" {
+			scaffoldFromTemplate(configDir, scaffoldFlags.template, scriptEngine)
+			return
+		}
+		if scaffoldFlags.interactive {
+			if isTerminal(os.Stdin) {
+				runScaffoldWizard(configDir, scaffoldFlags.forceOverwrite, scriptEngine)
+				return
+			}
+			logger.Warnf("stdin is not a terminal - creating configuration non-interactively")
+		}
 		impostermodel.Create(configDir, scaffoldFlags.generateResources, scaffoldFlags.forceOverwrite, scriptEngine, false)
 	},
 }
 
 func init() {
 	scaffoldCmd.Flags().BoolVarP(&scaffoldFlags.forceOverwrite, "force-overwrite", "f", false, "Force overwrite of destination file(s) if already exist")
-	scaffoldCmd.Flags().BoolVar(&scaffoldFlags.generateResources, "generate-resources", true, "Generate Imposter resources from OpenAPI paths")
+	scaffoldCmd.Flags().BoolVar(&scaffoldFlags.generateResources, "generate-resources", true, "Generate Imposter resources from OpenAPI paths, gRPC methods or SOAP operations")
 	scaffoldCmd.Flags().StringVarP(&scaffoldFlags.scriptEngine, "script-engine", "s", "none", "Generate placeholder Imposter script (none|groovy|js)")
+	scaffoldCmd.Flags().BoolVarP(&scaffoldFlags.interactive, "interactive", "i", false, "Prompt for the mock configuration")
+	scaffoldCmd.Flags().StringVarP(&scaffoldFlags.template, "template", "t", "", "Create configuration from a template (name, directory or git URL)")
+	scaffoldCmd.Flags().StringToStringVar(&scaffoldFlags.templateVars, "template-var", nil, "Template variables in the form KEY=VALUE")
 	rootCmd.AddCommand(scaffoldCmd)
 }
+
+func scaffoldFromTemplate(configDir string, templateRef string, scriptEngine impostermodel.ScriptEngine) {
+	templateDir, err := templates.Resolve(templateRef)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	plugin := impostermodel.PreferredPlugin(impostermodel.DiscoverSpecFiles(configDir))
+	vars, err := templates.BuildVars(configDir, plugin, string(scriptEngine), scaffoldFlags.templateVars)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	written, err := templates.Render(templateDir, configDir, vars, scaffoldFlags.forceOverwrite)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	logger.Infof("wrote %d file(s) from template %s to %s", len(written), templateRef, configDir)
+}
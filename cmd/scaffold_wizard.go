@@ -0,0 +1,180 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"gatehill.io/imposter/impostermodel"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// scaffoldPlan holds the answers collected by the scaffold wizard.
+type scaffoldPlan struct {
+	pluginName        string
+	specFiles         []string
+	generateResources bool
+	restResources     []impostermodel.RestResource
+	scriptEngine      impostermodel.ScriptEngine
+}
+
+// scaffoldWizard prompts for answers on its output and reads them from
+// its input. If the input is exhausted, default answers are used.
+type scaffoldWizard struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+var scaffoldPluginTypes = []string{"rest", "openapi", "grpc", "soap"}
+
+var scaffoldContentTypes = []string{"application/json", "application/xml", "text/html", "text/plain"}
+
+// isTerminal returns true if the file is an interactive terminal.
+func isTerminal(f *os.File) bool {
+	stat, err := f.Stat()
+	return err == nil && stat.Mode()&os.ModeCharDevice != 0
+}
+
+func runScaffoldWizard(configDir string, forceOverwrite bool, defaultScriptEngine impostermodel.ScriptEngine) {
+	wizard := &scaffoldWizard{in: bufio.NewReader(os.Stdin), out: os.Stdout}
+	specFiles := impostermodel.DiscoverSpecFiles(configDir)
+	plan := wizard.collectPlan(specFiles, defaultScriptEngine)
+
+	if plan.pluginName == "rest" {
+		impostermodel.CreateRestMock(configDir, plan.restResources, forceOverwrite, plan.scriptEngine)
+	} else {
+		impostermodel.CreateFromSpecs(plan.pluginName, plan.specFiles, plan.generateResources, forceOverwrite, plan.scriptEngine)
+	}
+}
+
+func (w *scaffoldWizard) collectPlan(specFiles map[string][]string, defaultScriptEngine impostermodel.ScriptEngine) scaffoldPlan {
+	defaultPlugin := impostermodel.PreferredPlugin(specFiles)
+
+	plan := scaffoldPlan{}
+	for {
+		plan.pluginName = w.choose("Plugin type", scaffoldPluginTypes, defaultPlugin)
+		if plan.pluginName == "rest" || len(specFiles[plan.pluginName]) > 0 {
+			break
+		}
+		_, _ = fmt.Fprintf(w.out, "No specification files found for the %s plugin\n", plan.pluginName)
+	}
+
+	if plan.pluginName == "rest" {
+		plan.restResources = w.collectRestResources()
+	} else {
+		plan.specFiles = specFiles[plan.pluginName]
+		plan.generateResources = w.confirm("Generate resources from the specification", true)
+	}
+
+	scriptEngine := w.choose("Script engine", []string{"none", "groovy", "js"}, scriptEngineAlias(defaultScriptEngine))
+	plan.scriptEngine = impostermodel.ParseScriptEngine(scriptEngine)
+	return plan
+}
+
+func (w *scaffoldWizard) collectRestResources() []impostermodel.RestResource {
+	basePath := w.ask("Base path", "/")
+
+	var resources []impostermodel.RestResource
+	for {
+		resourcePath := w.ask("Resource path", "/")
+		resource := impostermodel.RestResource{
+			Path:        path.Join("/", basePath, resourcePath),
+			Method:      strings.ToUpper(w.choose("HTTP method", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}, http.MethodGet)),
+			StatusCode:  w.askStatusCode(),
+			ContentType: w.ask("Response content type (e.g. "+strings.Join(scaffoldContentTypes, ", ")+")", "application/json"),
+		}
+		resources = append(resources, resource)
+
+		if !w.confirm("Add another resource", false) {
+			return resources
+		}
+	}
+}
+
+func (w *scaffoldWizard) askStatusCode() int {
+	for {
+		answer := w.ask("Response status code", "200")
+		statusCode, err := strconv.Atoi(answer)
+		if err == nil && statusCode >= 100 && statusCode <= 599 {
+			return statusCode
+		}
+		_, _ = fmt.Fprintf(w.out, "Invalid status code: %s\n", answer)
+	}
+}
+
+// ask prompts for a free text answer, returning the default
+// if the answer is blank or there is no more input.
+func (w *scaffoldWizard) ask(question string, defaultAnswer string) string {
+	_, _ = fmt.Fprintf(w.out, "%s [%s]: ", question, defaultAnswer)
+	line, err := w.in.ReadString('\n')
+	answer := strings.TrimSpace(line)
+	if err != nil && answer == "" {
+		_, _ = fmt.Fprintln(w.out)
+		return defaultAnswer
+	}
+	if answer == "" {
+		return defaultAnswer
+	}
+	return answer
+}
+
+// choose prompts until one of the options is given. Options are
+// matched case-insensitively.
+func (w *scaffoldWizard) choose(question string, options []string, defaultAnswer string) string {
+	for {
+		answer := w.ask(fmt.Sprintf("%s (%s)", question, strings.Join(options, ", ")), defaultAnswer)
+		for _, option := range options {
+			if strings.EqualFold(answer, option) {
+				return option
+			}
+		}
+		_, _ = fmt.Fprintf(w.out, "Invalid choice: %s\n", answer)
+	}
+}
+
+func (w *scaffoldWizard) confirm(question string, defaultAnswer bool) bool {
+	options := "y/N"
+	if defaultAnswer {
+		options = "Y/n"
+	}
+	_, _ = fmt.Fprintf(w.out, "%s? [%s]: ", question, options)
+	line, _ := w.in.ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true
+	case "n", "no":
+		return false
+	default:
+		return defaultAnswer
+	}
+}
+
+func scriptEngineAlias(engine impostermodel.ScriptEngine) string {
+	switch engine {
+	case impostermodel.ScriptEngineJavaScript:
+		return "js"
+	case impostermodel.ScriptEngineGroovy:
+		return "groovy"
+	default:
+		return "none"
+	}
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"bufio"
+	"gatehill.io/imposter/impostermodel"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_scaffoldWizard_collectPlan(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		specFiles map[string][]string
+		want      scaffoldPlan
+	}{
+		{
+			name:  "rest mock with multiple resources",
+			input: "rest\n/api\n/pets\npost\nnot-a-number\n201\n\ny\n/pets/{id}\n\n\ntext/plain\nn\njs\n",
+			want: scaffoldPlan{
+				pluginName: "rest",
+				restResources: []impostermodel.RestResource{
+					{Path: "/api/pets", Method: "POST", StatusCode: 201, ContentType: "application/json"},
+					{Path: "/api/pets/{id}", Method: "GET", StatusCode: 200, ContentType: "text/plain"},
+				},
+				scriptEngine: impostermodel.ScriptEngineJavaScript,
+			},
+		},
+		{
+			name:      "spec plugin defaults to discovered spec",
+			input:     "\nn\n\n",
+			specFiles: map[string][]string{"soap": {"/tmp/petstore.wsdl"}},
+			want: scaffoldPlan{
+				pluginName:        "soap",
+				specFiles:         []string{"/tmp/petstore.wsdl"},
+				generateResources: false,
+				scriptEngine:      impostermodel.ScriptEngineNone,
+			},
+		},
+		{
+			name:      "spec plugin without spec files is re-prompted",
+			input:     "grpc\nopenapi\n\ngroovy\n",
+			specFiles: map[string][]string{"openapi": {"/tmp/openapi.yaml"}},
+			want: scaffoldPlan{
+				pluginName:        "openapi",
+				specFiles:         []string{"/tmp/openapi.yaml"},
+				generateResources: true,
+				scriptEngine:      impostermodel.ScriptEngineGroovy,
+			},
+		},
+		{
+			name:  "defaults used when input is exhausted",
+			input: "",
+			want: scaffoldPlan{
+				pluginName: "rest",
+				restResources: []impostermodel.RestResource{
+					{Path: "/", Method: "GET", StatusCode: 200, ContentType: "application/json"},
+				},
+				scriptEngine: impostermodel.ScriptEngineNone,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wizard := &scaffoldWizard{in: bufio.NewReader(strings.NewReader(tt.input)), out: io.Discard}
+			got := wizard.collectPlan(tt.specFiles, impostermodel.ScriptEngineNone)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("collectPlan() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_createRestMockFromWizard(t *testing.T) {
+	configDir, err := os.MkdirTemp(os.TempDir(), "specs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	impostermodel.CreateRestMock(configDir, []impostermodel.RestResource{
+		{Path: "/api/pets", Method: "GET", StatusCode: 200, ContentType: "application/json"},
+		{Path: "/api/pets", Method: "DELETE", StatusCode: 204},
+	}, false, impostermodel.ScriptEngineNone)
+
+	for _, expected := range []string{"mock-config.yaml", "README.md", "GET-api_pets-response.json"} {
+		if !doesFileExist(filepath.Join(configDir, expected)) {
+			t.Errorf("%s should exist", expected)
+		}
+	}
+	if doesFileExist(filepath.Join(configDir, "DELETE-api_pets-response.txt")) {
+		t.Errorf("response file should not exist for resource without content")
+	}
+	readme, _ := os.ReadFile(filepath.Join(configDir, "README.md"))
+	if !strings.Contains(string(readme), "- GET-api_pets-response.json") {
+		t.Errorf("README should list response file, got:\n%s", readme)
+	}
+}
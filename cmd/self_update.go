@@ -0,0 +1,68 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"gatehill.io/imposter/config"
+	"gatehill.io/imposter/selfupdate"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var selfUpdateFlags = struct {
+	version string
+	force   bool
+}{}
+
+// selfUpdateCmd represents the selfUpdate command
+var selfUpdateCmd = &cobra.Command{
+	Use:   "self-update",
+	Short: "Update the CLI",
+	Long: `Updates the CLI to a specified version.
+
+If version is not specified, it defaults to 'latest'.
+
+The release for the current OS and architecture is downloaded, verified
+and then replaces the running binary.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		installed, err := selfupdate.Update(selfUpdateFlags.version, selfUpdateFlags.force)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		if installed != "" {
+			logger.Infof("updated CLI from version %s to %s", config.Config.Version, installed)
+		}
+	},
+}
+
+func init() {
+	selfUpdateCmd.Flags().StringVarP(&selfUpdateFlags.version, "version", "v", "", "CLI version (default \"latest\")")
+	selfUpdateCmd.Flags().BoolVarP(&selfUpdateFlags.force, "force", "f", false, "Update even if the CLI is already at the version")
+	rootCmd.AddCommand(selfUpdateCmd)
+}
+
+// notifyCliUpdate prints a notice if a newer version of the CLI is available.
+func notifyCliUpdate(cmd *cobra.Command) {
+	if cmd == selfUpdateCmd || !cmd.Runnable() || cmd.Name() == cobra.ShellCompRequestCmd || cmd.Name() == cobra.ShellCompNoDescRequestCmd {
+		return
+	}
+	if latest, available := selfupdate.CheckForUpdate(); available {
+		_, _ = fmt.Fprintf(os.Stderr, "ℹ️ A new version of the Imposter CLI is available: %s (current: %s)\nTo update, run: imposter self-update\n\n", latest, config.Config.Version)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"gatehill.io/imposter/engine"
+	"gatehill.io/imposter/inspect"
+	"github.com/spf13/cobra"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+var tailFlags = struct {
+	engineType string
+	port       int
+	filter     string
+	follow     bool
+}{}
+
+// tailCmd represents the tail command
+var tailCmd = &cobra.Command{
+	Use:   "tail [ID]",
+	Short: "Show requests to a running mock",
+	Long: `Shows a live table of requests to a mock started with 'imposter up --inspect'.
+
+The mock is identified by its ID, as shown by 'imposter list', or by the
+port on which it listens. If neither is specified, the most recently
+active mock is used.
+
+Each request shows its method, path, status, latency and the configured
+resource it matched, which helps to diagnose why a resource did not match.`,
+	Args: cobra.RangeArgs(0, 1),
+	Run: func(cmd *cobra.Command, args []string) {
+		port := tailFlags.port
+		if len(args) > 0 {
+			mock := findRunningMock(engine.GetConfiguredType(tailFlags.engineType), args[0], 0)
+			port = mock.Port
+		}
+		filter, err := inspect.ParseFilter(tailFlags.filter)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		tailSession(port, filter, tailFlags.follow)
+	},
+}
+
+func init() {
+	tailCmd.Flags().StringVarP(&tailFlags.engineType, "engine-type", "t", "", "Imposter engine type (valid: docker,jvm - default \"docker\")")
+	tailCmd.Flags().IntVarP(&tailFlags.port, "port", "p", 0, "Port on which the mock listens, instead of its ID")
+	tailCmd.Flags().StringVar(&tailFlags.filter, "filter", "", "Only show requests matching the filter (e.g. \"method=GET,status=4xx,path=/pets,unmatched\")")
+	tailCmd.Flags().BoolVarP(&tailFlags.follow, "follow", "f", true, "Wait for new requests")
+	registerEngineTypeCompletions(tailCmd)
+	rootCmd.AddCommand(tailCmd)
+}
+
+func tailSession(port int, filter inspect.Filter, follow bool) {
+	sessionPath, err := inspect.FindSession(port)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	logger.Debugf("reading session log: %s", sessionPath)
+
+	stopC := make(chan bool)
+	signalC := make(chan os.Signal, 1)
+	signal.Notify(signalC, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signalC
+		close(stopC)
+	}()
+
+	printer := inspect.NewPrinter(os.Stdout)
+	err = inspect.Tail(sessionPath, follow, stopC, func(entry inspect.Entry) {
+		if filter.Matches(entry) {
+			printer.Print(entry)
+		}
+	})
+	if err != nil {
+		logger.Fatal(err)
+	}
+}
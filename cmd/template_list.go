@@ -0,0 +1,59 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"gatehill.io/imposter/templates"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// templateListCmd represents the templateList command
+var templateListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List scaffold templates",
+	Long: `Lists the templates in the templates directory, which can be
+used with 'imposter scaffold --template NAME'.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		listTemplates()
+	},
+}
+
+func init() {
+	templateCmd.AddCommand(templateListCmd)
+}
+
+func listTemplates() {
+	logger.Tracef("listing templates")
+	available, err := templates.List()
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	var rows [][]string
+	for _, metadata := range available {
+		rows = append(rows, []string{metadata.Name, metadata.Description, metadata.Path})
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Name", "Description", "Path"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	table.AppendBulk(rows)
+	table.Render()
+}
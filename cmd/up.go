@@ -17,17 +17,28 @@ limitations under the License.
 package cmd
 
 import (
+	"crypto/tls"
 	"fmt"
+	"gatehill.io/imposter/certs"
+	"gatehill.io/imposter/chaos"
 	"gatehill.io/imposter/config"
 	"gatehill.io/imposter/engine"
 	"gatehill.io/imposter/fileutil"
+	"gatehill.io/imposter/inspect"
+	"gatehill.io/imposter/lockfile"
 	"gatehill.io/imposter/plugin"
+	"gatehill.io/imposter/proxy"
 	"gatehill.io/imposter/stringutil"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
@@ -48,8 +59,33 @@ var upFlags = struct {
 	dirMounts           []string
 	recursiveConfigScan bool
 	debugMode           bool
+	frozen              bool
+	inspect             bool
+	inspectFilter       string
+	inspectSave         string
+	chaosProfile        string
+	tls                 bool
+	mtls                bool
+	mtlsCA              string
+	detach              bool
+	docker              dockerFlags
 }{}
 
+// dockerFlags hold the Docker container options, which override those
+// under the `docker` configuration key.
+type dockerFlags struct {
+	containerName  string
+	network        string
+	networkAliases []string
+	hostIP         string
+	cpus           float64
+	memory         string
+	extraHosts     []string
+	restartPolicy  string
+	dns            []string
+	dnsSearch      []string
+}
+
 // upCmd represents the up command
 var upCmd = &cobra.Command{
 	Use:   "up [CONFIG_DIR]",
@@ -73,6 +109,10 @@ If CONFIG_DIR is not specified, the current working directory is used.`,
 
 		// Search for CLI config files in the mock config dir.
 		config.MergeCliConfigIfExists(configDir)
+		if upFlags.frozen {
+			viper.Set("lock.frozen", true)
+		}
+		applyDockerFlags(cmd)
 
 		var pullPolicy engine.PullPolicy
 		if upFlags.forcePull {
@@ -81,25 +121,46 @@ If CONFIG_DIR is not specified, the current working directory is used.`,
 			pullPolicy = engine.PullIfNotPresent
 		}
 
-		engineType := engine.GetConfiguredType(upFlags.engineType)
+		engineType := lockfile.GetConfiguredType(configDir, upFlags.engineType)
 		lib := engine.GetLibrary(engineType)
 
 		var version string
 		if !lib.IsSealedDistro() {
 			// only resolve version if not a sealed distro, to avoid prefs write
-			version = engine.GetConfiguredVersion(upFlags.engineVersion, pullPolicy != engine.PullAlways)
+			lock, lockedVersion, err := lockfile.ResolveVersion(configDir, engineType, upFlags.engineVersion, pullPolicy != engine.PullAlways)
+			if err != nil {
+				logger.Fatal(err)
+			}
+			version = lockedVersion
 
 			// only ensure (and potentially fetch) default plugins if not a sealed distro
 			if upFlags.ensurePlugins && lib.ShouldEnsurePlugins() {
-				_, err := plugin.EnsureConfiguredPlugins(version)
+				_, err := plugin.EnsurePlugins(plugin.ListMockPlugins(configDir), version, false)
 				if err != nil {
 					logger.Fatal(err)
 				}
+				if lock != nil {
+					if _, err = plugin.EnsurePlugins(lock.PluginNames(), version, false); err != nil {
+						logger.Fatal(err)
+					}
+				}
+			}
+			if err = lockfile.VerifyArtifacts(configDir, lock, engineType, version); err != nil {
+				logger.Fatal(err)
+			}
+		}
+
+		enginePort := upFlags.port
+		var publicUrl string
+		if upFlags.inspect || upFlags.chaosProfile != "" || upFlags.tls || upFlags.mtls {
+			if upFlags.detach {
+				logger.Fatal("--detach cannot be used with --inspect, --chaos, --tls or --mtls, which require the CLI to keep running")
 			}
+			enginePort, publicUrl = startFrontProxy(configDir)
 		}
 
 		startOptions := engine.StartOptions{
-			Port:            upFlags.port,
+			Port:            enginePort,
 			Version:         version,
 			PullPolicy:      pullPolicy,
 			LogLevel:        config.Config.LogLevel,
@@ -110,8 +171,9 @@ If CONFIG_DIR is not specified, the current working directory is used.`,
 			Environment:     buildStartEnvironment(upFlags.environment),
 			DirMounts:       upFlags.dirMounts,
 			DebugMode:       upFlags.debugMode,
+			PublicUrl:       publicUrl,
 		}
-		start(&lib, startOptions, configDir, upFlags.restartOnChange)
+		start(&lib, startOptions, configDir, upFlags.restartOnChange, upFlags.detach)
 	},
 }
 
@@ -130,10 +192,63 @@ func init() {
 	upCmd.Flags().StringArrayVar(&upFlags.dirMounts, "mount-dir", []string{}, "(Docker engine type only) Extra directory bind-mounts in the form HOST_PATH:CONTAINER_PATH (e.g. $HOME/somedir:/opt/imposter/somedir) or simply HOST_PATH, which will mount the directory at /opt/imposter/<dir>")
 	upCmd.Flags().BoolVarP(&upFlags.recursiveConfigScan, "recursive-config-scan", "r", false, "Scan for config files in subdirectories")
 	upCmd.Flags().BoolVar(&upFlags.debugMode, "debug-mode", false, fmt.Sprintf("Enable JVM debug mode and listen on port %v", engine.DefaultDebugPort))
+	upCmd.Flags().BoolVar(&upFlags.frozen, "frozen", false, "Fail if the lockfile is missing or does not match the configuration")
+	upCmd.Flags().BoolVar(&upFlags.inspect, "inspect", false, "Show a live table of requests to the mock")
+	upCmd.Flags().StringVar(&upFlags.inspectFilter, "inspect-filter", "", "Only show requests matching the filter (e.g. \"method=GET,status=4xx,path=/pets,unmatched\")")
+	upCmd.Flags().StringVar(&upFlags.inspectSave, "inspect-save", "", "Append requests to this file as JSON lines")
+	upCmd.Flags().StringVar(&upFlags.chaosProfile, "chaos", "", "Inject faults into responses using the rules in this chaos profile file")
+	upCmd.Flags().BoolVar(&upFlags.tls, "tls", false, "Serve the mock over HTTPS, using a certificate issued by a local CA")
+	upCmd.Flags().BoolVar(&upFlags.mtls, "mtls", false, "Serve the mock over HTTPS and require clients to present a certificate (implies --tls)")
+	upCmd.Flags().StringVar(&upFlags.mtlsCA, "mtls-ca", "", "CA certificate used to verify client certificates (default: the local CA)")
+	upCmd.Flags().BoolVarP(&upFlags.detach, "detach", "d", false, "Exit once the mock is ready, leaving it running in the background")
+	upCmd.Flags().StringVar(&upFlags.docker.containerName, "name", "", "(Docker engine type only) Name of the container")
+	upCmd.Flags().StringVar(&upFlags.docker.network, "network", "", "(Docker engine type only) Network for the container to join")
+	upCmd.Flags().StringArrayVar(&upFlags.docker.networkAliases, "network-alias", nil, "(Docker engine type only) Alias by which the container can be reached on the network")
+	upCmd.Flags().StringVar(&upFlags.docker.hostIP, "host-ip", "", "Host IP address to which the port is bound - for the Docker engine type, or any engine type with --inspect, --chaos, --tls or --mtls (default \"0.0.0.0\")")
+	upCmd.Flags().Float64Var(&upFlags.docker.cpus, "cpus", 0, "(Docker engine type only) Number of CPUs the container can use, such as 1.5")
+	upCmd.Flags().StringVar(&upFlags.docker.memory, "memory", "", "(Docker engine type only) Memory limit for the container, such as 512m or 2g")
+	upCmd.Flags().StringArrayVar(&upFlags.docker.extraHosts, "add-host", nil, "(Docker engine type only) Extra host in the form HOST:IP to add to the container's hosts file")
+	upCmd.Flags().StringVar(&upFlags.docker.restartPolicy, "restart", "", "(Docker engine type only) Restart policy for the container (valid: no,always,unless-stopped,on-failure[:max-retries])")
+	upCmd.Flags().StringArrayVar(&upFlags.docker.dns, "dns", nil, "(Docker engine type only) DNS server for the container to use")
+	upCmd.Flags().StringArrayVar(&upFlags.docker.dnsSearch, "dns-search", nil, "(Docker engine type only) DNS search domain for the container to use")
 	registerEngineTypeCompletions(upCmd)
 	rootCmd.AddCommand(upCmd)
 }
 
+// applyDockerFlags overrides the Docker container options in the configuration
+// with those set on the command line.
+func applyDockerFlags(cmd *cobra.Command) {
+	flags := map[string]interface{}{
+		"name":          upFlags.docker.containerName,
+		"network":       upFlags.docker.network,
+		"network-alias": upFlags.docker.networkAliases,
+		"host-ip":       upFlags.docker.hostIP,
+		"cpus":          upFlags.docker.cpus,
+		"memory":        upFlags.docker.memory,
+		"add-host":      upFlags.docker.extraHosts,
+		"restart":       upFlags.docker.restartPolicy,
+		"dns":           upFlags.docker.dns,
+		"dns-search":    upFlags.docker.dnsSearch,
+	}
+	keys := map[string]string{
+		"name":          "docker.containerName",
+		"network":       "docker.network",
+		"network-alias": "docker.networkAliases",
+		"host-ip":       "docker.hostIp",
+		"cpus":          "docker.cpus",
+		"memory":        "docker.memory",
+		"add-host":      "docker.extraHosts",
+		"restart":       "docker.restartPolicy",
+		"dns":           "docker.dns",
+		"dns-search":    "docker.dnsSearch",
+	}
+	for flag, value := range flags {
+		if cmd.Flags().Changed(flag) {
+			viper.Set(keys[flag], value)
+		}
+	}
+}
+
 func injectExplicitEnvironment(cliEnvArgs []string) {
 	for _, env := range cliEnvArgs {
 		envParts := strings.Split(env, "=")
@@ -167,16 +282,24 @@ func buildStartEnvironment(cliEnvArgs []string) []string {
 	return env
 }
 
-func start(lib *engine.EngineLibrary, startOptions engine.StartOptions, configDir string, restartOnChange bool) {
+func start(lib *engine.EngineLibrary, startOptions engine.StartOptions, configDir string, restartOnChange bool, detach bool) {
 	provider := (*lib).GetProvider(startOptions.Version)
 	mockEngine := provider.Build(configDir, startOptions)
 
 	wg := &sync.WaitGroup{}
+	if detach {
+		// the engine waits for the readiness checks to pass as it starts
+		if !mockEngine.Start(wg) {
+			logger.Fatal("mock failed to start")
+		}
+		logger.Infof("mock is ready on port %d and running in the background - stop it with 'imposter down'", startOptions.Port)
+		return
+	}
 	trapExit(mockEngine, wg)
 	success := mockEngine.Start(wg)
 
 	if success && restartOnChange {
-		dirUpdated := fileutil.WatchDir(configDir)
+		dirUpdated := fileutil.WatchDirAndFiles(configDir, listLinkedPluginFiles(configDir, startOptions))
 		go func() {
 			for {
 				<-dirUpdated
@@ -190,6 +313,130 @@ func start(lib *engine.EngineLibrary, startOptions engine.StartOptions, configDi
 	logger.Debug("shutting down")
 }
 
+// startFrontProxy listens on the port requested for the mock and relays
+// requests to the engine, through the request inspector and fault injection
+// layers, terminating TLS if enabled. HTTP/2 is offered, with or without
+// TLS, so gRPC calls can be relayed. It returns the port on which the engine
+// should listen, and the URL at which clients reach the mock.
+func startFrontProxy(configDir string) (int, string) {
+	enginePort, err := findFreePort()
+	if err != nil {
+		logger.Fatal(err)
+	}
+	handler := proxy.PassThrough(fmt.Sprintf("http://127.0.0.1:%d", enginePort))
+	if upFlags.chaosProfile != "" {
+		profile, err := chaos.LoadProfile(upFlags.chaosProfile)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		logger.Infof("injecting faults using chaos profile: %s", upFlags.chaosProfile)
+		handler = chaos.Middleware(profile, handler)
+	}
+	if upFlags.inspect {
+		handler = startInspector(configDir, handler)
+	}
+
+	// the front proxy is bound to the configured host IP, and the engine
+	// behind it only to the loopback interface, so it cannot be reached
+	// without passing through the proxy
+	hostIP := viper.GetString("docker.hostIp")
+	viper.Set("docker.hostIp", "127.0.0.1")
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(hostIP, strconv.Itoa(upFlags.port)))
+	if err != nil {
+		logger.Fatalf("failed to listen on port %d: %v", upFlags.port, err)
+	}
+	scheme := "http"
+	if upFlags.tls || upFlags.mtls {
+		listener = tls.NewListener(listener, buildTlsConfig())
+		scheme = "https"
+	}
+	go func() {
+		if err := http.Serve(listener, h2c.NewHandler(handler, &http2.Server{})); err != nil {
+			logger.Errorf("front proxy stopped: %v", err)
+		}
+	}()
+	logger.Debugf("engine will listen on internal port %d", enginePort)
+	urlHost := "localhost"
+	if ip := net.ParseIP(hostIP); ip != nil && !ip.IsUnspecified() {
+		urlHost = hostIP
+	}
+	return enginePort, fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(urlHost, strconv.Itoa(upFlags.port)))
+}
+
+func buildTlsConfig() *tls.Config {
+	tlsConfig, err := certs.ServerConfig(certs.DefaultHosts, upFlags.mtls, upFlags.mtlsCA)
+	if err != nil {
+		logger.Fatalf("failed to configure TLS: %s", err)
+	}
+	tlsConfig.NextProtos = []string{"h2", "http/1.1"}
+	caPath, err := certs.GetCAPath()
+	if err != nil {
+		logger.Fatal(err)
+	}
+	logger.Infof("serving mock over HTTPS - clients must trust the CA certificate: %s", caPath)
+
+	if upFlags.mtls {
+		if upFlags.mtlsCA != "" {
+			logger.Infof("clients must present a certificate issued by: %s", upFlags.mtlsCA)
+		} else {
+			client, err := certs.EnsureClientCert()
+			if err != nil {
+				logger.Fatalf("failed to generate client certificate: %s", err)
+			}
+			logger.Infof("clients must present a certificate issued by the local CA, such as: %s (key: %s)", client.Cert, client.Key)
+		}
+	}
+	return tlsConfig
+}
+
+// startInspector records the requests handled by next, so injected faults
+// are shown alongside the responses from the engine.
+func startInspector(configDir string, next http.Handler) http.Handler {
+	filter, err := inspect.ParseFilter(upFlags.inspectFilter)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	options := inspect.Options{
+		ConfigDir:       configDir,
+		RecursiveConfig: upFlags.recursiveConfigScan,
+		Filter:          filter,
+		SavePath:        upFlags.inspectSave,
+	}
+	handler, err := inspect.Start(upFlags.port, options, inspect.NewPrinter(os.Stdout), next)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	return handler
+}
+
+func findFreePort() (int, error) {
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		return 0, fmt.Errorf("failed to find free port: %v", err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// listLinkedPluginFiles returns the local files to which the plugins
+// used by the mock are linked, so changes to them trigger a restart.
+func listLinkedPluginFiles(configDir string, startOptions engine.StartOptions) []string {
+	if !startOptions.EnablePlugins || startOptions.Version == "" {
+		return nil
+	}
+	linked, err := plugin.ListLinkedPlugins(configDir, startOptions.Version)
+	if err != nil {
+		logger.Warnf("failed to list linked plugins: %s", err)
+		return nil
+	}
+	var files []string
+	for _, target := range linked {
+		files = append(files, target)
+	}
+	return files
+}
+
 // listen for an interrupt from the OS, then attempt engine cleanup
 func trapExit(mockEngine engine.MockEngine, wg *sync.WaitGroup) {
 	c := make(chan os.Signal)
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"gatehill.io/imposter/config"
 	"gatehill.io/imposter/engine"
+	"gatehill.io/imposter/semverutil"
 	"github.com/spf13/cobra"
 )
 
@@ -73,6 +74,8 @@ func describeVersions(engineType engine.EngineType, format outputFormat) string
 		engineConfigVersion := engine.GetConfiguredVersionOrResolve("", true, false)
 		if engineConfigVersion == "latest" {
 			engineConfigVersion = engine.GetHighestVersion(engines)
+		} else if semverutil.IsConstraint(engineConfigVersion) {
+			engineConfigVersion = engine.GetHighestMatchingVersion(engines, engineConfigVersion)
 		}
 		output += formatProperty(format, "imposter-engine", engineConfigVersion, false)
 		output += formatProperty(format, "engine-output", getInstalledEngineVersion(engineType, engineConfigVersion), true)
@@ -0,0 +1,120 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"gatehill.io/imposter/config"
+	"gatehill.io/imposter/engine"
+	"github.com/spf13/cobra"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var waitFlags = struct {
+	engineType string
+	port       int
+	id         string
+	timeout    time.Duration
+}{}
+
+// waitCmd represents the wait command
+var waitCmd = &cobra.Command{
+	Use:   "wait [CONFIG_DIR]",
+	Short: "Wait for a mock to be ready",
+	Long: `Waits until a running mock passes its readiness checks.
+
+The readiness checks are read from the CLI configuration, including the
+.imposter.yaml file in CONFIG_DIR. If CONFIG_DIR is not specified, the
+current working directory is used.
+
+The mock is identified by its port, or by its ID, as shown by 'imposter list'.
+If the mock is not ready before the timeout, the command exits with
+a non-zero status.`,
+	Args: cobra.RangeArgs(0, 1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var configDir string
+		if len(args) == 0 {
+			configDir, _ = os.Getwd()
+		} else {
+			configDir, _ = filepath.Abs(args[0])
+		}
+		config.MergeCliConfigIfExists(configDir)
+
+		readiness, err := engine.LoadReadinessConfig()
+		if err != nil {
+			logger.Fatal(err)
+		}
+		if waitFlags.timeout > 0 {
+			readiness.Timeout = waitFlags.timeout
+		}
+		mockUrl := resolveMockUrl(engine.GetConfiguredType(waitFlags.engineType), waitFlags.id, waitFlags.port)
+		if err = engine.WaitUntilReady(mockUrl, readiness, nil); err != nil {
+			logger.Fatal(err)
+		}
+		logger.Infof("mock at %s is ready", mockUrl)
+	},
+}
+
+func init() {
+	waitCmd.Flags().StringVarP(&waitFlags.engineType, "engine-type", "t", "", "Imposter engine type (valid: docker,jvm - default \"docker\")")
+	waitCmd.Flags().IntVarP(&waitFlags.port, "port", "p", 8080, "Port on which the mock listens")
+	waitCmd.Flags().StringVar(&waitFlags.id, "id", "", "ID of the mock, instead of its port")
+	waitCmd.Flags().DurationVar(&waitFlags.timeout, "timeout", 0, "Maximum time to wait, such as 90s (default: the readiness timeout)")
+	registerEngineTypeCompletions(waitCmd)
+	rootCmd.AddCommand(waitCmd)
+}
+
+// resolveMockUrl returns the URL of the running mock with the ID, or if id
+// is empty, listening on the port. A mock that is not yet running can only
+// be identified by its port.
+func resolveMockUrl(engineType engine.EngineType, id string, port int) string {
+	if mock := findRunningMock(engineType, id, port); mock != nil {
+		return mock.URL
+	}
+	return fmt.Sprintf("http://localhost:%d", port)
+}
+
+// findRunningMock returns the running mock with the ID, or if id is empty,
+// listening on the port, or nil if there is none. It is fatal if no mock
+// has the ID.
+func findRunningMock(engineType engine.EngineType, id string, port int) *engine.ManagedMock {
+	mockEngine := engine.BuildEngine(engineType, filepath.Join(os.TempDir(), "imposter-wait"), engine.StartOptions{})
+	mocks, err := mockEngine.ListAllManaged()
+	if err != nil {
+		if id != "" {
+			logger.Fatalf("failed to list mocks: %s", err)
+		}
+		logger.Debugf("failed to list mocks: %s", err)
+	}
+	for i, mock := range mocks {
+		if mock.URL == "" {
+			continue
+		}
+		if id != "" && strings.HasPrefix(mock.ID, id) {
+			return &mocks[i]
+		} else if id == "" && mock.Port == port {
+			return &mocks[i]
+		}
+	}
+	if id != "" {
+		logger.Fatalf("no running mock found with ID: %s", id)
+	}
+	return nil
+}
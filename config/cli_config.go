@@ -25,6 +25,7 @@ import (
 	"strings"
 
 	"gatehill.io/imposter/logging"
+	"gatehill.io/imposter/semverutil"
 	"github.com/spf13/viper"
 )
 
@@ -102,6 +103,17 @@ func checkCliVersion(required string) error {
 	if err != nil {
 		return fmt.Errorf("failed to parse CLI version: %v: %v", Config.Version, err)
 	}
+	if semverutil.IsConstraint(required) {
+		constraint, err := semverutil.ParseConstraint(required)
+		if err != nil {
+			return fmt.Errorf("failed to parse required CLI version: %v: %v", required, err)
+		}
+		if !constraint.Check(*cliVer) {
+			return fmt.Errorf("CLI version requirement not met [required: %v, current: %v] - to update, run: imposter self-update", required, Config.Version)
+		}
+		logger.Tracef("CLI version requirement met [required: %v, current: %v]", required, Config.Version)
+		return nil
+	}
 	reqVer, err := semver.NewVersion(required)
 	if err != nil {
 		return fmt.Errorf("failed to parse required CLI version: %v: %v", required, err)
@@ -110,7 +122,7 @@ func checkCliVersion(required string) error {
 		logger.Tracef("CLI version requirement met [required: %v, current: %v]", required, Config.Version)
 		return nil
 	} else {
-		return fmt.Errorf("CLI version requirement not met [required: %v, current: %v]", required, Config.Version)
+		return fmt.Errorf("CLI version requirement not met [required: %v, current: %v] - to update, run: imposter self-update", required, Config.Version)
 	}
 }
 
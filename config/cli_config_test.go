@@ -21,6 +21,18 @@ func Test_checkCliVersion(t *testing.T) {
 			required:      "1.0.0",
 			wantErr:       false,
 		},
+		{
+			name:          "version satisfies constraint",
+			configVersion: "1.2.0",
+			required:      "^1.1",
+			wantErr:       false,
+		},
+		{
+			name:          "version does not satisfy constraint",
+			configVersion: "2.0.0",
+			required:      ">=1.0 <2",
+			wantErr:       true,
+		},
 		{
 			name:          "version meets requirement",
 			configVersion: "1.2.0",
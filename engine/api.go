@@ -16,7 +16,10 @@ limitations under the License.
 
 package engine
 
-import "sync"
+import (
+	"sync"
+	"time"
+)
 
 type StartOptions struct {
 	Port            int
@@ -30,6 +33,30 @@ type StartOptions struct {
 	Environment     []string
 	DirMounts       []string
 	DebugMode       bool
+
+	// PublicUrl is the URL at which clients reach the mock, if requests
+	// pass through a front proxy in the CLI, such as to terminate TLS.
+	// If empty, clients connect directly to the engine on Port.
+	PublicUrl string
+}
+
+// BundleOptions control the contents and format of a bundle. Options that
+// do not apply to an engine type are ignored.
+type BundleOptions struct {
+	// Platforms are the target platforms, in the form os/arch[/variant],
+	// such as linux/arm64. If empty, the host platform is used.
+	Platforms []string
+
+	// Environment holds the default environment variables for the engine,
+	// in the form KEY=VALUE.
+	Environment []string
+
+	// Labels are added to the image.
+	Labels map[string]string
+
+	// OCI writes the image as an OCI image layout tarball, instead of
+	// building it with the Docker daemon.
+	OCI bool
 }
 
 type PullPolicy int
@@ -63,8 +90,9 @@ type Provider interface {
 
 	// Bundle creates a single archive file containing the engine binary and
 	// configuration files. The archive is written to the specified destination.
-	// If the engine type is 'docker', destination should be a valid image name.
-	Bundle(configDir string, dest string) error
+	// If the engine type is 'docker', destination should be a valid image name,
+	// unless the options specify an OCI image layout tarball.
+	Bundle(configDir string, dest string, options BundleOptions) error
 }
 
 type EngineLibrary interface {
@@ -82,6 +110,46 @@ type EngineLibrary interface {
 	ShouldEnsurePlugins() bool
 }
 
+// CachingLibrary is implemented by libraries that hold engine binaries
+// in a local cache, with a directory per version.
+type CachingLibrary interface {
+	EngineLibrary
+
+	// GetVersionDir returns the cache directory for the given engine version.
+	GetVersionDir(version string) (string, error)
+}
+
+// DigestLibrary is implemented by libraries whose engines are container
+// images, which are identified by their repository digest.
+type DigestLibrary interface {
+	EngineLibrary
+
+	// GetImageDigest returns the repository digest of the local engine
+	// image for the given version, or an empty string if it has none,
+	// such as an image that was built locally.
+	GetImageDigest(version string) (string, error)
+}
+
+// CachedEngine is an engine version held locally, such as a binary
+// in the cache or a container image.
+type CachedEngine struct {
+	EngineMetadata
+	Size     int64
+	Modified time.Time
+}
+
+// PrunableLibrary is implemented by libraries that can report the disk
+// usage of the engine versions they hold locally, and remove them.
+type PrunableLibrary interface {
+	EngineLibrary
+
+	// ListUsage returns the engine versions held locally, with their size.
+	ListUsage() ([]CachedEngine, error)
+
+	// Remove deletes the given engine version from local storage.
+	Remove(version string) error
+}
+
 type MockHealth string
 
 const (
@@ -94,6 +162,7 @@ type ManagedMock struct {
 	ID     string
 	Name   string
 	Port   int
+	URL    string
 	Health MockHealth
 }
 
@@ -0,0 +1,319 @@
+package engine
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"gatehill.io/imposter/library"
+	"gatehill.io/imposter/plugin"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const archiveManifestName = "manifest.json"
+
+// ArchiveManifest describes the contents of an engine archive.
+type ArchiveManifest struct {
+	Version     string        `json:"version"`
+	EngineTypes []EngineType  `json:"engineTypes"`
+	Plugins     []string      `json:"plugins,omitempty"`
+	Files       []ArchiveFile `json:"files"`
+	CreatedAt   time.Time     `json:"createdAt"`
+}
+
+// ArchiveFile is an entry in an engine archive. Path is relative to the
+// root of the archive, such as `engines/jvm/4.0.0/imposter.jar` or
+// `plugins/4.0.0/imposter-plugin-store-redis.jar`.
+type ArchiveFile struct {
+	Path   string `json:"path"`
+	Sha256 string `json:"sha256"`
+}
+
+// ExportArchive packs the cached engines of the given types, and the given
+// plugins, into a gzipped tar archive at dest, so they can be imported on a
+// machine without network access. Engines and plugins that are not in the
+// cache are pulled first.
+func ExportArchive(engineTypes []EngineType, version string, plugins []string, dest string) (*ArchiveManifest, error) {
+	manifest := &ArchiveManifest{
+		Version:     version,
+		EngineTypes: engineTypes,
+		Plugins:     plugins,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	// archive path -> local path
+	files := make(map[string]string)
+	var archivePaths []string
+	addFile := func(archivePath string, localPath string) {
+		files[archivePath] = localPath
+		archivePaths = append(archivePaths, archivePath)
+	}
+
+	for _, engineType := range engineTypes {
+		versionDir, err := getCacheVersionDir(engineType, version)
+		if err != nil {
+			return nil, err
+		}
+		if err = GetLibrary(engineType).GetProvider(version).Provide(PullIfNotPresent); err != nil {
+			return nil, fmt.Errorf("failed to pull engine type: %s version %s: %v", engineType, version, err)
+		}
+		entries, err := os.ReadDir(versionDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read engine cache directory: %s: %v", versionDir, err)
+		}
+		for _, entry := range entries {
+			if entry.Type().IsRegular() {
+				addFile(path.Join("engines", string(engineType), version, entry.Name()), filepath.Join(versionDir, entry.Name()))
+			}
+		}
+	}
+
+	if len(plugins) > 0 {
+		if _, err := plugin.EnsurePlugins(plugins, version, false); err != nil {
+			return nil, err
+		}
+		for _, pluginName := range plugins {
+			pluginFilePath, err := plugin.GetPluginFilePath(pluginName, version)
+			if err != nil {
+				return nil, err
+			}
+			addFile(path.Join("plugins", version, filepath.Base(pluginFilePath)), pluginFilePath)
+		}
+	}
+
+	for _, archivePath := range archivePaths {
+		hash, err := library.HashFile(files[archivePath])
+		if err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, ArchiveFile{Path: archivePath, Sha256: hash})
+	}
+
+	if err := writeArchive(dest, manifest, files); err != nil {
+		_ = os.Remove(dest)
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func writeArchive(dest string, manifest *ArchiveManifest, files map[string]string) error {
+	f, err := os.Create(dest)
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %s: %v", dest, err)
+	}
+	defer f.Close()
+	gzipWriter := gzip.NewWriter(f)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	manifestJson, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal archive manifest: %v", err)
+	}
+	if err = tarWriter.WriteHeader(&tar.Header{
+		Name:    archiveManifestName,
+		Mode:    0644,
+		Size:    int64(len(manifestJson)),
+		ModTime: manifest.CreatedAt,
+	}); err != nil {
+		return err
+	}
+	if _, err = tarWriter.Write(manifestJson); err != nil {
+		return err
+	}
+
+	for _, file := range manifest.Files {
+		if err = addArchiveFile(tarWriter, file.Path, files[file.Path]); err != nil {
+			return err
+		}
+	}
+
+	if err = tarWriter.Close(); err != nil {
+		return fmt.Errorf("failed to write archive: %s: %v", dest, err)
+	}
+	if err = gzipWriter.Close(); err != nil {
+		return fmt.Errorf("failed to write archive: %s: %v", dest, err)
+	}
+	return nil
+}
+
+func addArchiveFile(writer *tar.Writer, archivePath string, localPath string) error {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return fmt.Errorf("error opening file: %v", err)
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("error stating file: %v", err)
+	}
+	header, err := tar.FileInfoHeader(stat, "")
+	if err != nil {
+		return fmt.Errorf("error creating tar header for: %v: %v", localPath, err)
+	}
+	header.Name = archivePath
+
+	if err = writer.WriteHeader(header); err != nil {
+		return fmt.Errorf("error writing tar header for: %v: %v", localPath, err)
+	}
+	if _, err = io.Copy(writer, f); err != nil {
+		return fmt.Errorf("error writing file to archive: %v: %v", localPath, err)
+	}
+	return nil
+}
+
+// ImportArchive restores the engines and plugins in an archive created by
+// ExportArchive into the local caches. The hash of each file is checked
+// against the archive manifest.
+func ImportArchive(src string) (*ArchiveManifest, error) {
+	manifest, err := readArchiveManifest(src)
+	if err != nil {
+		return nil, err
+	}
+	expected := make(map[string]string)
+	for _, file := range manifest.Files {
+		expected[file.Path] = file.Sha256
+	}
+
+	f, err := os.Open(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %s: %v", src, err)
+	}
+	defer f.Close()
+	gzipReader, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %s: %v", src, err)
+	}
+	tarReader := tar.NewReader(gzipReader)
+
+	imported := 0
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %s: %v", src, err)
+		}
+		if header.Name == archiveManifestName || header.Typeflag != tar.TypeReg {
+			continue
+		}
+		hash, found := expected[header.Name]
+		if !found {
+			logger.Warnf("skipping file not listed in archive manifest: %s", header.Name)
+			continue
+		}
+		localPath, err := resolveImportPath(header.Name)
+		if err != nil {
+			return nil, err
+		}
+		if err = extractArchiveFile(tarReader, localPath, header.FileInfo().Mode(), hash, src); err != nil {
+			return nil, err
+		}
+		logger.Debugf("imported %s to %s", header.Name, localPath)
+		imported++
+	}
+	if imported != len(manifest.Files) {
+		return nil, fmt.Errorf("archive: %s is incomplete - expected %d files but found %d", src, len(manifest.Files), imported)
+	}
+	return manifest, nil
+}
+
+func readArchiveManifest(src string) (*ArchiveManifest, error) {
+	f, err := os.Open(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %s: %v", src, err)
+	}
+	defer f.Close()
+	gzipReader, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %s: %v", src, err)
+	}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("no manifest found in archive: %s", src)
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %s: %v", src, err)
+		}
+		if header.Name != archiveManifestName {
+			continue
+		}
+		var manifest ArchiveManifest
+		if err = json.NewDecoder(tarReader).Decode(&manifest); err != nil {
+			return nil, fmt.Errorf("failed to parse archive manifest: %s: %v", src, err)
+		}
+		return &manifest, nil
+	}
+}
+
+// resolveImportPath maps a path within an archive to its location
+// in the local engine or plugin cache.
+func resolveImportPath(archivePath string) (string, error) {
+	parts := strings.Split(archivePath, "/")
+	for _, part := range parts {
+		if part == "" || part == "." || part == ".." {
+			return "", fmt.Errorf("invalid path in archive: %s", archivePath)
+		}
+	}
+	switch {
+	case len(parts) == 4 && parts[0] == "engines":
+		versionDir, err := getCacheVersionDir(EngineType(parts[1]), parts[2])
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(versionDir, parts[3]), nil
+	case len(parts) == 3 && parts[0] == "plugins":
+		pluginDir, err := plugin.EnsurePluginDir(parts[1])
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(pluginDir, parts[2]), nil
+	default:
+		return "", fmt.Errorf("unexpected path in archive: %s", archivePath)
+	}
+}
+
+func getCacheVersionDir(engineType EngineType, version string) (string, error) {
+	if err := validateEngineType(engineType); err != nil {
+		return "", err
+	}
+	cachingLibrary, ok := GetLibrary(engineType).(CachingLibrary)
+	if !ok {
+		return "", fmt.Errorf("engine type: %s does not cache engine binaries", engineType)
+	}
+	return cachingLibrary.GetVersionDir(version)
+}
+
+// extractArchiveFile writes the file to localPath, failing if its
+// hash does not match the expected value. The hash is recorded, with
+// the archive as its source, so the file can be checked later.
+func extractArchiveFile(reader io.Reader, localPath string, mode os.FileMode, expectedHash string, source string) error {
+	if err := os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %s: %v", filepath.Dir(localPath), err)
+	}
+	f, err := os.OpenFile(localPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm())
+	if err != nil {
+		return fmt.Errorf("failed to create file: %s: %v", localPath, err)
+	}
+	_, err = io.Copy(f, reader)
+	_ = f.Close()
+	if err != nil {
+		return fmt.Errorf("failed to write file: %s: %v", localPath, err)
+	}
+
+	hash, err := library.HashFile(localPath)
+	if err != nil {
+		return err
+	}
+	if hash != expectedHash {
+		_ = os.Remove(localPath)
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", localPath, expectedHash, hash)
+	}
+	return library.RecordChecksum(localPath, source, false)
+}
@@ -0,0 +1,102 @@
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCachingLibrary struct {
+	cacheDir string
+	versions []string
+}
+
+func (l fakeCachingLibrary) CheckPrereqs() (bool, []string) { return true, nil }
+func (l fakeCachingLibrary) List() ([]EngineMetadata, error) {
+	var engines []EngineMetadata
+	for _, version := range l.versions {
+		engines = append(engines, EngineMetadata{EngineType: EngineTypeGolang, Version: version})
+	}
+	return engines, nil
+}
+func (l fakeCachingLibrary) GetProvider(string) Provider { return fakeProvider{} }
+func (l fakeCachingLibrary) IsSealedDistro() bool        { return false }
+func (l fakeCachingLibrary) ShouldEnsurePlugins() bool   { return false }
+func (l fakeCachingLibrary) GetVersionDir(version string) (string, error) {
+	return filepath.Join(l.cacheDir, version), nil
+}
+
+type fakeProvider struct{}
+
+func (fakeProvider) Satisfied() bool                            { return true }
+func (fakeProvider) Provide(PullPolicy) error                   { return nil }
+func (fakeProvider) GetEngineType() EngineType                  { return EngineTypeGolang }
+func (fakeProvider) Build(string, StartOptions) MockEngine      { return nil }
+func (fakeProvider) Bundle(string, string, BundleOptions) error { return nil }
+
+// registerFakeLibrary registers a caching library for the golang engine
+// type, backed by the given directory.
+func registerFakeLibrary(t *testing.T, cacheDir string) {
+	original := libraries[EngineTypeGolang]
+	RegisterLibrary(EngineTypeGolang, func() EngineLibrary {
+		return fakeCachingLibrary{cacheDir: cacheDir}
+	})
+	t.Cleanup(func() {
+		libraries[EngineTypeGolang] = original
+	})
+}
+
+func TestExportImportArchive(t *testing.T) {
+	tempDir := t.TempDir()
+	viper.Set("prefs.dir", filepath.Join(tempDir, "prefs"))
+	viper.Set("plugin.baseDir", filepath.Join(tempDir, "plugins"))
+	t.Cleanup(func() {
+		viper.Set("prefs.dir", nil)
+		viper.Set("plugin.baseDir", nil)
+	})
+
+	// source cache, with an engine binary and a plugin
+	sourceCacheDir := filepath.Join(tempDir, "source")
+	require.NoError(t, os.MkdirAll(filepath.Join(sourceCacheDir, "1.2.3"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(sourceCacheDir, "1.2.3", "imposter-go"), []byte("binary"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "plugins", "1.2.3"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "plugins", "1.2.3", "imposter-plugin-store-redis.jar"), []byte("plugin"), 0644))
+	registerFakeLibrary(t, sourceCacheDir)
+
+	archivePath := filepath.Join(tempDir, "engines.tar.gz")
+	manifest, err := ExportArchive([]EngineType{EngineTypeGolang}, "1.2.3", []string{"store-redis"}, archivePath)
+	require.NoError(t, err)
+	require.Len(t, manifest.Files, 2)
+	require.Equal(t, "engines/golang/1.2.3/imposter-go", manifest.Files[0].Path)
+	require.Equal(t, "plugins/1.2.3/imposter-plugin-store-redis.jar", manifest.Files[1].Path)
+
+	// import into empty caches
+	targetCacheDir := filepath.Join(tempDir, "target")
+	registerFakeLibrary(t, targetCacheDir)
+	viper.Set("plugin.baseDir", filepath.Join(tempDir, "target-plugins"))
+
+	imported, err := ImportArchive(archivePath)
+	require.NoError(t, err)
+	require.Equal(t, "1.2.3", imported.Version)
+
+	binary, err := os.ReadFile(filepath.Join(targetCacheDir, "1.2.3", "imposter-go"))
+	require.NoError(t, err)
+	require.Equal(t, "binary", string(binary))
+	stat, err := os.Stat(filepath.Join(targetCacheDir, "1.2.3", "imposter-go"))
+	require.NoError(t, err)
+	require.NotZero(t, stat.Mode()&0100, "binary should be executable")
+
+	plugin, err := os.ReadFile(filepath.Join(tempDir, "target-plugins", "1.2.3", "imposter-plugin-store-redis.jar"))
+	require.NoError(t, err)
+	require.Equal(t, "plugin", string(plugin))
+}
+
+func TestResolveImportPath_Invalid(t *testing.T) {
+	for _, archivePath := range []string{"engines/golang/../../etc/passwd", "other/file", "plugins/1.2.3"} {
+		_, err := resolveImportPath(archivePath)
+		require.Error(t, err, archivePath)
+	}
+}
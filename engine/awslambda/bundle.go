@@ -20,18 +20,34 @@ import (
 	"archive/zip"
 	"bytes"
 	"fmt"
+	"gatehill.io/imposter/engine"
 	"gatehill.io/imposter/fileutil"
 	"io"
 	"os"
 	"path"
+	"path/filepath"
 	"strings"
 )
 
-func (p *LambdaProvider) Bundle(configDir string, dest string) error {
+// Quotas on the size of a Lambda deployment package.
+const (
+	// MaxDirectUploadSize is the limit on the size of a zipped deployment
+	// package uploaded directly to Lambda, rather than from S3.
+	MaxDirectUploadSize = 50 * 1024 * 1024
+
+	// maxUnzippedSize is the limit on the size of the unzipped deployment
+	// package, however it is uploaded.
+	maxUnzippedSize = 250 * 1024 * 1024
+)
+
+func (p *LambdaProvider) Bundle(configDir string, dest string, options engine.BundleOptions) error {
 	deploymentPackage, err := CreateDeploymentPackage(p.Version, configDir)
 	if err != nil {
 		return fmt.Errorf("failed to create bundle: %v", err)
 	}
+	if err = CheckDirectUploadSize(deploymentPackage); err != nil {
+		logger.Warnf("%v - upload the bundle to S3 and deploy it from there", err)
+	}
 
 	if _, err := os.Stat(dest); err == nil {
 		return fmt.Errorf("destination bundle file already exists: %s", dest)
@@ -44,40 +60,58 @@ func (p *LambdaProvider) Bundle(configDir string, dest string) error {
 	return nil
 }
 
+// CreateDeploymentPackage returns a ZIP file holding the engine and the
+// files in the config dir, keeping their paths relative to the config dir.
+// Files excluded by the .imposterignore file in the config dir are skipped.
 func CreateDeploymentPackage(version string, dir string) (*[]byte, error) {
 	binaryPath, err := checkOrDownloadBinary(version)
 	if err != nil {
 		return nil, err
 	}
-	local, err := fileutil.ListFiles(dir, false)
+	local, err := fileutil.ListProjectFiles(dir)
 	if err != nil {
 		return nil, err
 	}
-	pkg, err := addFilesToZip(binaryPath, local)
+	pkg, unzippedSize, err := addFilesToZip(binaryPath, dir, local)
 	if err != nil {
 		return nil, err
 	}
+	if unzippedSize > maxUnzippedSize {
+		return nil, fmt.Errorf("deployment package is %s unzipped, which exceeds the AWS Lambda limit of %s, even when uploaded to S3 - exclude files from the package using a %s file", formatSize(unzippedSize), formatSize(maxUnzippedSize), fileutil.IgnoreFileName)
+	}
 	logger.Debug("created deployment package")
 	contents := pkg.Bytes()
 	return &contents, nil
 }
 
-func addFilesToZip(zipPath string, files []string) (*bytes.Buffer, error) {
+// CheckDirectUploadSize returns an error if the deployment package is too
+// large to upload directly to Lambda, so it must be uploaded to S3.
+func CheckDirectUploadSize(deploymentPackage *[]byte) error {
+	if size := int64(len(*deploymentPackage)); size > MaxDirectUploadSize {
+		return fmt.Errorf("deployment package is %s, which exceeds the AWS Lambda limit of %s for direct upload", formatSize(size), formatSize(MaxDirectUploadSize))
+	}
+	return nil
+}
+
+// addFilesToZip returns a copy of the ZIP file at zipPath with the files
+// added under the config directory, at their paths relative to baseDir,
+// along with the total unzipped size of the contents.
+func addFilesToZip(zipPath string, baseDir string, files []string) (*bytes.Buffer, int64, error) {
 	zr, err := zip.OpenReader(zipPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open source zip: %s: %v", zipPath, err)
+		return nil, 0, fmt.Errorf("failed to open source zip: %s: %v", zipPath, err)
 	}
 	defer zr.Close()
 
 	dst := new(bytes.Buffer)
 	zw := zip.NewWriter(dst)
-	defer zw.Close()
+	var unzippedSize int64
 
 	// copy existing
 	for _, zipItem := range zr.File {
 		zipItemReader, err := zipItem.OpenRaw()
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 		header := zipItem.FileHeader
 
@@ -86,27 +120,44 @@ func addFilesToZip(zipPath string, files []string) (*bytes.Buffer, error) {
 			continue
 		}
 		targetItem, err := zw.CreateRaw(&header)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to copy zip item: %s: %v", header.Name, err)
+		}
 		_, err = io.Copy(targetItem, zipItemReader)
 		if err != nil {
-			return nil, fmt.Errorf("failed to copy zip item: %s: %v", header.Name, err)
+			return nil, 0, fmt.Errorf("failed to copy zip item: %s: %v", header.Name, err)
 		}
+		unzippedSize += int64(header.UncompressedSize64)
 	}
 
 	logger.Infof("bundling %d files from workspace", len(files))
 	for _, localFile := range files {
-		logger.Tracef("bundling %s", localFile)
-		f, err := zw.Create(path.Join("config", path.Base(localFile)))
+		relPath, err := filepath.Rel(baseDir, localFile)
+		if err != nil {
+			return nil, 0, err
+		}
+		logger.Tracef("bundling %s", relPath)
+		f, err := zw.Create(path.Join("config", filepath.ToSlash(relPath)))
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 		contents, err := fileutil.ReadFile(localFile)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 		if _, err = f.Write(*contents); err != nil {
-			return nil, err
+			return nil, 0, err
 		}
+		unzippedSize += int64(len(*contents))
+	}
+
+	if err = zw.Close(); err != nil {
+		return nil, 0, err
 	}
+	return dst, unzippedSize, nil
+}
 
-	return dst, nil
+// formatSize returns the number of bytes in mebibytes.
+func formatSize(bytes int64) string {
+	return fmt.Sprintf("%.1f MiB", float64(bytes)/(1024*1024))
 }
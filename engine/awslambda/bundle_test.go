@@ -0,0 +1,58 @@
+package awslambda
+
+import (
+	"archive/zip"
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_addFilesToZip(t *testing.T) {
+	srcZip := filepath.Join(t.TempDir(), "engine.zip")
+	f, err := os.Create(srcZip)
+	require.NoError(t, err)
+	zw := zip.NewWriter(f)
+	w, err := zw.Create("lib/engine.jar")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("engine"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	require.NoError(t, f.Close())
+
+	configDir := t.TempDir()
+	var files []string
+	for _, name := range []string{"imposter-config.yaml", "responses/a/data.json", "responses/b/data.json"} {
+		file := filepath.Join(configDir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+		require.NoError(t, os.WriteFile(file, []byte(name), 0644))
+		files = append(files, file)
+	}
+
+	pkg, unzippedSize, err := addFilesToZip(srcZip, configDir, files)
+	require.NoError(t, err)
+
+	zr, err := zip.NewReader(bytes.NewReader(pkg.Bytes()), int64(pkg.Len()))
+	require.NoError(t, err)
+	var names []string
+	for _, file := range zr.File {
+		names = append(names, file.Name)
+	}
+	require.ElementsMatch(t, []string{
+		"lib/engine.jar",
+		"config/imposter-config.yaml",
+		"config/responses/a/data.json",
+		"config/responses/b/data.json",
+	}, names)
+	require.Equal(t, int64(len("engine")+len("imposter-config.yaml")+2*len("responses/a/data.json")), unzippedSize)
+}
+
+func TestCheckDirectUploadSize(t *testing.T) {
+	small := make([]byte, 1024)
+	require.NoError(t, CheckDirectUploadSize(&small))
+
+	large := make([]byte, MaxDirectUploadSize+1)
+	err := CheckDirectUploadSize(&large)
+	require.ErrorContains(t, err, "exceeds the AWS Lambda limit of 50.0 MiB")
+}
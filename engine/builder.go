@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"gatehill.io/imposter/logging"
+	"gatehill.io/imposter/semverutil"
 	"gatehill.io/imposter/stringutil"
 	"github.com/spf13/viper"
 )
@@ -125,18 +126,40 @@ func GetConfiguredVersion(override string, allowCached bool) string {
 	return GetConfiguredVersionOrResolve(override, allowCached, true)
 }
 
+// GetConfiguredVersionForType returns the configured version, resolving
+// 'latest' or a version constraint. Constraints are resolved against the
+// installed versions of the given engine type first.
+func GetConfiguredVersionForType(override string, engineType EngineType, allowCached bool) string {
+	return getConfiguredVersion(override, engineType, allowCached, true)
+}
+
+// GetConfiguredVersionOrResolve returns the configured version. If resolveIfLatest
+// is true, 'latest' or a version constraint is resolved to a specific version.
 func GetConfiguredVersionOrResolve(override string, allowCached bool, resolveIfLatest bool) string {
+	return getConfiguredVersion(override, GetConfiguredType(""), allowCached, resolveIfLatest)
+}
+
+func getConfiguredVersion(override string, engineType EngineType, allowCached bool, resolve bool) string {
 	version := stringutil.GetFirstNonEmpty(
 		override,
 		viper.GetString("version"),
 		"latest",
 	)
-	if version == "latest" && resolveIfLatest {
+	if !resolve {
+		return version
+	}
+	if version == "latest" {
 		latest, err := ResolveLatestToVersion(allowCached)
 		if err != nil {
 			panic(err)
 		}
 		version = latest
+	} else if semverutil.IsConstraint(version) {
+		resolved, err := ResolveConstraintToVersion(version, engineType, allowCached)
+		if err != nil {
+			panic(err)
+		}
+		version = resolved
 	}
 	return version
 }
@@ -179,6 +202,9 @@ func buildEnvFromParent(parentEnv []string, options StartOptions, includeHome bo
 	if !stringutil.ContainsPrefix(env, "IMPOSTER_LOG_LEVEL=") {
 		env = append(env, "IMPOSTER_LOG_LEVEL="+strings.ToUpper(options.LogLevel))
 	}
+	if options.PublicUrl != "" {
+		env = append(env, PublicUrlEnvVar+"="+options.PublicUrl)
+	}
 
 	return env
 }
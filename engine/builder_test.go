@@ -100,6 +100,7 @@ func TestBuildEnvFromParent(t *testing.T) {
 		{name: "should set log level", args: args{options: StartOptions{LogLevel: "WARN"}, includeHome: false, env: []string{}}, wantPrefixes: []string{"IMPOSTER_LOG_LEVEL=WARN"}},
 		{name: "should pass through imposter env var", args: args{options: StartOptions{LogLevel: "WARN"}, includeHome: false, env: []string{"IMPOSTER_TEST=foo"}}, wantPrefixes: []string{"IMPOSTER_TEST=foo"}},
 		{name: "should pass through log level env var", args: args{options: StartOptions{LogLevel: "WARN"}, includeHome: false, env: []string{"IMPOSTER_LOG_LEVEL=ERROR"}}, wantPrefixes: []string{"IMPOSTER_LOG_LEVEL=ERROR"}},
+		{name: "should set public url", args: args{options: StartOptions{LogLevel: "WARN", PublicUrl: "https://localhost:8443"}, includeHome: false, env: []string{}}, wantPrefixes: []string{"IMPOSTER_CLI_PUBLIC_URL=https://localhost:8443"}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,165 @@
+package engine
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"gatehill.io/imposter/fileutil"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// BundleFile is a file written to a bundle archive.
+type BundleFile struct {
+	// Name is the slash-separated path of the file in the archive.
+	Name string
+
+	// Path is the local file to copy, if Content is nil.
+	Path string
+
+	// Content is the content of the file.
+	Content []byte
+
+	// Mode holds the permission bits of the file.
+	Mode int64
+}
+
+// Open returns a reader for the content of the file.
+func (f BundleFile) Open() (io.ReadCloser, error) {
+	if f.Content != nil {
+		return io.NopCloser(bytes.NewReader(f.Content)), nil
+	}
+	file, err := os.Open(f.Path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file: %v", err)
+	}
+	return file, nil
+}
+
+// ListBundleFiles returns the files in dir and its subdirectories, named
+// by their paths relative to dir, under the prefix in the archive. Unless
+// includeHidden is true, hidden files and directories are skipped.
+func ListBundleFiles(dir string, prefix string, includeHidden bool) ([]BundleFile, error) {
+	local, err := fileutil.ListFilesRecursive(dir, includeHidden)
+	if err != nil {
+		return nil, err
+	}
+	return toBundleFiles(dir, prefix, local)
+}
+
+// ListConfigBundleFiles returns the files in the config dir, as returned by
+// fileutil.ListProjectFiles, named by their paths relative to the config
+// dir, under the prefix in the archive.
+func ListConfigBundleFiles(configDir string, prefix string) ([]BundleFile, error) {
+	local, err := fileutil.ListProjectFiles(configDir)
+	if err != nil {
+		return nil, err
+	}
+	return toBundleFiles(configDir, prefix, local)
+}
+
+func toBundleFiles(dir string, prefix string, local []string) ([]BundleFile, error) {
+	var files []BundleFile
+	for _, localFile := range local {
+		relPath, err := filepath.Rel(dir, localFile)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, BundleFile{
+			Name: path.Join(prefix, filepath.ToSlash(relPath)),
+			Path: localFile,
+			Mode: 0644,
+		})
+	}
+	return files, nil
+}
+
+// WriteZipBundle writes the files to a ZIP file at dest, under rootDir.
+func WriteZipBundle(dest string, rootDir string, files []BundleFile) error {
+	f, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	zipWriter := zip.NewWriter(f)
+
+	for _, file := range files {
+		header := &zip.FileHeader{Name: path.Join(rootDir, file.Name), Method: zip.Deflate}
+		header.SetMode(os.FileMode(file.Mode))
+		w, err := zipWriter.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		r, err := file.Open()
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(w, r)
+		_ = r.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return zipWriter.Close()
+}
+
+// GetBundleRootDir returns the name of the directory in the archive that
+// holds the bundle files, which is the name of the archive file without
+// its extension.
+func GetBundleRootDir(dest string) string {
+	name := filepath.Base(dest)
+	for _, ext := range []string{".tar.gz", ".tgz", ".zip"} {
+		if strings.HasSuffix(name, ext) && len(name) > len(ext) {
+			return strings.TrimSuffix(name, ext)
+		}
+	}
+	return "imposter-bundle"
+}
+
+// ShellEnvDefaults returns the lines of a shell script that export the
+// environment variables, unless they are already set by the caller.
+func ShellEnvDefaults(env []string) string {
+	var lines strings.Builder
+	for _, e := range sortedScriptEnv(env) {
+		key, value, _ := strings.Cut(e, "=")
+		lines.WriteString(fmt.Sprintf("if [ -z \"${%s+x}\" ]; then export %s=%s; fi\n", key, key, quoteShellValue(value)))
+	}
+	return lines.String()
+}
+
+// BatchEnvDefaults returns the Windows batch file equivalent of the lines
+// returned by ShellEnvDefaults.
+func BatchEnvDefaults(env []string) string {
+	var lines strings.Builder
+	for _, e := range sortedScriptEnv(env) {
+		key, value, _ := strings.Cut(e, "=")
+		lines.WriteString(fmt.Sprintf("if not defined %s set \"%s=%s\"\r\n", key, key, strings.ReplaceAll(value, "%", "%%")))
+	}
+	return lines.String()
+}
+
+// quoteShellValue returns the value in single quotes, so it is not
+// expanded by the shell.
+func quoteShellValue(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// sortedScriptEnv returns the environment variables with valid names,
+// sorted so the script is reproducible.
+func sortedScriptEnv(env []string) []string {
+	var valid []string
+	for _, e := range env {
+		key, _, _ := strings.Cut(e, "=")
+		if key == "" || strings.ContainsAny(key, " \t\"'%$`\\") {
+			logger.Warnf("skipping invalid environment variable name: %s", key)
+			continue
+		}
+		valid = append(valid, e)
+	}
+	sort.Strings(valid)
+	return valid
+}
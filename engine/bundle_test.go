@@ -0,0 +1,77 @@
+package engine
+
+import (
+	"archive/zip"
+	"github.com/stretchr/testify/require"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteZipBundle(t *testing.T) {
+	local := filepath.Join(t.TempDir(), "local.txt")
+	require.NoError(t, os.WriteFile(local, []byte("local"), 0644))
+
+	dest := filepath.Join(t.TempDir(), "bundle.zip")
+	err := WriteZipBundle(dest, "bundle", []BundleFile{
+		{Name: "config/local.txt", Path: local, Mode: 0644},
+		{Name: "run.sh", Content: []byte("#!/bin/sh\n"), Mode: 0755},
+	})
+	require.NoError(t, err)
+
+	zipReader, err := zip.OpenReader(dest)
+	require.NoError(t, err)
+	defer zipReader.Close()
+
+	contents := make(map[string]string)
+	for _, file := range zipReader.File {
+		r, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		_ = r.Close()
+		contents[file.Name] = string(content)
+		if file.Name == "bundle/run.sh" {
+			require.Equal(t, os.FileMode(0755), file.Mode().Perm())
+		}
+	}
+	require.Equal(t, map[string]string{
+		"bundle/config/local.txt": "local",
+		"bundle/run.sh":           "#!/bin/sh\n",
+	}, contents)
+}
+
+func TestListBundleFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, file := range []string{"a.yaml", "nested/b.json", ".imposter.yaml"} {
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, nil, 0644))
+	}
+
+	files, err := ListBundleFiles(dir, "config", false)
+	require.NoError(t, err)
+	var names []string
+	for _, file := range files {
+		names = append(names, file.Name)
+	}
+	require.ElementsMatch(t, []string{"config/a.yaml", "config/nested/b.json"}, names)
+}
+
+func TestGetBundleRootDir(t *testing.T) {
+	require.Equal(t, "petstore", GetBundleRootDir("/tmp/petstore.tar.gz"))
+	require.Equal(t, "petstore", GetBundleRootDir("petstore.zip"))
+	require.Equal(t, "imposter-bundle", GetBundleRootDir("petstore"))
+}
+
+func TestShellEnvDefaults(t *testing.T) {
+	lines := ShellEnvDefaults([]string{"B=it's $HOME", "A=1", "BAD NAME=x"})
+	require.Equal(t, "if [ -z \"${A+x}\" ]; then export A='1'; fi\n"+
+		"if [ -z \"${B+x}\" ]; then export B='it'\\''s $HOME'; fi\n", lines)
+}
+
+func TestBatchEnvDefaults(t *testing.T) {
+	lines := BatchEnvDefaults([]string{"A=100%"})
+	require.Equal(t, "if not defined A set \"A=100%%\"\r\n", lines)
+}
@@ -5,14 +5,17 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"gatehill.io/imposter/engine"
 	"gatehill.io/imposter/fileutil"
 	"github.com/docker/docker/api/types"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 const buildContextConfigDir = "config"
+const buildContextPluginDir = "plugins"
 const bundleConfigDestDir = "/opt/imposter/config"
 
 type buildOutput struct {
@@ -21,7 +24,7 @@ type buildOutput struct {
 }
 
 // buildImage builds a Docker image using the specified build context.
-func buildImage(buildCtx *bytes.Buffer, destImageAndTag string) error {
+func buildImage(buildCtx *bytes.Buffer, destImageAndTag string, options engine.BundleOptions) error {
 	logger.Tracef("building image with tag %s", destImageAndTag)
 	ctx, cli, err := buildCliClient()
 	if err != nil {
@@ -29,15 +32,18 @@ func buildImage(buildCtx *bytes.Buffer, destImageAndTag string) error {
 	}
 	defer cli.Close()
 
+	var platform string
+	if len(options.Platforms) > 0 {
+		platform = options.Platforms[0]
+	}
 	buildResponse, err := cli.ImageBuild(
 		ctx,
 		buildCtx,
 		types.ImageBuildOptions{
 			Dockerfile: "Dockerfile",
 			Tags:       []string{destImageAndTag},
-			Labels: map[string]string{
-				"builtwith": "imposter-cli",
-			},
+			Labels:     buildLabels(options.Labels),
+			Platform:   platform,
 		},
 	)
 	if err != nil {
@@ -46,33 +52,63 @@ func buildImage(buildCtx *bytes.Buffer, destImageAndTag string) error {
 	return awaitBuildComplete(buildResponse)
 }
 
-// addFilesToTar adds the files in the specified directory to a tar archive.
-func addFilesToTar(dir string, parentImage string) (*bytes.Buffer, error) {
+// buildLabels returns the labels for a bundle image, including the custom labels.
+func buildLabels(custom map[string]string) map[string]string {
+	labels := map[string]string{
+		"builtwith": "imposter-cli",
+	}
+	for k, v := range custom {
+		labels[k] = v
+	}
+	return labels
+}
+
+// addFilesToTar adds the files in the specified directory, the plugin files
+// and a Dockerfile to a tar archive, for use as a build context.
+func addFilesToTar(dir string, pluginFiles []string, parentImage string, env []string) (*bytes.Buffer, error) {
 	buf := new(bytes.Buffer)
 	tarWriter := tar.NewWriter(buf)
 
-	local, err := fileutil.ListFiles(dir, false)
+	local, err := fileutil.ListProjectFiles(dir)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, localFile := range local {
-		err := addFileToTar(tarWriter, dir, localFile)
+		// prepending "config/" to the path to match the Dockerfile COPY instruction
+		relPath, _ := filepath.Rel(dir, localFile)
+		err := addFileToTar(tarWriter, localFile, buildContextConfigDir+"/"+filepath.ToSlash(relPath))
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, pluginFile := range pluginFiles {
+		err := addFileToTar(tarWriter, pluginFile, buildContextPluginDir+"/"+filepath.Base(pluginFile))
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	err = addDockerfile(tarWriter, parentImage)
+	err = addDockerfile(tarWriter, parentImage, len(pluginFiles) > 0, env)
+	if err != nil {
+		return nil, err
+	}
 
 	tarWriter.Close()
 	return buf, nil
 }
 
-func addDockerfile(tarWriter *tar.Writer, parentImage string) error {
+func addDockerfile(tarWriter *tar.Writer, parentImage string, hasPlugins bool, env []string) error {
 	dockerfileContent := fmt.Sprintf(`FROM %s
 COPY %s %s
 `, parentImage, buildContextConfigDir, bundleConfigDestDir)
+	if hasPlugins {
+		dockerfileContent += fmt.Sprintf("COPY %s %s\n", buildContextPluginDir, containerPluginDir)
+	}
+	for _, e := range env {
+		key, value, _ := strings.Cut(e, "=")
+		dockerfileContent += fmt.Sprintf("ENV %s=%s\n", key, quoteDockerfileValue(value))
+	}
 
 	header := &tar.Header{
 		Name: "Dockerfile",
@@ -91,8 +127,15 @@ COPY %s %s
 	return nil
 }
 
-// addFileToTar adds the specified file to the tar archive.
-func addFileToTar(writer *tar.Writer, baseDir string, file string) error {
+// quoteDockerfileValue quotes the value for use in a Dockerfile instruction,
+// escaping characters that would otherwise be interpreted, such as variables.
+func quoteDockerfileValue(value string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`).Replace(value)
+	return `"` + escaped + `"`
+}
+
+// addFileToTar adds the specified file to the tar archive with the given name.
+func addFileToTar(writer *tar.Writer, file string, name string) error {
 	f, err := os.Open(file)
 	if err != nil {
 		return fmt.Errorf("error opening file: %v", err)
@@ -109,10 +152,7 @@ func addFileToTar(writer *tar.Writer, baseDir string, file string) error {
 		return fmt.Errorf("error creating tar header for: %v: %v", file, err)
 	}
 
-	// update the name to correctly reflect the relative path from the base dir
-	// prepending "config/" to the path to match the Dockerfile COPY instruction
-	relPath, _ := filepath.Rel(baseDir, file)
-	header.Name = buildContextConfigDir + "/" + relPath
+	header.Name = name
 
 	if err := writer.WriteHeader(header); err != nil {
 		return err
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 )
@@ -20,9 +21,38 @@ func Test_addFilesToTar(t *testing.T) {
 		t.Fatal(fmt.Errorf("error writing test config file: %s", err.Error()))
 	}
 
+	pluginDir := t.TempDir()
+	plugin := []byte("plugin jar")
+	pluginFile := pluginDir + "/imposter-plugin-store-redis.jar"
+	err = os.WriteFile(pluginFile, plugin, 0644)
+	if err != nil {
+		t.Fatal(fmt.Errorf("error writing test plugin file: %s", err.Error()))
+	}
+	ignoreDir := t.TempDir()
+	for name, content := range map[string][]byte{
+		".imposterignore":          []byte("scratch.txt\n"),
+		"nested/test-config-yaml":  config,
+		"node_modules/index.js":    []byte("ignored"),
+		"scratch.txt":              []byte("ignored"),
+		"test-config-yaml.swp":     []byte("ignored"),
+		".imposter/workspace.json": []byte("ignored"),
+	} {
+		path := filepath.Join(ignoreDir, filepath.FromSlash(name))
+		if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err = os.WriteFile(path, content, 0644); err != nil {
+			t.Fatal(fmt.Errorf("error writing test file: %s", err.Error()))
+		}
+	}
+
+	dockerfileWithPlugins := []byte("FROM imposter:latest\nCOPY config /opt/imposter/config\nCOPY plugins /opt/imposter/plugins\nENV IMPOSTER_FOO=\"bar \\$HOME\"\n")
+
 	type args struct {
 		dir         string
+		pluginFiles []string
 		parentImage string
+		env         []string
 	}
 	type want struct {
 		header tar.Header
@@ -58,10 +88,67 @@ func Test_addFilesToTar(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "should add plugins and environment",
+			args: args{
+				dir:         tempDir,
+				pluginFiles: []string{pluginFile},
+				parentImage: "imposter:latest",
+				env:         []string{"IMPOSTER_FOO=bar $HOME"},
+			},
+			want: []want{
+				{
+					header: tar.Header{
+						Name: "config/test-config-yaml",
+						Size: int64(len(config)),
+					},
+					body: config,
+				},
+				{
+					header: tar.Header{
+						Name: "plugins/imposter-plugin-store-redis.jar",
+						Size: int64(len(plugin)),
+					},
+					body: plugin,
+				},
+				{
+					header: tar.Header{
+						Name: "Dockerfile",
+						Size: int64(len(dockerfileWithPlugins)),
+					},
+					body: dockerfileWithPlugins,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should add nested files and skip ignored files",
+			args: args{
+				dir:         ignoreDir,
+				parentImage: "imposter:latest",
+			},
+			want: []want{
+				{
+					header: tar.Header{
+						Name: "config/nested/test-config-yaml",
+						Size: int64(len(config)),
+					},
+					body: config,
+				},
+				{
+					header: tar.Header{
+						Name: "Dockerfile",
+						Size: int64(len(dockerfile)),
+					},
+					body: dockerfile,
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := addFilesToTar(tt.args.dir, tt.args.parentImage)
+			got, err := addFilesToTar(tt.args.dir, tt.args.pluginFiles, tt.args.parentImage, tt.args.env)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("addFilesToTar() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -74,7 +74,17 @@ func (d *DockerMockEngine) startWithOptions(wg *sync.WaitGroup, options engine.S
 	containerUser := viper.GetString("docker.containerUser")
 	logger.Tracef("container user: %s", containerUser)
 
-	exposedPorts, portBindings := buildPorts(options)
+	containerOpts, err := loadContainerOptions()
+	if err != nil {
+		logger.Fatal(err)
+	}
+	hostConfig := &container.HostConfig{
+		Binds: buildBinds(d, options),
+	}
+	containerOpts.applyTo(hostConfig)
+
+	exposedPorts, portBindings := buildPorts(options, containerOpts.hostIP)
+	hostConfig.PortBindings = portBindings
 	resp, err := cli.ContainerCreate(ctx, &container.Config{
 		Image: d.provider.imageAndTag,
 		Cmd: []string{
@@ -85,10 +95,7 @@ func (d *DockerMockEngine) startWithOptions(wg *sync.WaitGroup, options engine.S
 		ExposedPorts: exposedPorts,
 		Labels:       containerLabels,
 		User:         containerUser,
-	}, &container.HostConfig{
-		Binds:        buildBinds(d, options),
-		PortBindings: portBindings,
-	}, nil, nil, "")
+	}, hostConfig, containerOpts.networkingConfig(), nil, containerOpts.name)
 	if err != nil {
 		logger.Fatal(err)
 	}
@@ -112,7 +119,7 @@ func (d *DockerMockEngine) startWithOptions(wg *sync.WaitGroup, options engine.S
 	return up
 }
 
-func buildPorts(options engine.StartOptions) (nat.PortSet, nat.PortMap) {
+func buildPorts(options engine.StartOptions, hostIP string) (nat.PortSet, nat.PortMap) {
 	ports := map[int]int{
 		options.Port: options.Port,
 	}
@@ -129,7 +136,7 @@ func buildPorts(options engine.StartOptions) (nat.PortSet, nat.PortMap) {
 		exposedPorts[containerPort] = struct{}{}
 		portBindings[containerPort] = []nat.PortBinding{
 			{
-				HostIP:   "0.0.0.0",
+				HostIP:   hostIP,
 				HostPort: hostPort,
 			},
 		}
@@ -152,11 +159,20 @@ func buildBinds(d *DockerMockEngine, options engine.StartOptions) []string {
 	}
 	if options.EnablePlugins {
 		logger.Tracef("plugins are enabled")
-		pluginDir, err := plugin.EnsurePluginDir(options.Version)
+		pluginDir, err := plugin.EnsureMockPluginDir(d.configDir, options.Version)
 		if err != nil {
 			logger.Fatal(err)
 		}
 		binds = append(binds, pluginDir+":"+containerPluginDir)
+
+		// linked plugins point outside the plugin dir, so are mounted individually
+		linked, err := plugin.ListLinkedPlugins(d.configDir, options.Version)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		for fileName, target := range linked {
+			binds = append(binds, target+":"+containerPluginDir+"/"+fileName)
+		}
 	} else {
 		logger.Tracef("plugins are disabled")
 	}
@@ -208,19 +224,26 @@ func parseDirMounts(dirMounts []string) []string {
 func generateMetadata(d *DockerMockEngine, options engine.StartOptions) (string, map[string]string) {
 	absoluteConfigDir, _ := filepath.Abs(d.configDir)
 
+	// the engine listens on a different port each time it is started
+	// behind a front proxy, so use the port clients connect to
+	_, port := engine.ResolveMockUrl(options.Port, options.PublicUrl)
+
 	var mockHash string
 	if options.Deduplicate != "" {
 		mockHash = stringutil.Sha1hashString(options.Deduplicate)
 	} else {
-		mockHash = genDefaultHash(absoluteConfigDir, options.Port)
+		mockHash = genDefaultHash(absoluteConfigDir, port)
 	}
 
 	containerLabels := map[string]string{
 		labelKeyManaged: "true",
 		labelKeyDir:     absoluteConfigDir,
-		labelKeyPort:    strconv.Itoa(options.Port),
+		labelKeyPort:    strconv.Itoa(port),
 		labelKeyHash:    mockHash,
 	}
+	if options.PublicUrl != "" {
+		containerLabels[labelKeyUrl] = options.PublicUrl
+	}
 	return mockHash, containerLabels
 }
 
@@ -69,7 +69,7 @@ func ensureContainerImage(
 	imageTag string,
 	imagePullPolicy engine.PullPolicy,
 ) (imageAndTag string, e error) {
-	imageAndTag = getImageRepo(engineType) + ":" + imageTag
+	imageAndTag = GetImage(engineType, imageTag)
 
 	if imagePullPolicy == engine.PullSkip {
 		return imageAndTag, nil
@@ -118,6 +118,11 @@ func pullImage(cli *client.Client, ctx context.Context, imageTag string, imageAn
 	return nil
 }
 
+// GetImage returns the engine image and tag for the engine type and version.
+func GetImage(engineType engine.EngineType, version string) string {
+	return getImageRepo(engineType) + ":" + version
+}
+
 func getImageRepo(engineType engine.EngineType) string {
 	var imageRepo string
 	switch engineType {
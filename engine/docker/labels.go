@@ -30,6 +30,7 @@ const labelKeyManaged = "io.gatehill.imposter.managed"
 const labelKeyPort = "io.gatehill.imposter.port"
 const labelKeyDir = "io.gatehill.imposter.dir"
 const labelKeyHash = "io.gatehill.imposter.hash"
+const labelKeyUrl = "io.gatehill.imposter.url"
 
 func genDefaultHash(absPath string, port int) string {
 	return stringutil.Sha1hashString(fmt.Sprintf("%v:%d", absPath, port))
@@ -48,10 +49,12 @@ func findContainersWithLabels(cli *client.Client, ctx context.Context, labels ma
 
 	var mocks []engine.ManagedMock
 	for _, container := range containers {
+		mockUrl, port := engine.ResolveMockUrl(findPublicPort(container), container.Labels[labelKeyUrl])
 		mock := engine.ManagedMock{
 			ID:   container.ID[0:12],
 			Name: container.Names[0],
-			Port: findPublicPort(container),
+			Port: port,
+			URL:  mockUrl,
 		}
 		mocks = append(mocks, mock)
 	}
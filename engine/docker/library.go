@@ -7,6 +7,7 @@ import (
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/client"
 	"strings"
+	"time"
 )
 
 type DockerEngineLibrary struct {
@@ -64,10 +65,69 @@ func (l DockerEngineLibrary) List() ([]engine.EngineMetadata, error) {
 	return available, nil
 }
 
+func (l DockerEngineLibrary) ListUsage() ([]engine.CachedEngine, error) {
+	ctx, cli, err := buildCliClient()
+	if err != nil {
+		return nil, fmt.Errorf("error building CLI client: %s", err)
+	}
+	imageRepo := getImageRepo(l.engineType)
+	imageSummaries, err := cli.ImageList(ctx, types.ImageListOptions{
+		Filters: filters.NewArgs(filters.Arg("reference", imageRepo+":*")),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing images: %s", err)
+	}
+	var cached []engine.CachedEngine
+	for _, imageSummary := range imageSummaries {
+		for _, tag := range imageSummary.RepoTags {
+			cached = append(cached, engine.CachedEngine{
+				EngineMetadata: engine.EngineMetadata{
+					EngineType: l.engineType,
+					Version:    strings.Split(tag, ":")[1],
+				},
+				Size:     imageSummary.Size,
+				Modified: time.Unix(imageSummary.Created, 0),
+			})
+		}
+	}
+	return cached, nil
+}
+
+func (l DockerEngineLibrary) Remove(version string) error {
+	ctx, cli, err := buildCliClient()
+	if err != nil {
+		return fmt.Errorf("error building CLI client: %s", err)
+	}
+	imageAndTag := getImageRepo(l.engineType) + ":" + version
+	if _, err = cli.ImageRemove(ctx, imageAndTag, types.ImageRemoveOptions{}); err != nil {
+		return fmt.Errorf("error removing image: %s: %s", imageAndTag, err)
+	}
+	return nil
+}
+
 func (l DockerEngineLibrary) GetProvider(version string) engine.Provider {
 	return getProvider(l.engineType, version)
 }
 
+func (l DockerEngineLibrary) GetImageDigest(version string) (string, error) {
+	ctx, cli, err := buildCliClient()
+	if err != nil {
+		return "", fmt.Errorf("error building CLI client: %s", err)
+	}
+	imageAndTag := GetImage(l.engineType, version)
+	image, _, err := cli.ImageInspectWithRaw(ctx, imageAndTag)
+	if err != nil {
+		return "", fmt.Errorf("error inspecting image: %s: %s", imageAndTag, err)
+	}
+	for _, repoDigest := range image.RepoDigests {
+		if _, digest, found := strings.Cut(repoDigest, "@"); found {
+			return digest, nil
+		}
+	}
+	logger.Debugf("image %s has no repository digest", imageAndTag)
+	return "", nil
+}
+
 func (DockerEngineLibrary) IsSealedDistro() bool {
 	return false
 }
@@ -0,0 +1,223 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"gatehill.io/imposter/engine"
+	"gatehill.io/imposter/fileutil"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// buildOciBundle builds the bundle image for each platform on top of the
+// parent image, which is fetched from its registry, so the Docker daemon
+// is not needed. The images are written to dest as an OCI image layout
+// tarball. If there is more than one platform, the tarball holds an index
+// of the images for each platform.
+func buildOciBundle(parentImage string, configDir string, pluginFiles []string, options engine.BundleOptions, dest string) error {
+	if _, err := os.Stat(dest); err == nil {
+		return fmt.Errorf("destination bundle file already exists: %s", dest)
+	}
+	parentRef, err := name.ParseReference(parentImage)
+	if err != nil {
+		return fmt.Errorf("invalid parent image: %s: %v", parentImage, err)
+	}
+	layer, err := buildBundleLayer(configDir, pluginFiles)
+	if err != nil {
+		return err
+	}
+
+	platforms := options.Platforms
+	if len(platforms) == 0 {
+		// engine images are Linux images
+		platforms = []string{"linux/" + runtime.GOARCH}
+	}
+	var addenda []mutate.IndexAddendum
+	for _, p := range platforms {
+		platform, err := v1.ParsePlatform(p)
+		if err != nil {
+			return fmt.Errorf("invalid platform: %s: %v", p, err)
+		}
+		logger.Debugf("fetching parent image %s for platform %s", parentImage, platform)
+		parent, err := remote.Image(parentRef, remote.WithPlatform(*platform), remote.WithAuthFromKeychain(authn.DefaultKeychain))
+		if err != nil {
+			return fmt.Errorf("failed to fetch parent image: %s for platform %s: %v", parentImage, platform, err)
+		}
+		img, err := buildBundleImage(parent, layer, options)
+		if err != nil {
+			return err
+		}
+		addenda = append(addenda, mutate.IndexAddendum{
+			Add:        img,
+			Descriptor: v1.Descriptor{Platform: platform},
+		})
+	}
+
+	return writeOciArchive(dest, func(p layout.Path) error {
+		if len(addenda) == 1 {
+			return p.AppendImage(addenda[0].Add.(v1.Image))
+		}
+		index := mutate.AppendManifests(mutate.IndexMediaType(empty.Index, types.OCIImageIndex), addenda...)
+		return p.AppendIndex(index)
+	})
+}
+
+// buildBundleImage adds the layer holding the configuration and plugin files
+// to the parent image, with the environment variables and labels.
+func buildBundleImage(parent v1.Image, layer v1.Layer, options engine.BundleOptions) (v1.Image, error) {
+	img, err := mutate.AppendLayers(parent, layer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add bundle layer: %v", err)
+	}
+	configFile, err := img.ConfigFile()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image configuration: %v", err)
+	}
+	config := *configFile.Config.DeepCopy()
+	config.Env = mergeEnv(config.Env, options.Environment)
+	if config.Labels == nil {
+		config.Labels = make(map[string]string)
+	}
+	for k, v := range buildLabels(options.Labels) {
+		config.Labels[k] = v
+	}
+	img, err = mutate.Config(img, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set image configuration: %v", err)
+	}
+	return img, nil
+}
+
+// mergeEnv returns the environment variables, in which those in overrides
+// replace those in env with the same name.
+func mergeEnv(env []string, overrides []string) []string {
+	var merged []string
+	for _, e := range env {
+		key, _, _ := strings.Cut(e, "=")
+		overridden := false
+		for _, o := range overrides {
+			if strings.HasPrefix(o, key+"=") {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged = append(merged, e)
+		}
+	}
+	return append(merged, overrides...)
+}
+
+// buildBundleLayer returns an image layer holding the configuration files
+// in the config dir and the plugin files.
+func buildBundleLayer(configDir string, pluginFiles []string) (v1.Layer, error) {
+	buf := new(bytes.Buffer)
+	tarWriter := tar.NewWriter(buf)
+
+	local, err := fileutil.ListProjectFiles(configDir)
+	if err != nil {
+		return nil, err
+	}
+	dirs := []string{bundleConfigDestDir}
+	if len(pluginFiles) > 0 {
+		dirs = append(dirs, containerPluginDir)
+	}
+	for _, dir := range dirs {
+		header := &tar.Header{Typeflag: tar.TypeDir, Name: strings.TrimPrefix(dir, "/") + "/", Mode: 0755}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return nil, err
+		}
+	}
+	for _, localFile := range local {
+		relPath, _ := filepath.Rel(configDir, localFile)
+		if err := addLayerFile(tarWriter, localFile, path.Join(bundleConfigDestDir, filepath.ToSlash(relPath))); err != nil {
+			return nil, err
+		}
+	}
+	for _, pluginFile := range pluginFiles {
+		if err := addLayerFile(tarWriter, pluginFile, path.Join(containerPluginDir, filepath.Base(pluginFile))); err != nil {
+			return nil, err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	content := buf.Bytes()
+	return tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(content)), nil
+	})
+}
+
+// addLayerFile adds the file to the layer at the given path, owned by root
+// and readable by the user the engine runs as.
+func addLayerFile(writer *tar.Writer, file string, dest string) error {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("error reading file: %v", err)
+	}
+	header := &tar.Header{
+		Name: strings.TrimPrefix(dest, "/"),
+		Mode: 0644,
+		Size: int64(len(content)),
+	}
+	if err = writer.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = writer.Write(content)
+	return err
+}
+
+// writeOciArchive writes an OCI image layout as a tarball at dest, calling
+// populate to add the images to the layout.
+func writeOciArchive(dest string, populate func(p layout.Path) error) error {
+	layoutDir, err := os.MkdirTemp(os.TempDir(), "imposter-oci")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(layoutDir)
+
+	p, err := layout.Write(layoutDir, empty.Index)
+	if err != nil {
+		return fmt.Errorf("failed to write OCI image layout: %v", err)
+	}
+	if err = populate(p); err != nil {
+		return fmt.Errorf("failed to write OCI image layout: %v", err)
+	}
+
+	f, err := os.Create(dest)
+	if err != nil {
+		return fmt.Errorf("error creating bundle file: %s: %v", dest, err)
+	}
+	defer f.Close()
+	tarWriter := tar.NewWriter(f)
+	err = filepath.WalkDir(layoutDir, func(file string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		relPath, err := filepath.Rel(layoutDir, file)
+		if err != nil {
+			return err
+		}
+		return addFileToTar(tarWriter, file, filepath.ToSlash(relPath))
+	})
+	if err != nil {
+		return fmt.Errorf("error writing bundle file: %s: %v", dest, err)
+	}
+	return tarWriter.Close()
+}
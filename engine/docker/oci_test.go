@@ -0,0 +1,158 @@
+package docker
+
+import (
+	"archive/tar"
+	"gatehill.io/imposter/engine"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_buildOciBundle(t *testing.T) {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+	parentImage := strings.TrimPrefix(server.URL, "http://") + "/imposter:test"
+	pushParentIndex(t, parentImage, "linux/amd64", "linux/arm64")
+
+	configDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "test-config.yaml"), []byte("plugin: rest\n"), 0600))
+	pluginFile := filepath.Join(t.TempDir(), "imposter-plugin-store-redis.jar")
+	require.NoError(t, os.WriteFile(pluginFile, []byte("plugin jar"), 0644))
+
+	options := engine.BundleOptions{
+		Platforms:   []string{"linux/amd64", "linux/arm64"},
+		Environment: []string{"IMPOSTER_FOO=bar"},
+		Labels:      map[string]string{"team": "payments"},
+		OCI:         true,
+	}
+
+	t.Run("multiple platforms", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "bundle.tar")
+		require.NoError(t, buildOciBundle(parentImage, configDir, []string{pluginFile}, options, dest))
+
+		index := readOciArchive(t, dest)
+		indexManifest, err := index.IndexManifest()
+		require.NoError(t, err)
+		require.Len(t, indexManifest.Manifests, 1, "layout should hold a single multi-platform index")
+
+		platformIndex, err := index.ImageIndex(indexManifest.Manifests[0].Digest)
+		require.NoError(t, err)
+		platformManifest, err := platformIndex.IndexManifest()
+		require.NoError(t, err)
+		require.Len(t, platformManifest.Manifests, 2)
+
+		for i, platform := range options.Platforms {
+			require.Equal(t, platform, platformManifest.Manifests[i].Platform.String())
+			img, err := platformIndex.Image(platformManifest.Manifests[i].Digest)
+			require.NoError(t, err)
+			requireBundleImage(t, img)
+		}
+	})
+
+	t.Run("single platform", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "bundle.tar")
+		singleOptions := options
+		singleOptions.Platforms = []string{"linux/arm64"}
+		require.NoError(t, buildOciBundle(parentImage, configDir, []string{pluginFile}, singleOptions, dest))
+
+		index := readOciArchive(t, dest)
+		indexManifest, err := index.IndexManifest()
+		require.NoError(t, err)
+		require.Len(t, indexManifest.Manifests, 1)
+		img, err := index.Image(indexManifest.Manifests[0].Digest)
+		require.NoError(t, err)
+		requireBundleImage(t, img)
+	})
+
+	t.Run("existing destination", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "bundle.tar")
+		require.NoError(t, os.WriteFile(dest, nil, 0644))
+		require.Error(t, buildOciBundle(parentImage, configDir, nil, options, dest))
+	})
+}
+
+func Test_mergeEnv(t *testing.T) {
+	merged := mergeEnv([]string{"PATH=/usr/bin", "IMPOSTER_FOO=old"}, []string{"IMPOSTER_FOO=new"})
+	require.Equal(t, []string{"PATH=/usr/bin", "IMPOSTER_FOO=new"}, merged)
+}
+
+// pushParentIndex pushes an index holding a random image for each platform.
+func pushParentIndex(t *testing.T, image string, platforms ...string) {
+	ref, err := name.ParseReference(image)
+	require.NoError(t, err)
+	var addenda []mutate.IndexAddendum
+	for _, p := range platforms {
+		img, err := random.Image(64, 1)
+		require.NoError(t, err)
+		platform, err := v1.ParsePlatform(p)
+		require.NoError(t, err)
+		addenda = append(addenda, mutate.IndexAddendum{Add: img, Descriptor: v1.Descriptor{Platform: platform}})
+	}
+	require.NoError(t, remote.WriteIndex(ref, mutate.AppendManifests(empty.Index, addenda...)))
+}
+
+// readOciArchive extracts the OCI image layout tarball and returns its index.
+func readOciArchive(t *testing.T, archive string) v1.ImageIndex {
+	f, err := os.Open(archive)
+	require.NoError(t, err)
+	defer f.Close()
+
+	layoutDir := t.TempDir()
+	tarReader := tar.NewReader(f)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		dest := filepath.Join(layoutDir, header.Name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(dest), 0755))
+		content, err := io.ReadAll(tarReader)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(dest, content, 0644))
+	}
+	index, err := layout.ImageIndexFromPath(layoutDir)
+	require.NoError(t, err)
+	return index
+}
+
+func requireBundleImage(t *testing.T, img v1.Image) {
+	configFile, err := img.ConfigFile()
+	require.NoError(t, err)
+	require.Contains(t, configFile.Config.Env, "IMPOSTER_FOO=bar")
+	require.Equal(t, "payments", configFile.Config.Labels["team"])
+	require.Equal(t, "imposter-cli", configFile.Config.Labels["builtwith"])
+
+	layers, err := img.Layers()
+	require.NoError(t, err)
+	require.Len(t, layers, 2, "bundle layer should be added to parent layer")
+	rc, err := layers[1].Uncompressed()
+	require.NoError(t, err)
+	defer rc.Close()
+
+	files := make(map[string]int64)
+	tarReader := tar.NewReader(rc)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		files[header.Name] = header.Mode
+	}
+	require.Equal(t, int64(0644), files["opt/imposter/config/test-config.yaml"], "config file should be readable by engine user")
+	require.Contains(t, files, "opt/imposter/plugins/imposter-plugin-store-redis.jar")
+}
@@ -0,0 +1,123 @@
+package docker
+
+import (
+	"fmt"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-units"
+	"github.com/spf13/viper"
+	"strconv"
+	"strings"
+)
+
+const defaultHostIP = "0.0.0.0"
+
+// containerOptions holds the optional settings for the mock container,
+// configured under the `docker` key, such as in the `.imposter.yaml`
+// file in a project.
+type containerOptions struct {
+	name           string
+	network        string
+	networkAliases []string
+	hostIP         string
+	cpus           float64
+	memory         int64
+	extraHosts     []string
+	restartPolicy  container.RestartPolicy
+	dns            []string
+	dnsSearch      []string
+}
+
+func loadContainerOptions() (containerOptions, error) {
+	options := containerOptions{
+		name:           viper.GetString("docker.containerName"),
+		network:        viper.GetString("docker.network"),
+		networkAliases: viper.GetStringSlice("docker.networkAliases"),
+		hostIP:         viper.GetString("docker.hostIp"),
+		cpus:           viper.GetFloat64("docker.cpus"),
+		extraHosts:     viper.GetStringSlice("docker.extraHosts"),
+		dns:            viper.GetStringSlice("docker.dns"),
+		dnsSearch:      viper.GetStringSlice("docker.dnsSearch"),
+	}
+	if options.hostIP == "" {
+		options.hostIP = defaultHostIP
+	}
+	if len(options.networkAliases) > 0 && options.network == "" {
+		return containerOptions{}, fmt.Errorf("docker network aliases require a network to be set")
+	}
+	if options.cpus < 0 {
+		return containerOptions{}, fmt.Errorf("invalid docker CPU limit: %v", options.cpus)
+	}
+	if memory := viper.GetString("docker.memory"); memory != "" {
+		bytes, err := units.RAMInBytes(memory)
+		if err != nil || bytes <= 0 {
+			return containerOptions{}, fmt.Errorf("invalid docker memory limit: %s - must be a size, such as 512m or 2g", memory)
+		}
+		options.memory = bytes
+	}
+	if policy := viper.GetString("docker.restartPolicy"); policy != "" {
+		restartPolicy, err := parseRestartPolicy(policy)
+		if err != nil {
+			return containerOptions{}, err
+		}
+		options.restartPolicy = restartPolicy
+	}
+	for _, host := range options.extraHosts {
+		if !strings.Contains(host, ":") {
+			return containerOptions{}, fmt.Errorf("invalid docker extra host: %s - must be in the form HOST:IP", host)
+		}
+	}
+	return options, nil
+}
+
+// parseRestartPolicy parses a policy in the form used by `docker run --restart`,
+// such as `unless-stopped` or `on-failure:3`.
+func parseRestartPolicy(policy string) (container.RestartPolicy, error) {
+	name, retries, hasRetries := strings.Cut(policy, ":")
+	restartPolicy := container.RestartPolicy{Name: name}
+	switch name {
+	case "no", "always", "unless-stopped":
+		if hasRetries {
+			return container.RestartPolicy{}, fmt.Errorf("invalid docker restart policy: %s - only on-failure accepts a maximum retry count", policy)
+		}
+	case "on-failure":
+		if hasRetries {
+			count, err := strconv.Atoi(retries)
+			if err != nil || count < 0 {
+				return container.RestartPolicy{}, fmt.Errorf("invalid docker restart policy: %s - retry count must be a number", policy)
+			}
+			restartPolicy.MaximumRetryCount = count
+		}
+	default:
+		return container.RestartPolicy{}, fmt.Errorf("invalid docker restart policy: %s - valid values are no, always, unless-stopped or on-failure[:max-retries]", policy)
+	}
+	return restartPolicy, nil
+}
+
+// applyTo sets the options on the host configuration of the container.
+func (o containerOptions) applyTo(hostConfig *container.HostConfig) {
+	if o.network != "" {
+		hostConfig.NetworkMode = container.NetworkMode(o.network)
+	}
+	if o.cpus > 0 {
+		hostConfig.NanoCPUs = int64(o.cpus * 1e9)
+	}
+	hostConfig.Memory = o.memory
+	hostConfig.ExtraHosts = o.extraHosts
+	hostConfig.RestartPolicy = o.restartPolicy
+	hostConfig.DNS = o.dns
+	hostConfig.DNSSearch = o.dnsSearch
+}
+
+// networkingConfig returns the configuration for joining the network, so
+// the mock can be reached by its aliases, or nil if no network is set.
+func (o containerOptions) networkingConfig() *network.NetworkingConfig {
+	if o.network == "" {
+		return nil
+	}
+	return &network.NetworkingConfig{
+		EndpointsConfig: map[string]*network.EndpointSettings{
+			o.network: {Aliases: o.networkAliases},
+		},
+	}
+}
@@ -0,0 +1,108 @@
+package docker
+
+import (
+	"github.com/docker/docker/api/types/container"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func setDockerConfig(t *testing.T, values map[string]interface{}) {
+	for key, value := range values {
+		viper.Set(key, value)
+	}
+	t.Cleanup(func() {
+		for key := range values {
+			viper.Set(key, nil)
+		}
+	})
+}
+
+func Test_loadContainerOptions(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		options, err := loadContainerOptions()
+		require.NoError(t, err)
+		require.Equal(t, defaultHostIP, options.hostIP)
+		require.Nil(t, options.networkingConfig())
+
+		hostConfig := &container.HostConfig{}
+		options.applyTo(hostConfig)
+		require.Equal(t, container.HostConfig{}, *hostConfig)
+	})
+
+	t.Run("all options", func(t *testing.T) {
+		setDockerConfig(t, map[string]interface{}{
+			"docker.containerName":  "orders-mock",
+			"docker.network":        "shop",
+			"docker.networkAliases": []string{"orders", "orders-api"},
+			"docker.hostIp":         "127.0.0.1",
+			"docker.cpus":           1.5,
+			"docker.memory":         "512m",
+			"docker.extraHosts":     []string{"db.local:10.0.0.5"},
+			"docker.restartPolicy":  "on-failure:3",
+			"docker.dns":            []string{"8.8.8.8"},
+			"docker.dnsSearch":      []string{"example.com"},
+		})
+		options, err := loadContainerOptions()
+		require.NoError(t, err)
+		require.Equal(t, "orders-mock", options.name)
+		require.Equal(t, "127.0.0.1", options.hostIP)
+
+		hostConfig := &container.HostConfig{}
+		options.applyTo(hostConfig)
+		require.Equal(t, container.NetworkMode("shop"), hostConfig.NetworkMode)
+		require.Equal(t, int64(1_500_000_000), hostConfig.NanoCPUs)
+		require.Equal(t, int64(512*1024*1024), hostConfig.Memory)
+		require.Equal(t, []string{"db.local:10.0.0.5"}, hostConfig.ExtraHosts)
+		require.Equal(t, container.RestartPolicy{Name: "on-failure", MaximumRetryCount: 3}, hostConfig.RestartPolicy)
+		require.Equal(t, []string{"8.8.8.8"}, hostConfig.DNS)
+		require.Equal(t, []string{"example.com"}, hostConfig.DNSSearch)
+
+		networking := options.networkingConfig()
+		require.NotNil(t, networking)
+		require.Equal(t, []string{"orders", "orders-api"}, networking.EndpointsConfig["shop"].Aliases)
+	})
+
+	invalid := map[string]map[string]interface{}{
+		"aliases without network": {"docker.networkAliases": []string{"orders"}},
+		"negative cpus":           {"docker.cpus": -1},
+		"invalid memory":          {"docker.memory": "lots"},
+		"invalid extra host":      {"docker.extraHosts": []string{"db.local"}},
+		"invalid restart policy":  {"docker.restartPolicy": "sometimes"},
+	}
+	for name, values := range invalid {
+		t.Run(name, func(t *testing.T) {
+			setDockerConfig(t, values)
+			_, err := loadContainerOptions()
+			require.Error(t, err)
+		})
+	}
+}
+
+func Test_parseRestartPolicy(t *testing.T) {
+	tests := []struct {
+		policy  string
+		want    container.RestartPolicy
+		wantErr bool
+	}{
+		{policy: "no", want: container.RestartPolicy{Name: "no"}},
+		{policy: "always", want: container.RestartPolicy{Name: "always"}},
+		{policy: "unless-stopped", want: container.RestartPolicy{Name: "unless-stopped"}},
+		{policy: "on-failure", want: container.RestartPolicy{Name: "on-failure"}},
+		{policy: "on-failure:5", want: container.RestartPolicy{Name: "on-failure", MaximumRetryCount: 5}},
+		{policy: "on-failure:x", wantErr: true},
+		{policy: "always:3", wantErr: true},
+		{policy: "never", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			got, err := parseRestartPolicy(tt.policy)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"gatehill.io/imposter/debounce"
 	"gatehill.io/imposter/engine"
+	"gatehill.io/imposter/plugin"
 )
 
 type DockerMockEngine struct {
@@ -62,14 +63,26 @@ func buildEngine(engineType engine.EngineType, configDir string, options engine.
 }
 
 // Bundle implements the Docker engine steps to create a mock bundle.
-// destFile is interpreted as the image tag.
-func (d *EngineImageProvider) Bundle(configDir string, dest string) error {
-	buf, err := addFilesToTar(configDir, d.imageAndTag)
+// destFile is interpreted as the image tag, unless the options specify an
+// OCI image layout tarball, in which case it is the path of the tarball.
+func (d *EngineImageProvider) Bundle(configDir string, dest string, options engine.BundleOptions) error {
+	pluginFiles, err := plugin.EnsureMockPluginFiles(configDir, d.Version)
+	if err != nil {
+		return err
+	}
+	if options.OCI {
+		return buildOciBundle(GetImage(d.EngineType, d.Version), configDir, pluginFiles, options, dest)
+	}
+	if len(options.Platforms) > 1 {
+		return fmt.Errorf("the Docker daemon can only build for one platform - write an OCI image layout tarball to build for multiple platforms")
+	}
+
+	buf, err := addFilesToTar(configDir, pluginFiles, d.imageAndTag, options.Environment)
 	if err != nil {
 		return fmt.Errorf("error adding files to build context: %v", err)
 	}
 
-	err = buildImage(buf, dest)
+	err = buildImage(buf, dest, options)
 	if err != nil {
 		return fmt.Errorf("error building image: %v", err)
 	}
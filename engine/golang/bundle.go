@@ -0,0 +1,222 @@
+package golang
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"gatehill.io/imposter/engine"
+)
+
+const bundleConfigDir = "config"
+
+// Bundle writes an archive holding the engine binary for the target
+// platform, the configuration files and a start script, so the mock can
+// be run without Docker or a JVM. The archive is a ZIP file for Windows,
+// otherwise a gzipped tarball.
+func (p *Provider) Bundle(configDir string, dest string, options engine.BundleOptions) error {
+	if _, err := os.Stat(dest); err == nil {
+		return fmt.Errorf("destination bundle file already exists: %s", dest)
+	}
+	goos, goarch, err := getBundlePlatform(options.Platforms)
+	if err != nil {
+		return err
+	}
+	binaryPath, err := p.ensureBinaryForPlatform(goos, goarch)
+	if err != nil {
+		return err
+	}
+	files, err := buildBundleFiles(configDir, binaryPath, goos, options.Environment)
+	if err != nil {
+		return err
+	}
+
+	rootDir := engine.GetBundleRootDir(dest)
+	if goos == "windows" {
+		err = engine.WriteZipBundle(dest, rootDir, files)
+	} else {
+		err = writeTarBundle(dest, rootDir, files)
+	}
+	if err != nil {
+		_ = os.Remove(dest)
+		return fmt.Errorf("error writing bundle file: %s: %v", dest, err)
+	}
+	return nil
+}
+
+// getBundlePlatform returns the OS and architecture of the bundle, which
+// defaults to those of the host.
+func getBundlePlatform(platforms []string) (goos string, goarch string, err error) {
+	if len(platforms) == 0 {
+		return runtime.GOOS, runtime.GOARCH, nil
+	} else if len(platforms) > 1 {
+		return "", "", fmt.Errorf("golang bundles support a single platform, but %d were specified", len(platforms))
+	}
+	goos, goarch, found := strings.Cut(platforms[0], "/")
+	if !found || goos == "" || goarch == "" || strings.Contains(goarch, "/") {
+		return "", "", fmt.Errorf("invalid platform: %s - must be in the form OS/ARCH, such as linux/amd64", platforms[0])
+	}
+	switch goos {
+	case "darwin", "linux", "windows":
+		return goos, goarch, nil
+	default:
+		return "", "", fmt.Errorf("unsupported platform OS: %s", goos)
+	}
+}
+
+// ensureBinaryForPlatform returns the path to the engine binary for the
+// given OS and architecture, downloading it if it is not present. Binaries
+// for platforms other than the host are held in a subdirectory of the
+// version directory.
+func (p *Provider) ensureBinaryForPlatform(goos string, goarch string) (string, error) {
+	if goos == runtime.GOOS && goarch == runtime.GOARCH {
+		if !p.Satisfied() {
+			if err := p.Provide(engine.PullIfNotPresent); err != nil {
+				return "", err
+			}
+		}
+		return p.binaryPath, nil
+	}
+
+	platformDir := filepath.Join(p.binDir, goos+"_"+goarch)
+	if binaryPath := findBinary(platformDir, goos); binaryPath != "" {
+		providerLogger.Debugf("engine binary '%v' for %s/%s already present", p.version, goos, goarch)
+		return binaryPath, nil
+	}
+	if err := downloadAndExtractBinary(p.version, platformDir, goos, goarch); err != nil {
+		return "", fmt.Errorf("failed to fetch binary for %s/%s: %v", goos, goarch, err)
+	}
+	binaryPath := findBinary(platformDir, goos)
+	if binaryPath == "" {
+		return "", fmt.Errorf("engine binary not found in release archive for %s/%s", goos, goarch)
+	}
+	return binaryPath, nil
+}
+
+// findBinary returns the path to the engine binary in the directory, or an
+// empty string if it is not present.
+func findBinary(dir string, goos string) string {
+	names := []string{binaryName}
+	if goos == "windows" {
+		names = []string{binaryName + ".exe", binaryName}
+	}
+	for _, name := range names {
+		if binaryPath := filepath.Join(dir, name); fileExists(binaryPath) {
+			return binaryPath
+		}
+	}
+	return ""
+}
+
+// buildBundleFiles returns the engine binary, the configuration files,
+// keeping their paths relative to the config dir, and the start script.
+func buildBundleFiles(configDir string, binaryPath string, goos string, env []string) ([]engine.BundleFile, error) {
+	binaryFile := binaryName
+	if goos == "windows" {
+		binaryFile += ".exe"
+	}
+	files := []engine.BundleFile{{Name: binaryFile, Path: binaryPath, Mode: 0755}}
+
+	configFiles, err := engine.ListConfigBundleFiles(configDir, bundleConfigDir)
+	if err != nil {
+		return nil, err
+	}
+	providerLogger.Infof("bundling %d files from workspace", len(configFiles))
+	files = append(files, configFiles...)
+
+	if goos == "windows" {
+		files = append(files, engine.BundleFile{Name: "start.bat", Content: []byte(buildBatchStartScript(binaryFile, env)), Mode: 0644})
+	} else {
+		files = append(files, engine.BundleFile{Name: "start.sh", Content: []byte(buildShellStartScript(binaryFile, env)), Mode: 0755})
+	}
+	return files, nil
+}
+
+// buildShellStartScript returns a script that starts the engine with the
+// bundled configuration. Environment variables set by the caller take
+// precedence over the defaults in the script.
+func buildShellStartScript(binaryFile string, env []string) string {
+	var script strings.Builder
+	script.WriteString("#!/bin/sh\nset -e\n")
+	script.WriteString("BUNDLE_DIR=\"$(cd \"$(dirname \"$0\")\" && pwd)\"\n")
+	script.WriteString(": \"${IMPOSTER_CONFIG_DIR:=$BUNDLE_DIR/" + bundleConfigDir + "}\"\n")
+	script.WriteString(": \"${IMPOSTER_PORT:=8080}\"\n")
+	script.WriteString("export IMPOSTER_CONFIG_DIR IMPOSTER_PORT\n")
+	script.WriteString(engine.ShellEnvDefaults(env))
+	script.WriteString("exec \"$BUNDLE_DIR/" + binaryFile + "\" \"$@\"\n")
+	return script.String()
+}
+
+// buildBatchStartScript returns the Windows equivalent of the script
+// returned by buildShellStartScript.
+func buildBatchStartScript(binaryFile string, env []string) string {
+	var script strings.Builder
+	script.WriteString("@echo off\r\nsetlocal\r\n")
+	script.WriteString("if not defined IMPOSTER_CONFIG_DIR set \"IMPOSTER_CONFIG_DIR=%~dp0" + bundleConfigDir + "\"\r\n")
+	script.WriteString("if not defined IMPOSTER_PORT set \"IMPOSTER_PORT=8080\"\r\n")
+	script.WriteString(engine.BatchEnvDefaults(env))
+	script.WriteString("\"%~dp0" + binaryFile + "\" %*\r\n")
+	return script.String()
+}
+
+func writeTarBundle(dest string, rootDir string, files []engine.BundleFile) error {
+	f, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gzipWriter := gzip.NewWriter(f)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	if err = tarWriter.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: rootDir + "/", Mode: 0755}); err != nil {
+		return err
+	}
+	dirs := make(map[string]bool)
+	for _, file := range files {
+		// parent directories are added before their files
+		var parents []string
+		for dir := path.Dir(file.Name); dir != "." && !dirs[dir]; dir = path.Dir(dir) {
+			dirs[dir] = true
+			parents = append([]string{dir}, parents...)
+		}
+		for _, dir := range parents {
+			if err = tarWriter.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: path.Join(rootDir, dir) + "/", Mode: 0755}); err != nil {
+				return err
+			}
+		}
+		if err = addTarFile(tarWriter, path.Join(rootDir, file.Name), file); err != nil {
+			return err
+		}
+	}
+	if err = tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
+
+func addTarFile(tarWriter *tar.Writer, name string, file engine.BundleFile) error {
+	size := int64(len(file.Content))
+	if file.Content == nil {
+		info, err := os.Stat(file.Path)
+		if err != nil {
+			return fmt.Errorf("error reading file: %v", err)
+		}
+		size = info.Size()
+	}
+	r, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	if err = tarWriter.WriteHeader(&tar.Header{Name: name, Mode: file.Mode, Size: size}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tarWriter, r)
+	return err
+}
@@ -0,0 +1,135 @@
+package golang
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"gatehill.io/imposter/engine"
+	"github.com/stretchr/testify/require"
+)
+
+func writeBundleTestConfig(t *testing.T) string {
+	configDir := t.TempDir()
+	for name, content := range map[string]string{
+		"imposter-config.yaml": "plugin: rest\n",
+		"responses/data.json":  "{}",
+		".imposter.yaml":       "engine: golang\n",
+	} {
+		path := filepath.Join(configDir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return configDir
+}
+
+func TestProvider_Bundle_Tarball(t *testing.T) {
+	configDir := writeBundleTestConfig(t)
+	binDir := t.TempDir()
+	platformDir := filepath.Join(binDir, "linux_arm64")
+	require.NoError(t, os.MkdirAll(platformDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(platformDir, binaryName), []byte("binary"), 0755))
+
+	dest := filepath.Join(t.TempDir(), "mock.tar.gz")
+	p := NewProvider("1.0.0", binDir)
+	err := p.Bundle(configDir, dest, engine.BundleOptions{
+		Platforms:   []string{"linux/arm64"},
+		Environment: []string{"IMPOSTER_LOG_LEVEL=DEBUG"},
+	})
+	require.NoError(t, err)
+
+	f, err := os.Open(dest)
+	require.NoError(t, err)
+	defer f.Close()
+	gzipReader, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	tarReader := tar.NewReader(gzipReader)
+
+	files := make(map[string]string)
+	modes := make(map[string]int64)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		if header.Typeflag == tar.TypeDir {
+			continue
+		}
+		content, err := io.ReadAll(tarReader)
+		require.NoError(t, err)
+		files[header.Name] = string(content)
+		modes[header.Name] = header.Mode
+	}
+
+	require.Len(t, files, 4)
+	require.Equal(t, "binary", files["mock/imposter-go"])
+	require.Equal(t, int64(0755), modes["mock/imposter-go"])
+	require.Equal(t, "plugin: rest\n", files["mock/config/imposter-config.yaml"])
+	require.Equal(t, "{}", files["mock/config/responses/data.json"])
+	require.Equal(t, int64(0755), modes["mock/start.sh"])
+	require.Contains(t, files["mock/start.sh"], `: "${IMPOSTER_CONFIG_DIR:=$BUNDLE_DIR/config}"`)
+	require.Contains(t, files["mock/start.sh"], `if [ -z "${IMPOSTER_LOG_LEVEL+x}" ]; then export IMPOSTER_LOG_LEVEL='DEBUG'; fi`)
+	require.Contains(t, files["mock/start.sh"], `exec "$BUNDLE_DIR/imposter-go" "$@"`)
+}
+
+func TestProvider_Bundle_Zip(t *testing.T) {
+	configDir := writeBundleTestConfig(t)
+	binDir := t.TempDir()
+	platformDir := filepath.Join(binDir, "windows_amd64")
+	require.NoError(t, os.MkdirAll(platformDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(platformDir, binaryName+".exe"), []byte("binary"), 0755))
+
+	dest := filepath.Join(t.TempDir(), "mock.zip")
+	p := NewProvider("1.0.0", binDir)
+	err := p.Bundle(configDir, dest, engine.BundleOptions{Platforms: []string{"windows/amd64"}})
+	require.NoError(t, err)
+
+	zipReader, err := zip.OpenReader(dest)
+	require.NoError(t, err)
+	defer zipReader.Close()
+
+	var names []string
+	for _, file := range zipReader.File {
+		names = append(names, file.Name)
+	}
+	require.ElementsMatch(t, []string{
+		"mock/imposter-go.exe",
+		"mock/config/imposter-config.yaml",
+		"mock/config/responses/data.json",
+		"mock/start.bat",
+	}, names)
+}
+
+func TestProvider_Bundle_ExistingDest(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "mock.tar.gz")
+	require.NoError(t, os.WriteFile(dest, nil, 0644))
+
+	p := NewProvider("1.0.0", t.TempDir())
+	err := p.Bundle(t.TempDir(), dest, engine.BundleOptions{})
+	require.ErrorContains(t, err, "already exists")
+}
+
+func Test_getBundlePlatform(t *testing.T) {
+	goos, goarch, err := getBundlePlatform(nil)
+	require.NoError(t, err)
+	require.Equal(t, runtime.GOOS, goos)
+	require.Equal(t, runtime.GOARCH, goarch)
+
+	goos, goarch, err = getBundlePlatform([]string{"darwin/arm64"})
+	require.NoError(t, err)
+	require.Equal(t, "darwin", goos)
+	require.Equal(t, "arm64", goarch)
+
+	_, _, err = getBundlePlatform([]string{"linux/amd64", "linux/arm64"})
+	require.Error(t, err)
+	_, _, err = getBundlePlatform([]string{"linux"})
+	require.Error(t, err)
+	_, _, err = getBundlePlatform([]string{"plan9/amd64"})
+	require.Error(t, err)
+}
@@ -54,6 +54,9 @@ func (g *GolangMockEngine) startWithOptions(wg *sync.WaitGroup, options engine.S
 	if options.LogLevel != "" {
 		env = append(env, fmt.Sprintf("IMPOSTER_LOG_LEVEL=%s", options.LogLevel))
 	}
+	if options.PublicUrl != "" {
+		env = append(env, engine.PublicUrlEnvVar+"="+options.PublicUrl)
+	}
 
 	command := (*g.provider).GetStartCommand([]string{}, env)
 	command.Stdout = os.Stdout
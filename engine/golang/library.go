@@ -47,14 +47,21 @@ func (l *Library) List() ([]engine.EngineMetadata, error) {
 }
 
 func (l *Library) GetProvider(version string) engine.Provider {
-	binCachePath, err := l.ensureBinCache()
+	versionedBinDir, err := l.GetVersionDir(version)
 	if err != nil {
 		providerLogger.Fatal(err)
 	}
-	versionedBinDir := filepath.Join(binCachePath, version)
 	return NewProvider(version, versionedBinDir)
 }
 
+func (l *Library) GetVersionDir(version string) (string, error) {
+	binCachePath, err := l.ensureBinCache()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(binCachePath, version), nil
+}
+
 func (l *Library) IsSealedDistro() bool {
 	return false
 }
@@ -66,3 +73,22 @@ func (l *Library) ShouldEnsurePlugins() bool {
 func (l *Library) ensureBinCache() (string, error) {
 	return library.EnsureDirUsingConfig("golang.binCache", binCacheDir)
 }
+
+func (l *Library) ListUsage() ([]engine.CachedEngine, error) {
+	binCachePath, err := l.ensureBinCache()
+	if err != nil {
+		return nil, err
+	}
+	return engine.ListCachedVersionDirs(engine.EngineTypeGolang, binCachePath, nil)
+}
+
+func (l *Library) Remove(version string) error {
+	versionDir, err := l.GetVersionDir(version)
+	if err != nil {
+		return err
+	}
+	if err = os.RemoveAll(versionDir); err != nil {
+		return fmt.Errorf("failed to remove engine directory: %v: %v", versionDir, err)
+	}
+	return nil
+}
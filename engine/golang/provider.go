@@ -81,33 +81,39 @@ func checkOrDownloadBinary(version string, policy engine.PullPolicy, binDir stri
 		}
 	}
 
-	if err := downloadAndExtractBinary(version, binDir); err != nil {
+	if err := downloadAndExtractBinary(version, binDir, runtime.GOOS, runtime.GOARCH); err != nil {
 		return "", fmt.Errorf("failed to fetch binary: %v", err)
 	}
 	providerLogger.Tracef("using imposter-go at: %v", binaryPath)
 	return binaryPath, nil
 }
 
-func downloadAndExtractBinary(version string, binDir string) error {
-	// Create bin directory if it doesn't exist
-	if err := os.MkdirAll(binDir, 0755); err != nil {
-		return fmt.Errorf("failed to create bin directory: %v", err)
-	}
-
-	// Get platform-specific filename
-	arch := runtime.GOARCH
+// getReleaseFileName returns the name of the release archive holding the
+// binary for the given OS and architecture.
+func getReleaseFileName(goos string, goarch string) string {
+	arch := goarch
 	if arch == "amd64" {
 		arch = "x86_64"
 	}
-	goos := runtime.GOOS
+	osName := goos
 	if goos == "darwin" {
-		goos = "Darwin"
+		osName = "Darwin"
 	} else if goos == "linux" {
-		goos = "Linux"
+		osName = "Linux"
 	} else if goos == "windows" {
-		goos = "Windows"
+		osName = "Windows"
 	}
-	fileName := fmt.Sprintf("imposter-go_%s_%s.tar.gz", goos, arch)
+	return fmt.Sprintf("imposter-go_%s_%s.tar.gz", osName, arch)
+}
+
+func downloadAndExtractBinary(version string, binDir string, goos string, goarch string) error {
+	// Create bin directory if it doesn't exist
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		return fmt.Errorf("failed to create bin directory: %v", err)
+	}
+
+	// Get platform-specific filename
+	fileName := getReleaseFileName(goos, goarch)
 	downloadPath := filepath.Join(binDir, fileName)
 
 	// Download the binary
@@ -121,6 +127,11 @@ func downloadAndExtractBinary(version string, binDir string) error {
 		return fmt.Errorf("failed to extract binary: %v", err)
 	}
 
+	// Record the checksum of the extracted binary in place of the archive
+	if err := recordBinaryChecksum(downloadPath, filepath.Join(binDir, binaryName)); err != nil {
+		providerLogger.Warnf("failed to record checksum of binary: %v", err)
+	}
+
 	// Clean up the downloaded archive
 	if err := os.Remove(downloadPath); err != nil {
 		providerLogger.Warnf("failed to clean up downloaded archive: %v", err)
@@ -129,6 +140,25 @@ func downloadAndExtractBinary(version string, binDir string) error {
 	return nil
 }
 
+// recordBinaryChecksum records the checksum of the binary extracted from
+// the archive, carrying over the source and verification status of the archive.
+func recordBinaryChecksum(archivePath string, binaryPath string) error {
+	archiveRecord, err := library.GetChecksumRecord(archivePath)
+	if err != nil {
+		return err
+	}
+	var source string
+	var verified bool
+	if archiveRecord != nil {
+		source = archiveRecord.Source
+		verified = archiveRecord.Verified
+		if err = library.ForgetChecksum(archivePath); err != nil {
+			return err
+		}
+	}
+	return library.RecordChecksum(binaryPath, source, verified)
+}
+
 func (p *Provider) GetEngineType() engine.EngineType {
 	return engine.EngineTypeGolang
 }
@@ -137,11 +167,6 @@ func (p *Provider) Build(configDir string, startOptions engine.StartOptions) eng
 	return NewGolangMockEngine(configDir, startOptions, p)
 }
 
-func (p *Provider) Bundle(configDir string, dest string) error {
-	// TODO: Implement if required
-	return fmt.Errorf("bundling not implemented for golang engine")
-}
-
 func (p *Provider) GetStartCommand(args []string, env []string) *exec.Cmd {
 	if !p.Satisfied() {
 		if err := p.Provide(engine.PullIfNotPresent); err != nil {
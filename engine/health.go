@@ -17,15 +17,23 @@ limitations under the License.
 package engine
 
 import (
+	"errors"
 	"fmt"
 	"github.com/spf13/viper"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
 const defaultStartTimeout = 30 * time.Second
 
+// PublicUrlEnvVar is set in the environment of an engine started behind
+// a front proxy, and holds the URL at which clients reach the mock.
+const PublicUrlEnvVar = "IMPOSTER_CLI_PUBLIC_URL"
+
 func getStartTimeout() time.Duration {
 	startTimeout := viper.GetInt("startTimeout")
 	if startTimeout == 0 {
@@ -47,10 +55,21 @@ func IsMockUp(port int) (success bool) {
 // CheckMockStatus invokes the status endpoint on the specified port and
 // checks it returns an HTTP 200 status.
 func CheckMockStatus(port int) error {
-	url := getStatusUrl(port)
+	return checkStatusUrl(getStatusUrl(port))
+}
+
+// CheckMockUrl invokes the status endpoint of the mock at the base URL,
+// which may use HTTPS with a certificate issued by the local CA, and
+// checks it returns an HTTP 200 status.
+func CheckMockUrl(baseUrl string) error {
+	return checkStatusUrl(strings.TrimSuffix(baseUrl, "/") + statusPath)
+}
+
+func checkStatusUrl(url string) error {
 	logger.Tracef("checking mock engine at %v", url)
-	client := http.Client{
-		Timeout: 2 * time.Second,
+	client, err := newHttpClient(url, defaultProbeTimeout)
+	if err != nil {
+		return err
 	}
 	resp, err := client.Get(url)
 	if err != nil {
@@ -67,13 +86,25 @@ func CheckMockStatus(port int) error {
 	return fmt.Errorf("healthcheck status was %d for mock at %s: %s", resp.StatusCode, url, err)
 }
 
+// WaitUntilUp waits for the engine listening on the specified port to pass
+// the configured readiness checks. It exits if the mock is not ready in time.
 func WaitUntilUp(port int, shutDownC chan bool) (success bool) {
-	url := getStatusUrl(port)
-	return WaitForUrl(fmt.Sprintf("status endpoint to return HTTP 200 at %v", url), url, shutDownC)
+	readiness, err := LoadReadinessConfig()
+	if err != nil {
+		logger.Fatal(err)
+	}
+	if err = WaitUntilReady(fmt.Sprintf("http://localhost:%d", port), readiness, shutDownC); err != nil {
+		if errors.Is(err, ErrAborted) {
+			logger.Debugf("aborted waiting for mock on port %d", port)
+			return false
+		}
+		logger.Fatal(err)
+	}
+	return true
 }
 
 func getStatusUrl(port int) string {
-	return fmt.Sprintf("http://localhost:%d/system/status", port)
+	return fmt.Sprintf("http://localhost:%d%s", port, statusPath)
 }
 
 func WaitForUrl(desc string, url string, abortC chan bool) (success bool) {
@@ -125,8 +156,33 @@ func WaitForOp(desc string, timeoutDuration time.Duration, abortC chan bool, ope
 	}
 }
 
+// ResolveMockUrl returns the URL and port at which clients reach a mock
+// whose engine listens on enginePort. If the mock was started behind a
+// front proxy, publicUrl is its URL, otherwise it is empty.
+func ResolveMockUrl(enginePort int, publicUrl string) (string, int) {
+	if publicUrl != "" {
+		if parsed, err := url.Parse(publicUrl); err == nil {
+			if port, err := strconv.Atoi(parsed.Port()); err == nil {
+				return publicUrl, port
+			}
+		}
+		logger.Warnf("invalid public URL for mock: %s", publicUrl)
+	}
+	if enginePort == 0 {
+		return "", 0
+	}
+	return fmt.Sprintf("http://localhost:%d", enginePort), enginePort
+}
+
 func PopulateHealth(mock *ManagedMock) {
-	if mock.Port != 0 {
+	if mock.URL != "" {
+		if err := CheckMockUrl(mock.URL); err == nil {
+			mock.Health = MockHealthHealthy
+		} else {
+			logger.Errorf("healthcheck request failed for mock: %s", err)
+			mock.Health = MockHealthUnhealthy
+		}
+	} else if mock.Port != 0 {
 		if IsMockUp(mock.Port) {
 			mock.Health = MockHealthHealthy
 		} else {
@@ -0,0 +1,53 @@
+package engine
+
+import (
+	"gatehill.io/imposter/certs"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestResolveMockUrl(t *testing.T) {
+	tests := []struct {
+		name       string
+		enginePort int
+		publicUrl  string
+		wantUrl    string
+		wantPort   int
+	}{
+		{name: "engine port", enginePort: 8080, wantUrl: "http://localhost:8080", wantPort: 8080},
+		{name: "public url", enginePort: 51234, publicUrl: "https://localhost:8443", wantUrl: "https://localhost:8443", wantPort: 8443},
+		{name: "invalid public url", enginePort: 8080, publicUrl: "https://localhost", wantUrl: "http://localhost:8080", wantPort: 8080},
+		{name: "unknown port", enginePort: 0, wantUrl: "", wantPort: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotUrl, gotPort := ResolveMockUrl(tt.enginePort, tt.publicUrl)
+			require.Equal(t, tt.wantUrl, gotUrl)
+			require.Equal(t, tt.wantPort, gotPort)
+		})
+	}
+}
+
+func TestCheckMockUrl_Tls(t *testing.T) {
+	viper.Set("tls.dir", t.TempDir())
+	t.Cleanup(func() {
+		viper.Set("tls.dir", "")
+	})
+	tlsConfig, err := certs.ServerConfig(certs.DefaultHosts, false, "")
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/system/status" {
+			http.NotFound(w, r)
+		}
+	}))
+	server.TLS = tlsConfig
+	server.StartTLS()
+	defer server.Close()
+
+	require.NoError(t, CheckMockUrl(strings.Replace(server.URL, "127.0.0.1", "localhost", 1)))
+}
@@ -0,0 +1,148 @@
+package jvm
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gatehill.io/imposter/engine"
+	"gatehill.io/imposter/plugin"
+)
+
+const (
+	bundleConfigDir = "config"
+	bundlePluginDir = "plugins"
+	bundleDistroDir = "distro"
+	bundleJarFile   = "imposter.jar"
+)
+
+// jvmLauncher holds the arguments to the 'java' command that start the
+// engine, for the shell and Windows batch launcher scripts.
+type jvmLauncher struct {
+	shellArgs string
+	batchArgs string
+}
+
+// Bundle writes a ZIP file holding the engine JAR, the plugins used by the
+// mock, the configuration files and launcher scripts.
+func (p *SingleJarProvider) Bundle(configDir string, dest string, options engine.BundleOptions) error {
+	if !p.Satisfied() {
+		if err := p.Provide(engine.PullIfNotPresent); err != nil {
+			return err
+		}
+	}
+	pluginFiles, err := plugin.EnsureMockPluginFiles(configDir, p.Version)
+	if err != nil {
+		return err
+	}
+	files := []engine.BundleFile{{Name: bundleJarFile, Path: p.jarPath, Mode: 0644}}
+	for _, pluginFile := range pluginFiles {
+		files = append(files, engine.BundleFile{
+			Name: path.Join(bundlePluginDir, filepath.Base(pluginFile)),
+			Path: pluginFile,
+			Mode: 0644,
+		})
+	}
+	launcher := jvmLauncher{
+		shellArgs: `-jar "$BUNDLE_DIR/` + bundleJarFile + `"`,
+		batchArgs: `-jar "%~dp0` + bundleJarFile + `"`,
+	}
+	return writeJvmBundle(configDir, dest, files, launcher, len(pluginFiles) > 0, options.Environment)
+}
+
+// Bundle writes a ZIP file holding the distribution, the configuration
+// files and launcher scripts. Plugins are not added, as the distribution
+// is sealed.
+func (p *UnpackedDistroProvider) Bundle(configDir string, dest string, options engine.BundleOptions) error {
+	if !p.Satisfied() {
+		if err := p.Provide(engine.PullIfNotPresent); err != nil {
+			return err
+		}
+	}
+	files, err := engine.ListBundleFiles(p.distroDir, bundleDistroDir, true)
+	if err != nil {
+		return err
+	}
+	launcher := jvmLauncher{
+		shellArgs: `-classpath "$BUNDLE_DIR/` + bundleDistroDir + `/lib/*" ` + mainClass,
+		batchArgs: `-classpath "%~dp0` + bundleDistroDir + `\lib\*" ` + mainClass,
+	}
+	return writeJvmBundle(configDir, dest, files, launcher, false, options.Environment)
+}
+
+// writeJvmBundle writes a ZIP file at dest holding the engine files, the
+// configuration files, keeping their paths relative to the config dir, and
+// the launcher scripts.
+func writeJvmBundle(configDir string, dest string, engineFiles []engine.BundleFile, launcher jvmLauncher, hasPlugins bool, env []string) error {
+	if _, err := os.Stat(dest); err == nil {
+		return fmt.Errorf("destination bundle file already exists: %s", dest)
+	}
+	configFiles, err := engine.ListConfigBundleFiles(configDir, bundleConfigDir)
+	if err != nil {
+		return err
+	}
+	logger.Infof("bundling %d files from workspace", len(configFiles))
+
+	files := append(engineFiles, configFiles...)
+	files = append(files,
+		engine.BundleFile{Name: "run.sh", Content: []byte(buildShellLauncher(launcher, hasPlugins, env)), Mode: 0755},
+		engine.BundleFile{Name: "run.bat", Content: []byte(buildBatchLauncher(launcher, hasPlugins, env)), Mode: 0644},
+	)
+	if err = engine.WriteZipBundle(dest, engine.GetBundleRootDir(dest), files); err != nil {
+		_ = os.Remove(dest)
+		return fmt.Errorf("error writing bundle file: %s: %v", dest, err)
+	}
+	return nil
+}
+
+// buildShellLauncher returns a script that starts the engine with the
+// bundled configuration. As with GetJavaCmdPath, 'java' is found in the
+// PATH, then JAVA_HOME, then using java_home on macOS.
+func buildShellLauncher(launcher jvmLauncher, hasPlugins bool, env []string) string {
+	var script strings.Builder
+	script.WriteString(`#!/bin/sh
+set -e
+BUNDLE_DIR="$(cd "$(dirname "$0")" && pwd)"
+
+if command -v java >/dev/null 2>&1; then
+  JAVA_CMD="java"
+elif [ -n "${JAVA_HOME}" ]; then
+  JAVA_CMD="${JAVA_HOME}/bin/java"
+elif [ -x /usr/libexec/java_home ]; then
+  JAVA_CMD="$(/usr/libexec/java_home)/bin/java"
+else
+  echo "failed to determine Java path - consider setting JAVA_HOME or updating PATH" >&2
+  exit 1
+fi
+
+: "${IMPOSTER_PORT:=8080}"
+`)
+	if hasPlugins {
+		script.WriteString(`: "${IMPOSTER_PLUGIN_DIR:=$BUNDLE_DIR/` + bundlePluginDir + `}"` + "\n")
+		script.WriteString("export IMPOSTER_PLUGIN_DIR\n")
+	}
+	script.WriteString(engine.ShellEnvDefaults(env))
+	script.WriteString(`exec "$JAVA_CMD" ` + launcher.shellArgs + ` --configDir="$BUNDLE_DIR/` + bundleConfigDir + `" --listenPort="$IMPOSTER_PORT" "$@"` + "\n")
+	return script.String()
+}
+
+// buildBatchLauncher returns the Windows equivalent of the script returned
+// by buildShellLauncher.
+func buildBatchLauncher(launcher jvmLauncher, hasPlugins bool, env []string) string {
+	lines := []string{
+		"@echo off",
+		"setlocal",
+		`set "JAVA_CMD=java"`,
+		"where java >nul 2>nul",
+		`if errorlevel 1 if defined JAVA_HOME set "JAVA_CMD=%JAVA_HOME%\bin\java.exe"`,
+		`if not defined IMPOSTER_PORT set "IMPOSTER_PORT=8080"`,
+	}
+	if hasPlugins {
+		lines = append(lines, `if not defined IMPOSTER_PLUGIN_DIR set "IMPOSTER_PLUGIN_DIR=%~dp0`+bundlePluginDir+`"`)
+	}
+	script := strings.Join(lines, "\r\n") + "\r\n" + engine.BatchEnvDefaults(env)
+	script += `"%JAVA_CMD%" ` + launcher.batchArgs + ` "--configDir=%~dp0` + bundleConfigDir + `" "--listenPort=%IMPOSTER_PORT%" %*` + "\r\n"
+	return script
+}
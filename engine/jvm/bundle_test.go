@@ -0,0 +1,91 @@
+package jvm
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gatehill.io/imposter/engine"
+	"github.com/stretchr/testify/require"
+)
+
+func writeBundleTestFiles(t *testing.T, files ...string) string {
+	dir := t.TempDir()
+	for _, file := range files {
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(file), 0644))
+	}
+	return dir
+}
+
+func readBundle(t *testing.T, dest string) map[string]string {
+	zipReader, err := zip.OpenReader(dest)
+	require.NoError(t, err)
+	defer zipReader.Close()
+
+	contents := make(map[string]string)
+	for _, file := range zipReader.File {
+		r, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		_ = r.Close()
+		contents[file.Name] = string(content)
+	}
+	return contents
+}
+
+func TestSingleJarProvider_Bundle(t *testing.T) {
+	configDir := writeBundleTestFiles(t, "imposter-config.yaml", "responses/data.json", ".imposter.yaml")
+	jarPath := filepath.Join(t.TempDir(), "imposter.jar")
+	require.NoError(t, os.WriteFile(jarPath, []byte("jar"), 0644))
+
+	provider := newSingleJarProvider("4.0.0").(*SingleJarProvider)
+	provider.jarPath = jarPath
+
+	dest := filepath.Join(t.TempDir(), "mock.zip")
+	err := provider.Bundle(configDir, dest, engine.BundleOptions{Environment: []string{"IMPOSTER_LOG_LEVEL=INFO"}})
+	require.NoError(t, err)
+
+	contents := readBundle(t, dest)
+	require.Len(t, contents, 5)
+	require.Equal(t, "jar", contents["mock/imposter.jar"])
+	require.Equal(t, "imposter-config.yaml", contents["mock/config/imposter-config.yaml"])
+	require.Equal(t, "responses/data.json", contents["mock/config/responses/data.json"])
+
+	runSh := contents["mock/run.sh"]
+	require.Contains(t, runSh, `JAVA_CMD="${JAVA_HOME}/bin/java"`)
+	require.Contains(t, runSh, `if [ -z "${IMPOSTER_LOG_LEVEL+x}" ]; then export IMPOSTER_LOG_LEVEL='INFO'; fi`)
+	require.Contains(t, runSh, `exec "$JAVA_CMD" -jar "$BUNDLE_DIR/imposter.jar" --configDir="$BUNDLE_DIR/config" --listenPort="$IMPOSTER_PORT" "$@"`)
+	require.NotContains(t, runSh, "IMPOSTER_PLUGIN_DIR")
+
+	runBat := contents["mock/run.bat"]
+	require.Contains(t, runBat, `if errorlevel 1 if defined JAVA_HOME set "JAVA_CMD=%JAVA_HOME%\bin\java.exe"`)
+	require.Contains(t, runBat, `"%JAVA_CMD%" -jar "%~dp0imposter.jar" "--configDir=%~dp0config" "--listenPort=%IMPOSTER_PORT%" %*`)
+}
+
+func TestUnpackedDistroProvider_Bundle(t *testing.T) {
+	configDir := writeBundleTestFiles(t, "imposter-config.yaml")
+	distroDir := writeBundleTestFiles(t, "lib/imposter-core.jar", "lib/imposter-rest.jar")
+
+	provider := newUnpackedDistroProvider("4.0.0").(*UnpackedDistroProvider)
+	provider.distroDir = distroDir
+
+	dest := filepath.Join(t.TempDir(), "mock.zip")
+	require.NoError(t, provider.Bundle(configDir, dest, engine.BundleOptions{}))
+
+	contents := readBundle(t, dest)
+	require.Contains(t, contents, "mock/distro/lib/imposter-core.jar")
+	require.Contains(t, contents, "mock/distro/lib/imposter-rest.jar")
+	require.Contains(t, contents, "mock/config/imposter-config.yaml")
+	require.Contains(t, contents["mock/run.sh"], `-classpath "$BUNDLE_DIR/distro/lib/*" `+mainClass)
+	require.Contains(t, contents["mock/run.bat"], `-classpath "%~dp0distro\lib\*" `+mainClass)
+}
+
+func Test_buildShellLauncher_Plugins(t *testing.T) {
+	script := buildShellLauncher(jvmLauncher{shellArgs: "-jar app.jar"}, true, nil)
+	require.Contains(t, script, `: "${IMPOSTER_PLUGIN_DIR:=$BUNDLE_DIR/plugins}"`)
+}
@@ -46,7 +46,7 @@ func (j *JvmMockEngine) startWithOptions(wg *sync.WaitGroup, options engine.Star
 		"--configDir=" + j.configDir,
 		fmt.Sprintf("--listenPort=%d", options.Port),
 	}
-	env := buildEnv(options)
+	env := buildEnv(j.configDir, options)
 	command := (*j.provider).GetStartCommand(args, env)
 	command.Stdout = os.Stdout
 	command.Stderr = os.Stderr
@@ -66,11 +66,11 @@ func (j *JvmMockEngine) startWithOptions(wg *sync.WaitGroup, options engine.Star
 	return up
 }
 
-func buildEnv(options engine.StartOptions) []string {
+func buildEnv(configDir string, options engine.StartOptions) []string {
 	env := engine.BuildEnv(options, true)
 	if options.EnablePlugins {
 		logger.Tracef("plugins are enabled")
-		pluginDir, err := plugin.EnsurePluginDir(options.Version)
+		pluginDir, err := plugin.EnsureMockPluginDir(configDir, options.Version)
 		if err != nil {
 			logger.Fatal(err)
 		}
@@ -71,6 +71,17 @@ func (j JvmEngineLibrary) GetProvider(version string) engine.Provider {
 	}
 }
 
+func (j JvmEngineLibrary) GetVersionDir(version string) (string, error) {
+	if j.engineType != engine.EngineTypeJvmSingleJar {
+		return "", fmt.Errorf("engine type: %s does not cache engine binaries", j.engineType)
+	}
+	binCachePath, err := ensureBinCache()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(binCachePath, version), nil
+}
+
 func (j JvmEngineLibrary) IsSealedDistro() bool {
 	switch j.engineType {
 	case engine.EngineTypeJvmSingleJar:
@@ -85,3 +96,29 @@ func (j JvmEngineLibrary) IsSealedDistro() bool {
 func (j JvmEngineLibrary) ShouldEnsurePlugins() bool {
 	return !j.IsSealedDistro()
 }
+
+func (j JvmEngineLibrary) ListUsage() ([]engine.CachedEngine, error) {
+	if j.engineType != engine.EngineTypeJvmSingleJar {
+		// sealed distributions are not managed by the CLI
+		return nil, nil
+	}
+	binCachePath, err := ensureBinCache()
+	if err != nil {
+		return nil, err
+	}
+	return engine.ListCachedVersionDirs(engine.EngineTypeJvmSingleJar, binCachePath, func(versionDir string) bool {
+		_, err := os.Stat(filepath.Join(versionDir, "imposter.jar"))
+		return err == nil
+	})
+}
+
+func (j JvmEngineLibrary) Remove(version string) error {
+	versionDir, err := j.GetVersionDir(version)
+	if err != nil {
+		return err
+	}
+	if err = os.RemoveAll(versionDir); err != nil {
+		return fmt.Errorf("failed to remove engine directory: %v: %v", versionDir, err)
+	}
+	return nil
+}
@@ -17,7 +17,6 @@ limitations under the License.
 package jvm
 
 import (
-	"fmt"
 	"gatehill.io/imposter/debounce"
 	"gatehill.io/imposter/engine"
 	"os/exec"
@@ -55,7 +54,3 @@ func buildEngine(configDir string, provider *JvmProvider, options engine.StartOp
 func (p *JvmProviderOptions) GetEngineType() engine.EngineType {
 	return p.EngineType
 }
-
-func (p *JvmProviderOptions) Bundle(configDir string, dest string) error {
-	return fmt.Errorf("JVM engine does not support bundling")
-}
@@ -52,12 +52,13 @@ func FindImposterProcesses(matcher ProcessMatcher) ([]engine.ManagedMock, error)
 		}
 		logger.Tracef("found %s Imposter process %d: %v, env: %v", matcher.ProcessName, p.Pid, cmdline, env)
 
-		port := matcher.GetPort(cmdline, env)
+		mockUrl, port := engine.ResolveMockUrl(matcher.GetPort(cmdline, env), ReadEnv(env, engine.PublicUrlEnvVar))
 
 		mock := engine.ManagedMock{
 			ID:   fmt.Sprintf("%d", p.Pid),
 			Name: procName,
 			Port: port,
+			URL:  mockUrl,
 		}
 		mocks = append(mocks, mock)
 	}
@@ -118,3 +119,14 @@ func ReadArg(cmdline []string, longArg string, shortArg string) string {
 	}
 	return ""
 }
+
+// ReadEnv returns the value of the environment variable with the given name,
+// or the empty string if it is not set
+func ReadEnv(env []string, name string) string {
+	for _, e := range env {
+		if value, found := strings.CutPrefix(e, name+"="); found {
+			return value
+		}
+	}
+	return ""
+}
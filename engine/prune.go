@@ -0,0 +1,93 @@
+package engine
+
+import (
+	"fmt"
+	"gatehill.io/imposter/fileutil"
+	"github.com/coreos/go-semver/semver"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// ListCachedVersionDirs returns the usage of each versioned directory
+// within a binary cache. If include is non-nil, only directories for
+// which it returns true are listed.
+func ListCachedVersionDirs(engineType EngineType, binCachePath string, include func(versionDir string) bool) ([]CachedEngine, error) {
+	entries, err := os.ReadDir(binCachePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading binary cache directory: %v: %v", binCachePath, err)
+	}
+	var cached []CachedEngine
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		versionDir := filepath.Join(binCachePath, entry.Name())
+		if include != nil && !include(versionDir) {
+			continue
+		}
+		size, modified, err := fileutil.DirUsage(versionDir)
+		if err != nil {
+			return nil, err
+		}
+		cached = append(cached, CachedEngine{
+			EngineMetadata: EngineMetadata{EngineType: engineType, Version: entry.Name()},
+			Size:           size,
+			Modified:       modified,
+		})
+	}
+	return cached, nil
+}
+
+// SelectForPruning returns the engines that should be removed. Engines
+// are grouped by type and ordered from newest to oldest version. If keep
+// is non-negative, the newest keep versions of each type are retained.
+// If olderThan is non-zero, only engines last modified before that age
+// are removed. When both are set, an engine must meet both to be removed.
+func SelectForPruning(engines []CachedEngine, keep int, olderThan time.Duration, now time.Time) []CachedEngine {
+	byType := make(map[EngineType][]CachedEngine)
+	var types []EngineType
+	for _, e := range engines {
+		if _, found := byType[e.EngineType]; !found {
+			types = append(types, e.EngineType)
+		}
+		byType[e.EngineType] = append(byType[e.EngineType], e)
+	}
+
+	var selected []CachedEngine
+	for _, engineType := range types {
+		versions := byType[engineType]
+		sortNewestFirst(versions)
+		for i, e := range versions {
+			if keep >= 0 && i < keep {
+				continue
+			}
+			if olderThan > 0 && now.Sub(e.Modified) < olderThan {
+				continue
+			}
+			selected = append(selected, e)
+		}
+	}
+	return selected
+}
+
+// sortNewestFirst orders engines by descending semantic version. Versions
+// that are not valid semantic versions, such as 'latest', are ordered
+// by modification time, after the valid versions.
+func sortNewestFirst(engines []CachedEngine) {
+	sort.SliceStable(engines, func(i, j int) bool {
+		vi, errI := semver.NewVersion(engines[i].Version)
+		vj, errJ := semver.NewVersion(engines[j].Version)
+		switch {
+		case errI == nil && errJ == nil:
+			return vj.LessThan(*vi)
+		case errI == nil:
+			return true
+		case errJ == nil:
+			return false
+		default:
+			return engines[i].Modified.After(engines[j].Modified)
+		}
+	})
+}
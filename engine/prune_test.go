@@ -0,0 +1,51 @@
+package engine
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSelectForPruning(t *testing.T) {
+	now := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	cached := func(engineType EngineType, version string, ageDays int) CachedEngine {
+		return CachedEngine{
+			EngineMetadata: EngineMetadata{EngineType: engineType, Version: version},
+			Modified:       now.Add(-time.Duration(ageDays) * 24 * time.Hour),
+		}
+	}
+	engines := []CachedEngine{
+		cached(EngineTypeJvmSingleJar, "4.0.0", 40),
+		cached(EngineTypeJvmSingleJar, "4.10.0", 5),
+		cached(EngineTypeJvmSingleJar, "4.2.0", 20),
+		cached(EngineTypeGolang, "1.0.0", 60),
+		cached(EngineTypeGolang, "1.1.0", 50),
+	}
+
+	tests := []struct {
+		name      string
+		keep      int
+		olderThan time.Duration
+		want      []string
+	}{
+		{name: "keep newest per type", keep: 1, want: []string{"jvm:4.2.0", "jvm:4.0.0", "golang:1.0.0"}},
+		{name: "older than", keep: -1, olderThan: 30 * 24 * time.Hour, want: []string{"jvm:4.0.0", "golang:1.1.0", "golang:1.0.0"}},
+		{name: "keep and older than", keep: 2, olderThan: 30 * 24 * time.Hour, want: []string{"jvm:4.0.0"}},
+		{name: "keep all", keep: 5, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, e := range SelectForPruning(engines, tt.keep, tt.olderThan, now) {
+				got = append(got, string(e.EngineType)+":"+e.Version)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("SelectForPruning() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("SelectForPruning() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"gatehill.io/imposter/certs"
+	"github.com/spf13/viper"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultProbeInterval = 100 * time.Millisecond
+	defaultProbeTimeout  = 2 * time.Second
+	statusPath           = "/system/status"
+)
+
+// ErrAborted is returned when waiting for a mock is aborted, such as
+// when the mock is stopped.
+var ErrAborted = errors.New("aborted")
+
+// ReadinessConfig holds the checks that determine when a mock is ready
+// to serve requests. It is configured under the `readiness` key, such as
+// in the `.imposter.yaml` file in a project.
+type ReadinessConfig struct {
+	// Probes must all pass for the mock to be ready. If empty, the status
+	// endpoint must return HTTP 200.
+	Probes []Probe
+
+	// Interval is the time between attempts.
+	Interval time.Duration
+
+	// Retries is the maximum number of attempts. If zero, attempts continue
+	// until Timeout.
+	Retries int
+
+	// Timeout is the maximum time to wait for the mock to be ready.
+	Timeout time.Duration
+
+	// RequestTimeout is the maximum time to wait for each probe response.
+	RequestTimeout time.Duration
+}
+
+// Probe is a request to the mock that must succeed for it to be ready.
+type Probe struct {
+	// Path is requested relative to the URL of the mock, unless it
+	// is an absolute URL.
+	Path string
+
+	// Status is the expected response status (default 200).
+	Status int
+
+	// Body, if set, must be contained in the response body.
+	Body string
+}
+
+// LoadReadinessConfig returns the readiness checks from the configuration,
+// applying defaults for those not set.
+func LoadReadinessConfig() (ReadinessConfig, error) {
+	var readiness ReadinessConfig
+	if err := viper.UnmarshalKey("readiness", &readiness); err != nil {
+		return ReadinessConfig{}, fmt.Errorf("failed to parse readiness configuration: %v", err)
+	}
+	if len(readiness.Probes) == 0 {
+		readiness.Probes = []Probe{{Path: statusPath}}
+	}
+	for i := range readiness.Probes {
+		if readiness.Probes[i].Path == "" {
+			return ReadinessConfig{}, fmt.Errorf("invalid readiness configuration: probe %d has no path", i+1)
+		}
+		if readiness.Probes[i].Status == 0 {
+			readiness.Probes[i].Status = http.StatusOK
+		}
+	}
+	if readiness.Interval <= 0 {
+		readiness.Interval = defaultProbeInterval
+	}
+	if readiness.Timeout <= 0 {
+		readiness.Timeout = getStartTimeout()
+	}
+	if readiness.RequestTimeout <= 0 {
+		readiness.RequestTimeout = defaultProbeTimeout
+	}
+	return readiness, nil
+}
+
+// WaitUntilReady runs the probes against the mock at baseUrl until they all
+// pass, returning an error if the timeout or maximum number of attempts is
+// reached first. Probes that pass are not run again.
+func WaitUntilReady(baseUrl string, readiness ReadinessConfig, abortC chan bool) error {
+	logger.Tracef("waiting for mock at %s to be ready", baseUrl)
+	client, err := newHttpClient(baseUrl, readiness.RequestTimeout)
+	if err != nil {
+		return err
+	}
+	deadline := time.NewTimer(readiness.Timeout)
+	defer deadline.Stop()
+
+	pending := readiness.Probes
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-abortC:
+			return ErrAborted
+		case <-deadline.C:
+			return fmt.Errorf("timed out after %v waiting for mock at %s to be ready: %v", readiness.Timeout, baseUrl, lastErr)
+		case <-time.After(readiness.Interval):
+		}
+
+		var failed []Probe
+		for _, probe := range pending {
+			if err := runProbe(client, baseUrl, probe); err != nil {
+				logger.Tracef("readiness probe failed: %v", err)
+				failed = append(failed, probe)
+				lastErr = err
+			}
+		}
+		if pending = failed; len(pending) == 0 {
+			logger.Tracef("mock at %s is ready", baseUrl)
+			return nil
+		}
+		if readiness.Retries > 0 && attempt >= readiness.Retries {
+			return fmt.Errorf("mock at %s not ready after %d attempts: %v", baseUrl, attempt, lastErr)
+		}
+	}
+}
+
+func runProbe(client *http.Client, baseUrl string, probe Probe) error {
+	url := probe.Path
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		url = strings.TrimSuffix(baseUrl, "/") + "/" + strings.TrimPrefix(probe.Path, "/")
+	}
+	resp, err := client.Get(url)
+	if err != nil {
+		return fmt.Errorf("request to %s failed: %v", url, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response from %s: %v", url, err)
+	}
+	if resp.StatusCode != probe.Status {
+		return fmt.Errorf("status from %s was %d, expected %d", url, resp.StatusCode, probe.Status)
+	}
+	if probe.Body != "" && !strings.Contains(string(body), probe.Body) {
+		return fmt.Errorf("response from %s did not contain: %s", url, probe.Body)
+	}
+	return nil
+}
+
+// newHttpClient returns a client for requests to the mock at the URL, which
+// trusts the local CA if the URL uses HTTPS.
+func newHttpClient(url string, timeout time.Duration) (*http.Client, error) {
+	client := &http.Client{Timeout: timeout}
+	if strings.HasPrefix(url, "https://") {
+		tlsConfig, err := certs.ClientConfig()
+		if err != nil {
+			return nil, err
+		}
+		client.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	}
+	return client, nil
+}
@@ -0,0 +1,100 @@
+package engine
+
+import (
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLoadReadinessConfig(t *testing.T) {
+	t.Cleanup(func() {
+		viper.Set("readiness", nil)
+	})
+
+	readiness, err := LoadReadinessConfig()
+	require.NoError(t, err)
+	require.Equal(t, []Probe{{Path: "/system/status", Status: http.StatusOK}}, readiness.Probes)
+	require.Equal(t, defaultProbeInterval, readiness.Interval)
+	require.Equal(t, defaultStartTimeout, readiness.Timeout)
+	require.Equal(t, defaultProbeTimeout, readiness.RequestTimeout)
+
+	viper.Set("readiness", map[string]interface{}{
+		"interval": "500ms",
+		"retries":  10,
+		"timeout":  "2m",
+		"probes": []map[string]interface{}{
+			{"path": "/system/status"},
+			{"path": "/pets", "status": 201, "body": "Fluffy"},
+		},
+	})
+	readiness, err = LoadReadinessConfig()
+	require.NoError(t, err)
+	require.Equal(t, []Probe{
+		{Path: "/system/status", Status: http.StatusOK},
+		{Path: "/pets", Status: http.StatusCreated, Body: "Fluffy"},
+	}, readiness.Probes)
+	require.Equal(t, 500*time.Millisecond, readiness.Interval)
+	require.Equal(t, 10, readiness.Retries)
+	require.Equal(t, 2*time.Minute, readiness.Timeout)
+
+	viper.Set("readiness", map[string]interface{}{
+		"probes": []map[string]interface{}{{"status": 200}},
+	})
+	_, err = LoadReadinessConfig()
+	require.ErrorContains(t, err, "probe 1 has no path")
+}
+
+func TestWaitUntilReady(t *testing.T) {
+	// the pets endpoint is only ready after it has been warmed up
+	var petsRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/system/status":
+			_, _ = w.Write([]byte("ok"))
+		case "/pets":
+			if petsRequests.Add(1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte(`[{"name": "Fluffy"}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	base := ReadinessConfig{
+		Interval:       10 * time.Millisecond,
+		Timeout:        time.Second,
+		RequestTimeout: time.Second,
+	}
+	withProbes := func(retries int, probes ...Probe) ReadinessConfig {
+		readiness := base
+		readiness.Retries = retries
+		readiness.Probes = probes
+		return readiness
+	}
+
+	t.Run("ready", func(t *testing.T) {
+		readiness := withProbes(0, Probe{Path: "/system/status", Status: 200}, Probe{Path: "/pets", Status: 200, Body: "Fluffy"})
+		require.NoError(t, WaitUntilReady(server.URL, readiness, nil))
+	})
+	t.Run("unexpected body", func(t *testing.T) {
+		readiness := withProbes(0, Probe{Path: "/system/status", Status: 200, Body: "healthy"})
+		require.ErrorContains(t, WaitUntilReady(server.URL, readiness, nil), "timed out")
+	})
+	t.Run("retries exhausted", func(t *testing.T) {
+		readiness := withProbes(3, Probe{Path: "/missing", Status: 200})
+		require.ErrorContains(t, WaitUntilReady(server.URL, readiness, nil), "not ready after 3 attempts")
+	})
+	t.Run("aborted", func(t *testing.T) {
+		abortC := make(chan bool, 1)
+		abortC <- true
+		readiness := withProbes(0, Probe{Path: "/missing", Status: 200})
+		require.ErrorIs(t, WaitUntilReady(server.URL, readiness, abortC), ErrAborted)
+	})
+}
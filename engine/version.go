@@ -3,7 +3,9 @@ package engine
 import (
 	"encoding/json"
 	"fmt"
+	"gatehill.io/imposter/library"
 	"gatehill.io/imposter/prefs"
+	"gatehill.io/imposter/semverutil"
 	"github.com/coreos/go-semver/semver"
 	"io"
 	"net/http"
@@ -12,6 +14,7 @@ import (
 )
 
 const latestReleaseApi = "https://api.github.com/repos/outofcoffee/imposter/releases/latest"
+const releasesApi = "https://api.github.com/repos/outofcoffee/imposter/releases?per_page=100"
 const checkThresholdSeconds = 86_400
 
 func ResolveLatestToVersion(allowCached bool) (string, error) {
@@ -36,6 +39,118 @@ func ResolveLatestToVersion(allowCached bool) (string, error) {
 	return latest, nil
 }
 
+// ResolveConstraintToVersion returns the highest version satisfying the
+// constraint, such as `^4.2`. Installed versions of the engine type are
+// preferred, unless allowCached is false, in which case the published
+// releases are checked first.
+func ResolveConstraintToVersion(constraint string, engineType EngineType, allowCached bool) (string, error) {
+	logger.Tracef("resolving version constraint: %s (cache allowed: %v)", constraint, allowCached)
+	c, err := semverutil.ParseConstraint(constraint)
+	if err != nil {
+		return "", err
+	}
+
+	installed := c.Highest(listInstalledVersions(engineType))
+	if allowCached && installed != "" {
+		logger.Debugf("resolved version constraint %s to installed version %s", constraint, installed)
+		return installed, nil
+	}
+
+	releases, err := listReleases(allowCached)
+	if err != nil {
+		if installed != "" {
+			logger.Warnf("failed to list releases (%s) - using installed version %s", err, installed)
+			return installed, nil
+		}
+		return "", fmt.Errorf("failed to resolve version constraint %s: %s", constraint, err)
+	}
+	published := c.Highest(releases)
+	if published == "" {
+		if installed != "" {
+			return installed, nil
+		}
+		return "", fmt.Errorf("no version found matching constraint: %s", constraint)
+	}
+	logger.Debugf("resolved version constraint %s to %s", constraint, published)
+	return published, nil
+}
+
+func listInstalledVersions(engineType EngineType) []string {
+	newLibrary := libraries[engineType]
+	if newLibrary == nil {
+		return nil
+	}
+	library := newLibrary()
+	if library.IsSealedDistro() {
+		return nil
+	}
+	engines, err := library.List()
+	if err != nil {
+		logger.Debugf("could not list installed %s engines: %s", engineType, err)
+		return nil
+	}
+	var versions []string
+	for _, e := range engines {
+		versions = append(versions, e.Version)
+	}
+	return versions
+}
+
+// listReleases returns the published release versions, using the
+// cached list if allowed and it is recent enough.
+func listReleases(allowCached bool) ([]string, error) {
+	p := getVersionPrefs()
+	now := time.Now().Unix()
+	if allowCached {
+		lastCheck, _ := p.ReadPropertyInt("last_releases_check")
+		if now-int64(lastCheck) < checkThresholdSeconds {
+			if cached, _ := p.ReadPropertyString("releases"); cached != "" {
+				return strings.Split(cached, ","), nil
+			}
+		}
+	}
+
+	releases, err := fetchReleasesFromApi()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.WriteProperty("releases", strings.Join(releases, ",")); err != nil {
+		logger.Warnf("failed to record releases: %s", err)
+	}
+	if err = p.WriteProperty("last_releases_check", now); err != nil {
+		logger.Warnf("failed to record last releases check time: %s", err)
+	}
+	return releases, nil
+}
+
+func fetchReleasesFromApi() ([]string, error) {
+	releasesApi := library.ApplyMirror(releasesApi)
+	logger.Tracef("fetching releases from: %s", releasesApi)
+	resp, err := http.Get(releasesApi)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list releases from %s: %s", releasesApi, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("failed to list releases from %s - status code: %d", releasesApi, resp.StatusCode)
+	}
+	var data []struct {
+		TagName    string `json:"tag_name"`
+		Draft      bool   `json:"draft"`
+		Prerelease bool   `json:"prerelease"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to list releases from %s - cannot unmarshall response body: %s", releasesApi, err)
+	}
+	var releases []string
+	for _, release := range data {
+		if !release.Draft && !release.Prerelease {
+			releases = append(releases, strings.TrimPrefix(release.TagName, "v"))
+		}
+	}
+	return releases, nil
+}
+
 func GetHighestVersion(engines []EngineMetadata) string {
 	var highest *semver.Version
 	for _, engine := range engines {
@@ -53,6 +168,21 @@ func GetHighestVersion(engines []EngineMetadata) string {
 	return ""
 }
 
+// GetHighestMatchingVersion returns the highest version of the engines
+// satisfying the constraint, or the empty string if none do.
+func GetHighestMatchingVersion(engines []EngineMetadata, constraint string) string {
+	c, err := semverutil.ParseConstraint(constraint)
+	if err != nil {
+		logger.Warn(err)
+		return ""
+	}
+	var versions []string
+	for _, engine := range engines {
+		versions = append(versions, engine.Version)
+	}
+	return c.Highest(versions)
+}
+
 func loadCached(now int64) string {
 	var latest string
 
@@ -100,6 +230,7 @@ func getVersionPrefs() prefs.Prefs {
 }
 
 func fetchLatestFromApi() (string, error) {
+	latestReleaseApi := library.ApplyMirror(latestReleaseApi)
 	logger.Tracef("fetching latest version from: %s", latestReleaseApi)
 	resp, err := http.Get(latestReleaseApi)
 	if err != nil {
@@ -0,0 +1,68 @@
+package engine
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveConstraintToVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/repos/outofcoffee/imposter/releases" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`[
+  {"tag_name": "v5.0.0"},
+  {"tag_name": "v4.3.0-rc1", "prerelease": true},
+  {"tag_name": "v4.2.5"},
+  {"tag_name": "v4.2.1"},
+  {"tag_name": "v4.1.0"}
+]`))
+	}))
+	defer server.Close()
+
+	viper.Set("download.mirror", server.URL)
+	viper.Set("prefs.dir", filepath.Join(t.TempDir(), "prefs"))
+	t.Cleanup(func() {
+		viper.Set("download.mirror", nil)
+		viper.Set("prefs.dir", nil)
+	})
+
+	original := libraries[EngineTypeGolang]
+	RegisterLibrary(EngineTypeGolang, func() EngineLibrary {
+		return fakeCachingLibrary{versions: []string{"4.2.2", "4.1.0"}}
+	})
+	t.Cleanup(func() {
+		libraries[EngineTypeGolang] = original
+	})
+
+	tests := []struct {
+		name        string
+		constraint  string
+		allowCached bool
+		want        string
+		wantErr     bool
+	}{
+		{name: "prefers installed version", constraint: "^4.2", allowCached: true, want: "4.2.2"},
+		{name: "uses published version if not cached", constraint: "^4.2", allowCached: false, want: "4.2.5"},
+		{name: "uses published version if none installed", constraint: ">=5", allowCached: true, want: "5.0.0"},
+		{name: "patch range", constraint: "~4.2.1", allowCached: false, want: "4.2.5"},
+		{name: "no match", constraint: "^6", allowCached: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveConstraintToVersion(tt.constraint, EngineTypeGolang, tt.allowCached)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
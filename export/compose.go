@@ -0,0 +1,68 @@
+package export
+
+import (
+	"fmt"
+	"path/filepath"
+	"sigs.k8s.io/yaml"
+	"strings"
+)
+
+// Compose returns a Docker Compose file for the project. The paths of
+// mounted files are relative to baseDir, which should be the directory
+// holding the Compose file.
+func Compose(project Project, baseDir string) ([]byte, error) {
+	var volumes []string
+	if !project.Baked {
+		volumes = append(volumes, relativeTo(baseDir, project.ConfigDir)+":"+containerConfigDir+":ro")
+	}
+	for _, plugin := range project.Plugins {
+		if plugin.LocalPath == "" {
+			return nil, fmt.Errorf("plugin %s is not installed", plugin.FileName)
+		}
+		volumes = append(volumes, plugin.LocalPath+":"+containerPluginDir+"/"+plugin.FileName+":ro")
+	}
+
+	service := map[string]interface{}{
+		"image":   project.Image,
+		"command": project.engineArgs(),
+		"ports":   []string{fmt.Sprintf("%d:%d", project.Port, project.Port)},
+	}
+	if len(project.Env) > 0 {
+		service["environment"] = project.envMap()
+	}
+	if len(volumes) > 0 {
+		service["volumes"] = volumes
+	}
+	if !project.Distroless {
+		service["healthcheck"] = map[string]interface{}{
+			"test":     []string{"CMD", "curl", "-fs", fmt.Sprintf("http://localhost:%d%s", project.Port, statusPath)},
+			"interval": "5s",
+			"timeout":  "2s",
+			"retries":  12,
+		}
+	}
+
+	compose := map[string]interface{}{
+		"services": map[string]interface{}{
+			project.Name: service,
+		},
+	}
+	return yaml.Marshal(compose)
+}
+
+// relativeTo returns the path relative to baseDir, in the form Compose
+// expects for bind mounts, or the absolute path if it cannot be made
+// relative.
+func relativeTo(baseDir string, path string) string {
+	rel, err := filepath.Rel(baseDir, path)
+	if err != nil {
+		return path
+	}
+	rel = filepath.ToSlash(rel)
+	if rel == "." {
+		return "./"
+	} else if !strings.HasPrefix(rel, "../") {
+		rel = "./" + rel
+	}
+	return rel
+}
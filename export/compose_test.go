@@ -0,0 +1,64 @@
+package export
+
+import (
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"sigs.k8s.io/yaml"
+	"testing"
+)
+
+func TestCompose(t *testing.T) {
+	configDir := newConfigDir(t)
+
+	t.Run("mounts config and plugins", func(t *testing.T) {
+		manifest, err := Compose(newProject(configDir), filepath.Dir(configDir))
+		require.NoError(t, err)
+
+		var compose struct {
+			Services map[string]struct {
+				Image       string            `json:"image"`
+				Command     []string          `json:"command"`
+				Environment map[string]string `json:"environment"`
+				Ports       []string          `json:"ports"`
+				Volumes     []string          `json:"volumes"`
+				Healthcheck map[string]any    `json:"healthcheck"`
+			} `json:"services"`
+		}
+		require.NoError(t, yaml.Unmarshal(manifest, &compose))
+		service, ok := compose.Services["petstore"]
+		require.True(t, ok, "service should be named after the project")
+		require.Equal(t, "outofcoffee/imposter:4.2.0", service.Image)
+		require.Equal(t, []string{"--configDir=/opt/imposter/config", "--listenPort=8080"}, service.Command)
+		require.Equal(t, map[string]string{"IMPOSTER_FOO": "bar=baz"}, service.Environment)
+		require.Equal(t, []string{"8080:8080"}, service.Ports)
+		require.Equal(t, []string{
+			"./" + filepath.Base(configDir) + ":/opt/imposter/config:ro",
+			"/plugins/imposter-plugin-store-redis.jar:/opt/imposter/plugins/imposter-plugin-store-redis.jar:ro",
+		}, service.Volumes)
+		require.NotEmpty(t, service.Healthcheck)
+	})
+
+	t.Run("baked distroless image", func(t *testing.T) {
+		project := newProject(configDir)
+		project.Baked = true
+		project.Distroless = true
+		project.Plugins = nil
+		manifest, err := Compose(project, configDir)
+		require.NoError(t, err)
+		require.NotContains(t, string(manifest), "volumes")
+		require.NotContains(t, string(manifest), "healthcheck")
+	})
+
+	t.Run("plugin not installed", func(t *testing.T) {
+		project := newProject(configDir)
+		project.Plugins[0].LocalPath = ""
+		_, err := Compose(project, configDir)
+		require.Error(t, err)
+	})
+}
+
+func Test_relativeTo(t *testing.T) {
+	require.Equal(t, "./", relativeTo("/work/mock", "/work/mock"))
+	require.Equal(t, "./mock", relativeTo("/work", "/work/mock"))
+	require.Equal(t, "../mock", relativeTo("/work/deploy", "/work/mock"))
+}
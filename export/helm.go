@@ -0,0 +1,239 @@
+package export
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sigs.k8s.io/yaml"
+)
+
+const chartVersion = "0.1.0"
+
+// chartTemplates are written to the templates directory of the chart. The
+// configuration files are read from the config directory of the chart, so
+// the chart can be upgraded after they change.
+var chartTemplates = map[string]string{
+	"_helpers.tpl": `{{- define "imposter.fullname" -}}
+{{- if contains .Chart.Name .Release.Name }}
+{{- .Release.Name | trunc 53 | trimSuffix "-" }}
+{{- else }}
+{{- printf "%s-%s" .Release.Name .Chart.Name | trunc 53 | trimSuffix "-" }}
+{{- end }}
+{{- end }}
+
+{{- define "imposter.selectorLabels" -}}
+app.kubernetes.io/name: {{ .Chart.Name }}
+app.kubernetes.io/instance: {{ .Release.Name }}
+{{- end }}
+
+{{- define "imposter.labels" -}}
+{{ include "imposter.selectorLabels" . }}
+app.kubernetes.io/managed-by: {{ .Release.Service }}
+{{- end }}
+
+{{/* ConfigMap keys cannot contain path separators */}}
+{{- define "imposter.configKey" -}}
+{{ regexReplaceAll "[^-._a-zA-Z0-9]" (. | trimPrefix "config/" | replace "/" "__") "_" }}
+{{- end }}
+`,
+	"configmap.yaml": `{{- if .Values.mountConfig }}
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ include "imposter.fullname" . }}-config
+  labels:
+    {{- include "imposter.labels" . | nindent 4 }}
+binaryData:
+  {{- range $path, $_ := .Files.Glob "config/**" }}
+  {{ include "imposter.configKey" $path }}: {{ $.Files.Get $path | b64enc }}
+  {{- end }}
+{{- end }}
+`,
+	"deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ include "imposter.fullname" . }}
+  labels:
+    {{- include "imposter.labels" . | nindent 4 }}
+spec:
+  replicas: {{ .Values.replicaCount }}
+  selector:
+    matchLabels:
+      {{- include "imposter.selectorLabels" . | nindent 6 }}
+  template:
+    metadata:
+      labels:
+        {{- include "imposter.selectorLabels" . | nindent 8 }}
+      annotations:
+        checksum/config: {{ include (print $.Template.BasePath "/configmap.yaml") . | sha256sum }}
+    spec:
+      {{- with .Values.plugins }}
+      initContainers:
+        - name: install-plugins
+          image: {{ $.Values.pluginInstaller.image }}
+          command:
+            - sh
+            - -c
+            - |
+              set -e
+              {{- range . }}
+              curl -fsSL -o /opt/imposter/plugins/{{ .file }} {{ .url }}
+              {{- end }}
+          volumeMounts:
+            - name: plugins
+              mountPath: /opt/imposter/plugins
+      {{- end }}
+      containers:
+        - name: imposter
+          image: "{{ .Values.image.repository }}:{{ .Values.image.tag }}"
+          imagePullPolicy: {{ .Values.image.pullPolicy }}
+          args:
+            - --configDir=/opt/imposter/config
+            - --listenPort={{ .Values.port }}
+          {{- with .Values.env }}
+          env:
+            {{- range $name, $value := . }}
+            - name: {{ $name }}
+              value: {{ $value | quote }}
+            {{- end }}
+          {{- end }}
+          ports:
+            - name: http
+              containerPort: {{ .Values.port }}
+          readinessProbe:
+            httpGet:
+              path: /system/status
+              port: http
+          livenessProbe:
+            httpGet:
+              path: /system/status
+              port: http
+            initialDelaySeconds: 30
+          {{- with .Values.resources }}
+          resources:
+            {{- toYaml . | nindent 12 }}
+          {{- end }}
+          {{- if or .Values.mountConfig .Values.plugins }}
+          volumeMounts:
+            {{- if .Values.mountConfig }}
+            - name: config
+              mountPath: /opt/imposter/config
+              readOnly: true
+            {{- end }}
+            {{- if .Values.plugins }}
+            - name: plugins
+              mountPath: /opt/imposter/plugins
+            {{- end }}
+          {{- end }}
+      {{- if or .Values.mountConfig .Values.plugins }}
+      volumes:
+        {{- if .Values.mountConfig }}
+        - name: config
+          configMap:
+            name: {{ include "imposter.fullname" . }}-config
+            items:
+              {{- range $path, $_ := .Files.Glob "config/**" }}
+              - key: {{ include "imposter.configKey" $path }}
+                path: {{ trimPrefix "config/" $path | quote }}
+              {{- end }}
+        {{- end }}
+        {{- if .Values.plugins }}
+        - name: plugins
+          emptyDir: {}
+        {{- end }}
+      {{- end }}
+`,
+	"service.yaml": `apiVersion: v1
+kind: Service
+metadata:
+  name: {{ include "imposter.fullname" . }}
+  labels:
+    {{- include "imposter.labels" . | nindent 4 }}
+spec:
+  type: {{ .Values.service.type }}
+  selector:
+    {{- include "imposter.selectorLabels" . | nindent 4 }}
+  ports:
+    - name: http
+      port: {{ .Values.service.port }}
+      targetPort: http
+`,
+}
+
+// WriteHelmChart writes a Helm chart for the project to chartDir, returning
+// the paths written. Unless the image is baked, the configuration files are
+// copied into the chart and held in a ConfigMap.
+func WriteHelmChart(project Project, chartDir string, forceOverwrite bool) ([]string, error) {
+	if entries, err := os.ReadDir(chartDir); err == nil && len(entries) > 0 && !forceOverwrite {
+		return nil, fmt.Errorf("chart directory is not empty: %s", chartDir)
+	}
+	var files []configFile
+	if !project.Baked {
+		var err error
+		if files, err = listConfigFiles(project.ConfigDir); err != nil {
+			return nil, err
+		}
+	}
+
+	repository, tag := splitImage(project.Image)
+	chart := map[string]interface{}{
+		"apiVersion":  "v2",
+		"name":        project.Name,
+		"description": "Imposter mock for " + project.Name,
+		"type":        "application",
+		"version":     chartVersion,
+		"appVersion":  tag,
+	}
+	var plugins []map[string]string
+	for _, plugin := range project.Plugins {
+		plugins = append(plugins, map[string]string{"file": plugin.FileName, "url": plugin.Url})
+	}
+	values := map[string]interface{}{
+		"replicaCount": 1,
+		"image": map[string]string{
+			"repository": repository,
+			"tag":        tag,
+			"pullPolicy": "IfNotPresent",
+		},
+		"mountConfig":     !project.Baked,
+		"port":            project.Port,
+		"service":         map[string]interface{}{"type": "ClusterIP", "port": project.Port},
+		"env":             project.envMap(),
+		"plugins":         plugins,
+		"pluginInstaller": map[string]string{"image": pluginInstallerImage},
+		"resources":       map[string]interface{}{},
+	}
+
+	var written []string
+	write := func(relPath string, content []byte) error {
+		path := filepath.Join(chartDir, filepath.FromSlash(relPath))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for: %s: %v", path, err)
+		}
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return fmt.Errorf("failed to write chart file: %s: %v", path, err)
+		}
+		written = append(written, path)
+		return nil
+	}
+	for relPath, content := range map[string]interface{}{"Chart.yaml": chart, "values.yaml": values} {
+		y, err := yaml.Marshal(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s: %v", relPath, err)
+		}
+		if err = write(relPath, y); err != nil {
+			return nil, err
+		}
+	}
+	for name, template := range chartTemplates {
+		if err := write("templates/"+name, []byte(template)); err != nil {
+			return nil, err
+		}
+	}
+	for _, file := range files {
+		if err := write("config/"+file.relPath, file.content); err != nil {
+			return nil, err
+		}
+	}
+	return written, nil
+}
@@ -0,0 +1,44 @@
+package export
+
+import (
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"sigs.k8s.io/yaml"
+	"testing"
+)
+
+func TestWriteHelmChart(t *testing.T) {
+	configDir := newConfigDir(t)
+	chartDir := filepath.Join(t.TempDir(), "chart")
+
+	written, err := WriteHelmChart(newProject(configDir), chartDir, false)
+	require.NoError(t, err)
+	require.Len(t, written, 2+len(chartTemplates)+2)
+	require.FileExists(t, filepath.Join(chartDir, "templates", "deployment.yaml"))
+	require.FileExists(t, filepath.Join(chartDir, "config", "responses", "pets.json"))
+
+	values, err := os.ReadFile(filepath.Join(chartDir, "values.yaml"))
+	require.NoError(t, err)
+	var parsed struct {
+		Image struct {
+			Repository string `json:"repository"`
+			Tag        string `json:"tag"`
+		} `json:"image"`
+		MountConfig bool                `json:"mountConfig"`
+		Env         map[string]string   `json:"env"`
+		Plugins     []map[string]string `json:"plugins"`
+	}
+	require.NoError(t, yaml.Unmarshal(values, &parsed))
+	require.Equal(t, "outofcoffee/imposter", parsed.Image.Repository)
+	require.Equal(t, "4.2.0", parsed.Image.Tag)
+	require.True(t, parsed.MountConfig)
+	require.Equal(t, map[string]string{"IMPOSTER_FOO": "bar=baz"}, parsed.Env)
+	require.Equal(t, "imposter-plugin-store-redis.jar", parsed.Plugins[0]["file"])
+
+	_, err = WriteHelmChart(newProject(configDir), chartDir, false)
+	require.Error(t, err, "should not overwrite existing chart")
+
+	_, err = WriteHelmChart(newProject(configDir), chartDir, true)
+	require.NoError(t, err)
+}
@@ -0,0 +1,199 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"sigs.k8s.io/yaml"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxConfigMapSize is the limit on the size of the data in a ConfigMap.
+const maxConfigMapSize = 1024 * 1024
+
+type object = map[string]interface{}
+
+// Kubernetes returns the manifests for a Deployment and Service running the
+// project. Unless the image is baked, the configuration files are held in
+// a ConfigMap. Plugins are downloaded by an init container.
+func Kubernetes(project Project) ([]byte, error) {
+	var manifests []object
+	var files []configFile
+	if !project.Baked {
+		var err error
+		if files, err = listConfigFiles(project.ConfigDir); err != nil {
+			return nil, err
+		}
+		configMap, err := buildConfigMap(project, files)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, configMap)
+	}
+	manifests = append(manifests, buildDeployment(project, files), buildService(project))
+
+	var out bytes.Buffer
+	for i, manifest := range manifests {
+		y, err := yaml.Marshal(manifest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal manifest: %v", err)
+		}
+		if i > 0 {
+			out.WriteString("---\n")
+		}
+		out.Write(y)
+	}
+	return out.Bytes(), nil
+}
+
+func buildConfigMap(project Project, files []configFile) (object, error) {
+	data := make(map[string]string)
+	binaryData := make(map[string][]byte)
+	var size int
+	for _, file := range files {
+		key := configMapKey(file.relPath)
+		if _, exists := data[key]; exists {
+			return nil, fmt.Errorf("config files have the same ConfigMap key: %s", key)
+		} else if _, exists := binaryData[key]; exists {
+			return nil, fmt.Errorf("config files have the same ConfigMap key: %s", key)
+		}
+		if utf8.Valid(file.content) {
+			data[key] = string(file.content)
+		} else {
+			binaryData[key] = file.content
+		}
+		size += len(file.content)
+	}
+	if size > maxConfigMapSize {
+		return nil, fmt.Errorf("config files are too large for a ConfigMap (%d bytes) - bundle them into an image with 'imposter bundle' and pass --image", size)
+	}
+
+	configMap := object{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   buildMetadata(project, project.Name+"-config"),
+	}
+	if len(data) > 0 {
+		configMap["data"] = data
+	}
+	if len(binaryData) > 0 {
+		// marshalled as base64
+		configMap["binaryData"] = binaryData
+	}
+	return configMap, nil
+}
+
+func buildDeployment(project Project, files []configFile) object {
+	selector := map[string]string{"app.kubernetes.io/name": project.Name}
+	httpGet := object{"path": statusPath, "port": "http"}
+
+	container := object{
+		"name":  "imposter",
+		"image": project.Image,
+		"args":  project.engineArgs(),
+		"ports": []object{
+			{"name": "http", "containerPort": project.Port},
+		},
+		"readinessProbe": object{"httpGet": httpGet},
+		"livenessProbe":  object{"httpGet": httpGet, "initialDelaySeconds": 30},
+	}
+	if env := buildEnv(project); len(env) > 0 {
+		container["env"] = env
+	}
+
+	podSpec := object{}
+	var volumes, volumeMounts []object
+	if !project.Baked {
+		var items []object
+		for _, file := range files {
+			items = append(items, object{"key": configMapKey(file.relPath), "path": file.relPath})
+		}
+		volumes = append(volumes, object{
+			"name":      "config",
+			"configMap": object{"name": project.Name + "-config", "items": items},
+		})
+		volumeMounts = append(volumeMounts, object{"name": "config", "mountPath": containerConfigDir, "readOnly": true})
+	}
+	if len(project.Plugins) > 0 {
+		pluginMount := object{"name": "plugins", "mountPath": containerPluginDir}
+		volumes = append(volumes, object{"name": "plugins", "emptyDir": object{}})
+		volumeMounts = append(volumeMounts, pluginMount)
+		podSpec["initContainers"] = []object{
+			{
+				"name":         "install-plugins",
+				"image":        pluginInstallerImage,
+				"command":      []string{"sh", "-c", buildPluginInstallScript(project.Plugins)},
+				"volumeMounts": []object{pluginMount},
+			},
+		}
+	}
+	if len(volumeMounts) > 0 {
+		container["volumeMounts"] = volumeMounts
+		podSpec["volumes"] = volumes
+	}
+	podSpec["containers"] = []object{container}
+
+	return object{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   buildMetadata(project, project.Name),
+		"spec": object{
+			"replicas": 1,
+			"selector": object{"matchLabels": selector},
+			"template": object{
+				"metadata": object{"labels": selector},
+				"spec":     podSpec,
+			},
+		},
+	}
+}
+
+func buildService(project Project) object {
+	return object{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata":   buildMetadata(project, project.Name),
+		"spec": object{
+			"selector": map[string]string{"app.kubernetes.io/name": project.Name},
+			"ports": []object{
+				{"name": "http", "port": project.Port, "targetPort": "http"},
+			},
+		},
+	}
+}
+
+func buildMetadata(project Project, name string) object {
+	return object{
+		"name": name,
+		"labels": map[string]string{
+			"app.kubernetes.io/name":       project.Name,
+			"app.kubernetes.io/managed-by": "imposter-cli",
+		},
+	}
+}
+
+func buildEnv(project Project) []object {
+	env := project.envMap()
+	var names []string
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var vars []object
+	for _, name := range names {
+		vars = append(vars, object{"name": name, "value": env[name]})
+	}
+	return vars
+}
+
+// buildPluginInstallScript returns a shell script that downloads the
+// plugins into the plugin directory.
+func buildPluginInstallScript(plugins []Plugin) string {
+	var commands []string
+	for _, plugin := range plugins {
+		commands = append(commands, fmt.Sprintf("curl -fsSL -o %s/%s %s", containerPluginDir, plugin.FileName, plugin.Url))
+	}
+	return strings.Join(commands, " && ")
+}
@@ -0,0 +1,48 @@
+package export
+
+import (
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestKubernetes(t *testing.T) {
+	configDir := newConfigDir(t)
+
+	t.Run("config map and plugins", func(t *testing.T) {
+		manifest, err := Kubernetes(newProject(configDir))
+		require.NoError(t, err)
+
+		docs := strings.Split(string(manifest), "---\n")
+		require.Len(t, docs, 3)
+		require.Contains(t, docs[0], "kind: ConfigMap")
+		require.Contains(t, docs[0], "responses__pets.json: |")
+		require.Contains(t, docs[1], "kind: Deployment")
+		require.Contains(t, docs[1], "path: responses/pets.json")
+		require.Contains(t, docs[1], "curl -fsSL -o /opt/imposter/plugins/imposter-plugin-store-redis.jar https://example.com/v4.2.0/imposter-plugin-store-redis.jar")
+		require.Contains(t, docs[1], "path: /system/status")
+		require.Contains(t, docs[1], "value: bar=baz")
+		require.Contains(t, docs[2], "kind: Service")
+	})
+
+	t.Run("binary files", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(configDir, "logo.png"), []byte{0x89, 0x50, 0x4e, 0x47, 0xff}, 0644))
+		t.Cleanup(func() { _ = os.Remove(filepath.Join(configDir, "logo.png")) })
+
+		manifest, err := Kubernetes(newProject(configDir))
+		require.NoError(t, err)
+		require.Contains(t, string(manifest), "binaryData:\n  logo.png: iVBOR/8=")
+	})
+
+	t.Run("baked image", func(t *testing.T) {
+		project := newProject(configDir)
+		project.Baked = true
+		project.Plugins = nil
+		manifest, err := Kubernetes(project)
+		require.NoError(t, err)
+		require.NotContains(t, string(manifest), "ConfigMap")
+		require.NotContains(t, string(manifest), "volumes")
+	})
+}
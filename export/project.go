@@ -0,0 +1,145 @@
+package export
+
+import (
+	"fmt"
+	"gatehill.io/imposter/fileutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	containerConfigDir = "/opt/imposter/config"
+	containerPluginDir = "/opt/imposter/plugins"
+	statusPath         = "/system/status"
+
+	// pluginInstallerImage downloads plugins into the pod before the
+	// engine starts.
+	pluginInstallerImage = "curlimages/curl:8.10.1"
+)
+
+var (
+	invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+	invalidKeyChars  = regexp.MustCompile(`[^-._a-zA-Z0-9]`)
+)
+
+// Project describes a mock to export as deployment manifests.
+type Project struct {
+	// Name is used for the service and its resources. It must be a
+	// valid DNS label, as returned by SanitiseName.
+	Name string
+
+	// ConfigDir holds the mock configuration files.
+	ConfigDir string
+
+	// Image is the engine image and tag.
+	Image string
+
+	// Baked is true if the image already contains the configuration,
+	// such as one built by `imposter bundle`, so it is not mounted.
+	Baked bool
+
+	// Distroless is true if the image has no shell tools, so a container
+	// health check command cannot be run.
+	Distroless bool
+
+	// Port is the port on which the engine listens.
+	Port int
+
+	// Env holds the environment variables for the engine, in the
+	// form KEY=VALUE.
+	Env []string
+
+	// Plugins are installed in the engine plugin directory.
+	Plugins []Plugin
+}
+
+// Plugin is a plugin file used by the mock.
+type Plugin struct {
+	// FileName is the name of the plugin file in the plugin directory.
+	FileName string
+
+	// LocalPath is the path of the installed plugin file, if any.
+	LocalPath string
+
+	// Url is the location from which the plugin file is downloaded.
+	Url string
+}
+
+type configFile struct {
+	relPath string
+	content []byte
+}
+
+// SanitiseName returns the name as a valid DNS label, for use as the name
+// of services and Kubernetes resources.
+func SanitiseName(name string) string {
+	sanitised := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if len(sanitised) > 53 {
+		// leave room for resource name suffixes, such as '-config'
+		sanitised = strings.TrimRight(sanitised[:53], "-")
+	}
+	if sanitised == "" {
+		return "imposter"
+	}
+	return sanitised
+}
+
+// splitImage returns the repository and tag of the image.
+func splitImage(image string) (repository string, tag string) {
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[:i], image[i+1:]
+	}
+	return image, "latest"
+}
+
+// envMap returns the environment variables of the project, keyed by name.
+func (p Project) envMap() map[string]string {
+	env := make(map[string]string)
+	for _, e := range p.Env {
+		key, value, _ := strings.Cut(e, "=")
+		env[key] = value
+	}
+	return env
+}
+
+func (p Project) engineArgs() []string {
+	return []string{
+		"--configDir=" + containerConfigDir,
+		fmt.Sprintf("--listenPort=%d", p.Port),
+	}
+}
+
+// listConfigFiles returns the files in the config dir and its subdirectories,
+// skipping hidden files and directories, such as the `.imposter.yaml` file,
+// and those excluded by the `.imposterignore` file.
+func listConfigFiles(configDir string) ([]configFile, error) {
+	local, err := fileutil.ListProjectFiles(configDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list config files in: %s: %v", configDir, err)
+	}
+	var files []configFile
+	for _, path := range local {
+		relPath, err := filepath.Rel(configDir, path)
+		if err != nil {
+			return nil, err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file: %s: %v", path, err)
+		}
+		files = append(files, configFile{relPath: filepath.ToSlash(relPath), content: content})
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no config files found in: %s", configDir)
+	}
+	return files, nil
+}
+
+// configMapKey returns the key for the file in a ConfigMap, which cannot
+// contain path separators. The file is mounted at its original path using
+// the items of the volume.
+func configMapKey(relPath string) string {
+	return invalidKeyChars.ReplaceAllString(strings.ReplaceAll(relPath, "/", "__"), "_")
+}
@@ -0,0 +1,83 @@
+package export
+
+import (
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSanitiseName(t *testing.T) {
+	tests := map[string]string{
+		"petstore":      "petstore",
+		"Pet Store":     "pet-store",
+		"orders_api.v2": "orders-api-v2",
+		"--mock--":      "mock",
+		"!!!":           "imposter",
+		"a-very-long-name-that-exceeds-the-maximum-length-of-a-label": "a-very-long-name-that-exceeds-the-maximum-length-of-a",
+	}
+	for name, want := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, want, SanitiseName(name))
+		})
+	}
+}
+
+func Test_splitImage(t *testing.T) {
+	repository, tag := splitImage("outofcoffee/imposter:4.2.0")
+	require.Equal(t, "outofcoffee/imposter", repository)
+	require.Equal(t, "4.2.0", tag)
+
+	repository, tag = splitImage("localhost:5000/mocks/petstore")
+	require.Equal(t, "localhost:5000/mocks/petstore", repository)
+	require.Equal(t, "latest", tag)
+}
+
+func Test_listConfigFiles(t *testing.T) {
+	configDir := newConfigDir(t)
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, ".imposter.yaml"), []byte("engine: docker\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(configDir, ".imposter", "cache"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, ".imposter", "cache", "file"), []byte("x"), 0644))
+
+	files, err := listConfigFiles(configDir)
+	require.NoError(t, err)
+	var paths []string
+	for _, file := range files {
+		paths = append(paths, file.relPath)
+	}
+	require.Equal(t, []string{"petstore-config.yaml", "responses/pets.json"}, paths)
+
+	_, err = listConfigFiles(t.TempDir())
+	require.Error(t, err, "empty config dir should fail")
+}
+
+func Test_configMapKey(t *testing.T) {
+	require.Equal(t, "petstore-config.yaml", configMapKey("petstore-config.yaml"))
+	require.Equal(t, "responses__pets.json", configMapKey("responses/pets.json"))
+	require.Equal(t, "my_file.json", configMapKey("my file.json"))
+}
+
+func newConfigDir(t *testing.T) string {
+	configDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(configDir, "responses"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "petstore-config.yaml"), []byte("plugin: rest\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "responses", "pets.json"), []byte("[]\n"), 0644))
+	return configDir
+}
+
+func newProject(configDir string) Project {
+	return Project{
+		Name:      "petstore",
+		ConfigDir: configDir,
+		Image:     "outofcoffee/imposter:4.2.0",
+		Port:      8080,
+		Env:       []string{"IMPOSTER_FOO=bar=baz"},
+		Plugins: []Plugin{
+			{
+				FileName:  "imposter-plugin-store-redis.jar",
+				LocalPath: "/plugins/imposter-plugin-store-redis.jar",
+				Url:       "https://example.com/v4.2.0/imposter-plugin-store-redis.jar",
+			},
+		},
+	}
+}
@@ -18,6 +18,9 @@ package fileutil
 
 import (
 	"github.com/radovskyb/watcher"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -26,12 +29,32 @@ const watchDebounceMs = 1000
 // WatchDir observes changes to the given directory
 // and notifies on a channel when they occur.
 func WatchDir(dir string) (updatedC chan bool) {
+	return WatchDirAndFiles(dir, nil)
+}
+
+// WatchDirAndFiles observes changes to the given directory, as well as
+// the given files, which may be outside the directory, and notifies on
+// a channel when they occur. Changes to files in the directory excluded
+// by its ignore file, or by the default ignore rules, are not notified.
+func WatchDirAndFiles(dir string, files []string) (updatedC chan bool) {
 	updatedC = make(chan bool)
 
 	w := watcher.New()
+	rules, err := LoadIgnoreRules(dir)
+	if err != nil {
+		logger.Warnln(err)
+	}
+	w.AddFilterHook(ignoreFilterHook(dir, rules))
 	if err := w.AddRecursive(dir); err != nil {
 		logger.Warnln(err)
 	}
+	for _, file := range files {
+		if err := w.Add(file); err != nil {
+			logger.Warnln(err)
+		} else {
+			logger.Infof("watching for changes to: %v", file)
+		}
+	}
 
 	dirUpdated := false
 	go func() {
@@ -70,3 +93,18 @@ func WatchDir(dir string) (updatedC chan bool) {
 
 	return updatedC
 }
+
+// ignoreFilterHook skips the files in dir excluded by the ignore rules.
+// Files outside dir are not skipped.
+func ignoreFilterHook(dir string, rules *IgnoreRules) watcher.FilterFileHookFunc {
+	return func(info os.FileInfo, fullPath string) error {
+		relPath, err := filepath.Rel(dir, fullPath)
+		if err != nil || relPath == "." || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+			return nil
+		}
+		if rules.Ignored(filepath.ToSlash(relPath), info.IsDir()) {
+			return watcher.ErrSkip
+		}
+		return nil
+	}
+}
@@ -21,9 +21,11 @@ import (
 	"gatehill.io/imposter/logging"
 	"gatehill.io/imposter/stringutil"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 var logger = logging.GetLogger()
@@ -128,6 +130,33 @@ func ListFiles(dir string, includeHidden bool) ([]string, error) {
 	return files, nil
 }
 
+// ListFilesRecursive returns the files in the dir and its subdirectories.
+// Unless includeHidden is true, hidden files and directories are skipped.
+func ListFilesRecursive(dir string, includeHidden bool) ([]string, error) {
+	logger.Tracef("listing files recursively in: %s", dir)
+
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && !includeHidden && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list directory contents: %s: %s", dir, err)
+	}
+	return files, nil
+}
+
 func ReadFile(filePath string) (*[]byte, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -140,3 +169,29 @@ func ReadFile(filePath string) (*[]byte, error) {
 	}
 	return &contents, err
 }
+
+// DirUsage returns the total size of the files within dir, including
+// subdirectories, and the most recent modification time of dir or any
+// of its contents.
+func DirUsage(dir string) (size int64, modified time.Time, err error) {
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		if info.ModTime().After(modified) {
+			modified = info.ModTime()
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to determine disk usage of: %s: %v", dir, err)
+	}
+	return size, modified, nil
+}
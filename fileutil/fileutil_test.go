@@ -168,6 +168,34 @@ func TestCopyDirShallow(t *testing.T) {
 	}
 }
 
+func TestDirUsage(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("12345"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sub", "b.txt"), []byte("123"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	size, modified, err := DirUsage(dir)
+	if err != nil {
+		t.Fatalf("DirUsage() error = %v", err)
+	}
+	if size != 8 {
+		t.Errorf("DirUsage() size = %v, want %v", size, 8)
+	}
+	if modified.IsZero() {
+		t.Errorf("DirUsage() modified should not be zero")
+	}
+
+	if _, _, err := DirUsage(filepath.Join(dir, "missing")); err == nil {
+		t.Errorf("DirUsage() expected error for missing directory")
+	}
+}
+
 func TestListFiles(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -199,6 +227,48 @@ func TestListFiles(t *testing.T) {
 	}
 }
 
+func TestListFilesRecursive(t *testing.T) {
+	tests := []struct {
+		name          string
+		includeHidden bool
+		want          int
+	}{
+		{
+			name:          "exclude hidden files and directories",
+			includeHidden: false,
+			want:          2, // Non-hidden files, including nested
+		},
+		{
+			name:          "include hidden files and directories",
+			includeHidden: true,
+			want:          4, // All files including hidden and nested
+		},
+	}
+
+	dir := t.TempDir()
+	for _, file := range []string{"root.json", "nested/nested.json", ".hidden.txt", ".hidden-dir/ignored.txt"} {
+		path := filepath.Join(dir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ListFilesRecursive(dir, tt.includeHidden)
+			if err != nil {
+				t.Fatalf("ListFilesRecursive() error = %v", err)
+			}
+			if len(got) != tt.want {
+				t.Errorf("ListFilesRecursive() = got %v files, want %v files", len(got), tt.want)
+			}
+		})
+	}
+}
+
 func TestReadFile(t *testing.T) {
 	tests := []struct {
 		name     string
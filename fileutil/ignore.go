@@ -0,0 +1,233 @@
+package fileutil
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// IgnoreFileName is the name of the file in a config dir listing the
+// files to exclude, using the same syntax as a .gitignore file.
+const IgnoreFileName = ".imposterignore"
+
+// defaultIgnorePatterns are applied before those in the ignore file, so
+// the ignore file can re-include them with a negated pattern.
+var defaultIgnorePatterns = []string{
+	".git/",
+	"node_modules/",
+	".imposter/",
+
+	// editor swap, backup and lock files
+	"*.swp",
+	"*.swo",
+	"*.swx",
+	"4913",
+	"*~",
+	".#*",
+	`\#*#`,
+}
+
+// IgnoreRules holds the patterns from an ignore file. A nil *IgnoreRules
+// ignores nothing.
+type IgnoreRules struct {
+	patterns []ignorePattern
+}
+
+type ignorePattern struct {
+	regex   *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// LoadIgnoreRules returns the default rules, followed by those from the
+// ignore file in dir, if present.
+func LoadIgnoreRules(dir string) (*IgnoreRules, error) {
+	file, err := os.Open(filepath.Join(dir, IgnoreFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ParseIgnoreRules(defaultIgnorePatterns), nil
+		}
+		return nil, fmt.Errorf("failed to read ignore file in: %s: %v", dir, err)
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ignore file in: %s: %v", dir, err)
+	}
+	return ParseIgnoreRules(append(append([]string{}, defaultIgnorePatterns...), lines...)), nil
+}
+
+// ParseIgnoreRules returns the rules for the lines of an ignore file.
+func ParseIgnoreRules(lines []string) *IgnoreRules {
+	rules := &IgnoreRules{}
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		pattern := ignorePattern{}
+		if strings.HasPrefix(line, "!") {
+			pattern.negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, `\#`) || strings.HasPrefix(line, `\!`) {
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			pattern.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		// patterns containing a separator, other than at the end, are
+		// relative to the config dir, otherwise they match at any depth
+		anchored := strings.Contains(line, "/")
+		line = strings.TrimPrefix(line, "/")
+		var expr string
+		if anchored {
+			expr = "^" + globToRegex(line) + "$"
+		} else {
+			expr = "^(?:.*/)?" + globToRegex(line) + "$"
+		}
+		regex, err := regexp.Compile(expr)
+		if err != nil {
+			logger.Warnf("skipping invalid ignore pattern: %s: %v", line, err)
+			continue
+		}
+		pattern.regex = regex
+		rules.patterns = append(rules.patterns, pattern)
+	}
+	return rules
+}
+
+// globToRegex returns the regular expression for a gitignore glob, in
+// which '**' matches any number of directories.
+func globToRegex(glob string) string {
+	var expr strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case c == '*':
+			expr.WriteString("[^/]*")
+		case c == '?':
+			expr.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				expr.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			expr.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case c == '\\' && i+1 < len(glob):
+			i++
+			expr.WriteString(regexp.QuoteMeta(string(glob[i])))
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return expr.String()
+}
+
+// Ignored returns true if the path, which is slash-separated and relative
+// to the config dir, or any of its parent directories, is ignored.
+func (r *IgnoreRules) Ignored(relPath string, isDir bool) bool {
+	if r == nil || len(r.patterns) == 0 {
+		return false
+	}
+	relPath = strings.TrimPrefix(path.Clean(relPath), "./")
+	parts := strings.Split(relPath, "/")
+	for i := 1; i < len(parts); i++ {
+		if r.matches(strings.Join(parts[:i], "/"), true) {
+			return true
+		}
+	}
+	return r.matches(relPath, isDir)
+}
+
+// matches returns true if the last pattern matching the path excludes it.
+func (r *IgnoreRules) matches(relPath string, isDir bool) bool {
+	ignored := false
+	for _, pattern := range r.patterns {
+		if pattern.dirOnly && !isDir {
+			continue
+		}
+		if pattern.regex.MatchString(relPath) {
+			ignored = !pattern.negate
+		}
+	}
+	return ignored
+}
+
+// Filter returns the files, which are in dir, that are not ignored.
+func (r *IgnoreRules) Filter(dir string, files []string) []string {
+	var kept []string
+	for _, file := range files {
+		relPath, err := filepath.Rel(dir, file)
+		if err == nil && r.Ignored(filepath.ToSlash(relPath), false) {
+			logger.Tracef("ignoring file: %s", file)
+			continue
+		}
+		kept = append(kept, file)
+	}
+	return kept
+}
+
+// ListProjectFiles returns the files in the config dir and its
+// subdirectories, skipping hidden files and directories, and those
+// excluded by the default ignore rules or the ignore file in the config dir.
+func ListProjectFiles(dir string) ([]string, error) {
+	rules, err := LoadIgnoreRules(dir)
+	if err != nil {
+		return nil, err
+	}
+	logger.Tracef("listing project files in: %s", dir)
+
+	var files []string
+	err = filepath.WalkDir(dir, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if file == dir {
+			return nil
+		}
+		relPath, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(d.Name(), ".") || rules.Ignored(filepath.ToSlash(relPath), d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			files = append(files, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list directory contents: %s: %s", dir, err)
+	}
+	return files, nil
+}
@@ -0,0 +1,186 @@
+package fileutil
+
+import (
+	"github.com/radovskyb/watcher"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestIgnoreRules_Ignored(t *testing.T) {
+	rules := ParseIgnoreRules([]string{
+		"# comment",
+		"",
+		"*.log",
+		"!keep.log",
+		"build/",
+		"/root-only.txt",
+		"docs/*.md",
+		"**/tmp",
+		"recordings/**/*.bak",
+		"data[0-9].json",
+	})
+
+	tests := []struct {
+		relPath string
+		isDir   bool
+		want    bool
+	}{
+		{relPath: "server.log", want: true},
+		{relPath: "nested/deep/server.log", want: true},
+		{relPath: "keep.log", want: false},
+		{relPath: "build", isDir: true, want: true},
+		{relPath: "build", isDir: false, want: false},
+		{relPath: "build/output.json", want: true},
+		{relPath: "root-only.txt", want: true},
+		{relPath: "nested/root-only.txt", want: false},
+		{relPath: "docs/readme.md", want: true},
+		{relPath: "docs/nested/readme.md", want: false},
+		{relPath: "a/b/tmp", isDir: true, want: true},
+		{relPath: "a/b/tmp/file.json", want: true},
+		{relPath: "recordings/x/y/z.bak", want: true},
+		{relPath: "recordings/z.bak", want: true},
+		{relPath: "data1.json", want: true},
+		{relPath: "dataX.json", want: false},
+		{relPath: "imposter-config.yaml", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.relPath, func(t *testing.T) {
+			if got := rules.Ignored(tt.relPath, tt.isDir); got != tt.want {
+				t.Errorf("Ignored(%s, %v) = %v, want %v", tt.relPath, tt.isDir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIgnoreRules_Nil(t *testing.T) {
+	var rules *IgnoreRules
+	if rules.Ignored("anything", false) {
+		t.Errorf("nil rules should not ignore files")
+	}
+}
+
+func TestListProjectFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		IgnoreFileName:                "*.bak\nscratch/\n",
+		"imposter-config.yaml":        "",
+		"responses/nested/data.json":  "",
+		"responses/nested/data.bak":   "",
+		"scratch/notes.txt":           "",
+		".hidden/secret.txt":          "",
+		"recordings/recorded-1.json":  "",
+		"recordings/.recording.state": "",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := ListProjectFiles(dir)
+	if err != nil {
+		t.Fatalf("ListProjectFiles() error = %v", err)
+	}
+	var relPaths []string
+	for _, file := range got {
+		relPath, _ := filepath.Rel(dir, file)
+		relPaths = append(relPaths, filepath.ToSlash(relPath))
+	}
+	sort.Strings(relPaths)
+	want := []string{"imposter-config.yaml", "recordings/recorded-1.json", "responses/nested/data.json"}
+	if strings.Join(relPaths, ",") != strings.Join(want, ",") {
+		t.Errorf("ListProjectFiles() = %v, want %v", relPaths, want)
+	}
+}
+
+func TestLoadIgnoreRules_Defaults(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, IgnoreFileName), []byte("!node_modules/\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := LoadIgnoreRules(dir)
+	if err != nil {
+		t.Fatalf("LoadIgnoreRules() error = %v", err)
+	}
+
+	tests := []struct {
+		relPath string
+		isDir   bool
+		want    bool
+	}{
+		{relPath: ".git", isDir: true, want: true},
+		{relPath: ".imposter/workspaces.json", want: true},
+		{relPath: "nested/.imposter", isDir: true, want: true},
+		{relPath: ".imposter-config.yaml.swp", want: true},
+		{relPath: "imposter-config.yaml~", want: true},
+		{relPath: ".#imposter-config.yaml", want: true},
+		{relPath: "#imposter-config.yaml#", want: true},
+		{relPath: "4913", want: true},
+		{relPath: "node_modules/index.js", want: false},
+		{relPath: "imposter-config.yaml", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.relPath, func(t *testing.T) {
+			if got := rules.Ignored(tt.relPath, tt.isDir); got != tt.want {
+				t.Errorf("Ignored(%s, %v) = %v, want %v", tt.relPath, tt.isDir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIgnoreRules_Filter(t *testing.T) {
+	dir := t.TempDir()
+	rules := ParseIgnoreRules([]string{"*.bak"})
+	files := []string{filepath.Join(dir, "a.json"), filepath.Join(dir, "a.bak")}
+
+	got := rules.Filter(dir, files)
+	if len(got) != 1 || got[0] != files[0] {
+		t.Errorf("Filter() = %v, want %v", got, files[:1])
+	}
+}
+
+func TestIgnoreFilterHook(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"imposter-config.yaml", ".imposter-config.yaml.swp"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	outside := filepath.Join(t.TempDir(), "plugin.swp")
+	if err := os.WriteFile(outside, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := LoadIgnoreRules(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hook := ignoreFilterHook(dir, rules)
+
+	tests := []struct {
+		path string
+		want error
+	}{
+		{path: dir, want: nil},
+		{path: filepath.Join(dir, "imposter-config.yaml"), want: nil},
+		{path: filepath.Join(dir, ".imposter-config.yaml.swp"), want: watcher.ErrSkip},
+		{path: outside, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(filepath.Base(tt.path), func(t *testing.T) {
+			info, err := os.Stat(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := hook(info, tt.path); got != tt.want {
+				t.Errorf("hook(%s) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"gatehill.io/imposter/fileutil"
 	"gatehill.io/imposter/logging"
 	"gatehill.io/imposter/openapi"
+	"gatehill.io/imposter/protobuf"
+	"gatehill.io/imposter/wsdl"
 	"os"
 	"path"
 	"path/filepath"
@@ -31,19 +33,23 @@ type ConfigGenerationOptions struct {
 	ScriptEngine   ScriptEngine
 	ScriptFileName string
 	SpecFilePath   string
+	WsdlFilePath   string
 }
 
 var logger = logging.GetLogger()
 
+// specPlugins are the plugins that generate mocks from specification files,
+// in the order in which they are scaffolded.
+var specPlugins = []string{"openapi", "grpc", "soap"}
+
 func Create(configDir string, generateResources bool, forceOverwrite bool, scriptEngine ScriptEngine, requireOpenApi bool) {
-	openApiSpecs := openapi.DiscoverOpenApiSpecs(configDir)
-	logger.Infof("found %d OpenAPI spec(s)", len(openApiSpecs))
+	specFiles := DiscoverSpecFiles(configDir)
 
-	if len(openApiSpecs) > 0 {
-		logger.Tracef("using openapi plugin")
-		for _, openApiSpec := range openApiSpecs {
-			scriptFileName := getScriptFileName(openApiSpec, scriptEngine, forceOverwrite)
-			writeOpenapiMockConfig(openApiSpec, generateResources, forceOverwrite, scriptEngine, scriptFileName)
+	if len(specFiles) > 0 {
+		for _, pluginName := range specPlugins {
+			if files := specFiles[pluginName]; len(files) > 0 {
+				CreateFromSpecs(pluginName, files, generateResources, forceOverwrite, scriptEngine)
+			}
 		}
 	} else if !requireOpenApi {
 		logger.Infof("falling back to rest plugin")
@@ -52,7 +58,59 @@ func Create(configDir string, generateResources bool, forceOverwrite bool, scrip
 		scriptFileName := getScriptFileName(syntheticMockPath, scriptEngine, forceOverwrite)
 		writeRestMockConfig(syntheticMockPath, responseFilePath, generateResources, forceOverwrite, scriptEngine, scriptFileName)
 	} else {
-		logger.Fatalf("no OpenAPI specs found in: %s", configDir)
+		logger.Fatalf("no OpenAPI, protobuf or WSDL specs found in: %s", configDir)
+	}
+}
+
+// DiscoverSpecFiles finds the specification files within the given
+// directory, keyed by the name of the plugin that supports them.
+// Plugins without any specification files are omitted.
+func DiscoverSpecFiles(configDir string) map[string][]string {
+	openApiSpecs := openapi.DiscoverOpenApiSpecs(configDir)
+	logger.Infof("found %d OpenAPI spec(s)", len(openApiSpecs))
+
+	protoFiles := protobuf.DiscoverProtoFiles(configDir)
+	logger.Infof("found %d protobuf file(s)", len(protoFiles))
+
+	wsdlFiles := wsdl.DiscoverWsdlFiles(configDir)
+	logger.Infof("found %d WSDL file(s)", len(wsdlFiles))
+
+	specFiles := make(map[string][]string)
+	for pluginName, files := range map[string][]string{"openapi": openApiSpecs, "grpc": protoFiles, "soap": wsdlFiles} {
+		if len(files) > 0 {
+			specFiles[pluginName] = files
+		}
+	}
+	return specFiles
+}
+
+// PreferredPlugin returns the first plugin with specification files,
+// or the rest plugin if there are none.
+func PreferredPlugin(specFiles map[string][]string) string {
+	for _, pluginName := range specPlugins {
+		if len(specFiles[pluginName]) > 0 {
+			return pluginName
+		}
+	}
+	return "rest"
+}
+
+// CreateFromSpecs writes a mock configuration for each of the given
+// specification files, using the named plugin.
+func CreateFromSpecs(pluginName string, specFiles []string, generateResources bool, forceOverwrite bool, scriptEngine ScriptEngine) {
+	logger.Tracef("using %s plugin", pluginName)
+	for _, specFile := range specFiles {
+		scriptFileName := getScriptFileName(specFile, scriptEngine, forceOverwrite)
+		switch pluginName {
+		case "openapi":
+			writeOpenapiMockConfig(specFile, generateResources, forceOverwrite, scriptEngine, scriptFileName)
+		case "grpc":
+			writeGrpcMockConfig(specFile, generateResources, forceOverwrite, scriptEngine, scriptFileName)
+		case "soap":
+			writeSoapMockConfig(specFile, generateResources, forceOverwrite, scriptEngine, scriptFileName)
+		default:
+			logger.Fatalf("unsupported plugin for specification files: %s", pluginName)
+		}
 	}
 }
 
@@ -63,6 +121,9 @@ func GenerateConfig(options ConfigGenerationOptions, resources []Resource) []byt
 	if options.SpecFilePath != "" {
 		pluginConfig.SpecFile = filepath.Base(options.SpecFilePath)
 	}
+	if options.WsdlFilePath != "" {
+		pluginConfig.WsdlFile = filepath.Base(options.WsdlFilePath)
+	}
 	if len(resources) > 0 {
 		pluginConfig.Resources = resources
 	} else {
@@ -0,0 +1,88 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impostermodel
+
+import (
+	"gatehill.io/imposter/fileutil"
+	"gatehill.io/imposter/protobuf"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func writeGrpcMockConfig(protoFilePath string, generateResources bool, forceOverwrite bool, scriptEngine ScriptEngine, scriptFileName string) {
+	var resources []Resource
+	if generateResources {
+		resources = buildGrpcResources(protoFilePath, forceOverwrite, scriptEngine, scriptFileName)
+	} else {
+		logger.Debug("skipping resource generation")
+	}
+	options := ConfigGenerationOptions{
+		PluginName:     "grpc",
+		ScriptEngine:   scriptEngine,
+		ScriptFileName: scriptFileName,
+		SpecFilePath:   protoFilePath,
+	}
+	writeMockConfigAdjacent(protoFilePath, resources, forceOverwrite, options)
+}
+
+// buildGrpcResources generates a resource for each service method in the
+// protobuf file, writing a sample response file alongside it.
+func buildGrpcResources(protoFilePath string, forceOverwrite bool, scriptEngine ScriptEngine, scriptFileName string) []Resource {
+	files, err := protobuf.Parse([]string{protoFilePath}, nil)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	var resources []Resource
+	for _, method := range protobuf.ListMethods(files) {
+		responseFile := writeGrpcResponseFile(protoFilePath, method, forceOverwrite)
+		resource := Resource{
+			Path:   method.Path,
+			Method: "POST",
+			Response: &ResponseConfig{
+				StatusCode: 200,
+				StaticFile: responseFile,
+			},
+		}
+		if IsScriptEngineEnabled(scriptEngine) {
+			resource.Response.ScriptFile = scriptFileName
+		}
+		resources = append(resources, resource)
+	}
+	logger.Debugf("generated %d resources from protobuf file", len(resources))
+	return resources
+}
+
+// writeGrpcResponseFile writes a sample JSON response for the method
+// and returns its file name, relative to the protobuf file.
+func writeGrpcResponseFile(protoFilePath string, method protobuf.Method, forceOverwrite bool) string {
+	serviceName := method.Service[strings.LastIndex(method.Service, ".")+1:]
+	responseFileName := serviceName + "-" + method.Name + "-response.json"
+	responseFilePath := filepath.Join(filepath.Dir(protoFilePath), responseFileName)
+	fileutil.MustNotExist(responseFilePath, forceOverwrite)
+
+	sample, err := protobuf.GenerateSampleJson(method.Output)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	if err = os.WriteFile(responseFilePath, append(sample, '\n'), 0644); err != nil {
+		logger.Fatalf("failed to write response file: %s: %s", responseFilePath, err)
+	}
+	logger.Debugf("wrote response file: %v", responseFilePath)
+	return responseFileName
+}
@@ -31,8 +31,11 @@ type RequestBody struct {
 }
 
 type Resource struct {
-	Path           string             `json:"path"`
-	Method         string             `json:"method"`
+	Path           string             `json:"path,omitempty"`
+	Method         string             `json:"method,omitempty"`
+	Binding        string             `json:"binding,omitempty"`
+	Operation      string             `json:"operation,omitempty"`
+	SoapAction     string             `json:"soapAction,omitempty"`
 	QueryParams    *map[string]string `json:"queryParams,omitempty"`
 	RequestBody    *RequestBody       `json:"requestBody,omitempty"`
 	RequestHeaders *map[string]string `json:"requestHeaders,omitempty"`
@@ -42,6 +45,7 @@ type Resource struct {
 type PluginConfig struct {
 	Plugin    string          `json:"plugin"`
 	SpecFile  string          `json:"specFile,omitempty"`
+	WsdlFile  string          `json:"wsdlFile,omitempty"`
 	Response  *ResponseConfig `json:"response,omitempty"`
 	Resources []Resource      `json:"resources,omitempty"`
 }
@@ -17,23 +17,58 @@ limitations under the License.
 package impostermodel
 
 import (
+	"fmt"
+	"gatehill.io/imposter/fileutil"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 )
 
+// RestResource describes a resource in a rest mock created
+// from user input, rather than from a specification.
+type RestResource struct {
+	Path        string
+	Method      string
+	StatusCode  int
+	ContentType string
+}
+
+// sampleResponseBodies are written as the response files of rest
+// resources, keyed by content type.
+var sampleResponseBodies = map[string]string{
+	"application/json": "{ \"hello\": \"world\" }\n",
+	"application/xml":  "<hello>world</hello>\n",
+	"text/xml":         "<hello>world</hello>\n",
+	"text/html":        "<html><body><h1>Hello world</h1></body></html>\n",
+	"text/plain":       "Hello world\n",
+}
+
+var contentTypeExtensions = map[string]string{
+	"application/json": ".json",
+	"application/xml":  ".xml",
+	"text/xml":         ".xml",
+	"text/html":        ".html",
+	"text/plain":       ".txt",
+}
+
 // generateRestMockFiles creates files for a rest mock, and returns
 // the full path to the response file.
 func generateRestMockFiles(configDir string) (readmeFilePath string, responseFilePath string) {
-	return generateReadmeFile(configDir), generateResponseFile(configDir)
+	return generateReadmeFile(configDir, []string{"response.json", "mock-config.yaml"}), generateResponseFile(configDir)
 }
 
-func generateReadmeFile(configDir string) string {
+func generateReadmeFile(configDir string, exampleFiles []string) string {
 	readmeFile := filepath.Join(configDir, "README.md")
 	configFile, err := os.Create(readmeFile)
 	if err != nil {
 		logger.Fatal(err)
 	}
 	defer configFile.Close()
+	var fileList string
+	for _, exampleFile := range exampleFiles {
+		fileList += "- " + exampleFile + "\n"
+	}
 	_, err = configFile.WriteString(`Imposter REST mock
 
 Start the mock with:
@@ -44,9 +79,7 @@ The mock will be accessible at: http://localhost:8080
 
 Example files:
 
-- response.json
-- mock-config.yaml
-`)
+` + fileList)
 	if err != nil {
 		logger.Fatalf("failed to write readme file: %s: %s", readmeFile, err)
 	}
@@ -98,3 +131,71 @@ func buildRestResources(responseFilePath string, scriptEngine ScriptEngine, scri
 	}
 	return []Resource{resource}
 }
+
+// CreateRestMock writes a rest mock configuration containing the given
+// resources, along with a response file for each resource and a README.
+func CreateRestMock(configDir string, restResources []RestResource, forceOverwrite bool, scriptEngine ScriptEngine) {
+	syntheticMockPath := path.Join(configDir, "mock.txt")
+	scriptFileName := getScriptFileName(syntheticMockPath, scriptEngine, forceOverwrite)
+
+	var resources []Resource
+	var exampleFiles []string
+	for _, restResource := range restResources {
+		resource := Resource{
+			Path:   restResource.Path,
+			Method: restResource.Method,
+			Response: &ResponseConfig{
+				StatusCode: restResource.StatusCode,
+			},
+		}
+		if restResource.ContentType != "" && restResource.StatusCode != 204 {
+			responseFileName := writeRestResponseFile(configDir, restResource, forceOverwrite)
+			resource.Response.StaticFile = responseFileName
+			resource.Response.Headers = &map[string]string{
+				"Content-Type": restResource.ContentType,
+			}
+			exampleFiles = append(exampleFiles, responseFileName)
+		}
+		if IsScriptEngineEnabled(scriptEngine) {
+			resource.Response.ScriptFile = scriptFileName
+		}
+		resources = append(resources, resource)
+	}
+	if scriptFileName != "" {
+		exampleFiles = append(exampleFiles, scriptFileName)
+	}
+	generateReadmeFile(configDir, append(exampleFiles, "mock-config.yaml"))
+
+	options := ConfigGenerationOptions{
+		PluginName:     "rest",
+		ScriptEngine:   scriptEngine,
+		ScriptFileName: scriptFileName,
+	}
+	writeMockConfigAdjacent(syntheticMockPath, resources, forceOverwrite, options)
+}
+
+// writeRestResponseFile writes a sample response file for the resource,
+// named after its method and path, and returns the file name.
+func writeRestResponseFile(configDir string, resource RestResource, forceOverwrite bool) string {
+	pathName := strings.Trim(strings.NewReplacer("{", "", "}", "").Replace(resource.Path), "/")
+	if pathName == "" {
+		pathName = "index"
+	}
+	extension, found := contentTypeExtensions[resource.ContentType]
+	if !found {
+		extension = ".txt"
+	}
+	responseFileName := fmt.Sprintf("%s-%s-response%s", resource.Method, strings.ReplaceAll(pathName, "/", "_"), extension)
+	responseFilePath := filepath.Join(configDir, responseFileName)
+	fileutil.MustNotExist(responseFilePath, forceOverwrite)
+
+	body, found := sampleResponseBodies[resource.ContentType]
+	if !found {
+		body = sampleResponseBodies["text/plain"]
+	}
+	if err := os.WriteFile(responseFilePath, []byte(body), 0644); err != nil {
+		logger.Fatalf("failed to write response file: %s: %s", responseFilePath, err)
+	}
+	logger.Debugf("wrote response file: %v", responseFilePath)
+	return responseFileName
+}
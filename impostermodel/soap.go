@@ -0,0 +1,87 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impostermodel
+
+import (
+	"gatehill.io/imposter/fileutil"
+	"gatehill.io/imposter/wsdl"
+	"os"
+	"path/filepath"
+)
+
+func writeSoapMockConfig(wsdlFilePath string, generateResources bool, forceOverwrite bool, scriptEngine ScriptEngine, scriptFileName string) {
+	var resources []Resource
+	if generateResources {
+		resources = buildSoapResources(wsdlFilePath, forceOverwrite, scriptEngine, scriptFileName)
+	} else {
+		logger.Debug("skipping resource generation")
+	}
+	options := ConfigGenerationOptions{
+		PluginName:     "soap",
+		ScriptEngine:   scriptEngine,
+		ScriptFileName: scriptFileName,
+		WsdlFilePath:   wsdlFilePath,
+	}
+	writeMockConfigAdjacent(wsdlFilePath, resources, forceOverwrite, options)
+}
+
+// buildSoapResources generates a resource for each operation of each SOAP
+// binding in the WSDL file, writing a sample response envelope alongside it.
+func buildSoapResources(wsdlFilePath string, forceOverwrite bool, scriptEngine ScriptEngine, scriptFileName string) []Resource {
+	doc, err := wsdl.Parse(wsdlFilePath)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	var resources []Resource
+	for _, op := range doc.Operations() {
+		responseFile := writeSoapResponseFile(wsdlFilePath, doc, op, forceOverwrite)
+		resource := Resource{
+			Binding:    op.Binding,
+			Operation:  op.Name,
+			SoapAction: op.SoapAction,
+			Response: &ResponseConfig{
+				StatusCode: 200,
+				StaticFile: responseFile,
+			},
+		}
+		if IsScriptEngineEnabled(scriptEngine) {
+			resource.Response.ScriptFile = scriptFileName
+		}
+		resources = append(resources, resource)
+	}
+	logger.Debugf("generated %d resources from WSDL", len(resources))
+	return resources
+}
+
+// writeSoapResponseFile writes a sample response envelope for the operation
+// and returns its file name, relative to the WSDL file.
+func writeSoapResponseFile(wsdlFilePath string, doc *wsdl.Document, op wsdl.Operation, forceOverwrite bool) string {
+	responseFileName := op.Binding + "-" + op.Name + "-response.xml"
+	responseFilePath := filepath.Join(filepath.Dir(wsdlFilePath), responseFileName)
+	fileutil.MustNotExist(responseFilePath, forceOverwrite)
+
+	sample, err := doc.GenerateSampleResponse(op)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	if err = os.WriteFile(responseFilePath, sample, 0644); err != nil {
+		logger.Fatalf("failed to write response file: %s: %s", responseFilePath, err)
+	}
+	logger.Debugf("wrote response file: %v", responseFilePath)
+	return responseFileName
+}
@@ -0,0 +1,144 @@
+package inspect
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Entry describes a request to a mock and its response.
+type Entry struct {
+	Time   time.Time `json:"time"`
+	Method string    `json:"method"`
+	Path   string    `json:"path"`
+	Query  string    `json:"query,omitempty"`
+
+	// Status is zero if no response was sent.
+	Status       int   `json:"status"`
+	LatencyMs    int64 `json:"latencyMs"`
+	RequestSize  int   `json:"requestSize"`
+	ResponseSize int   `json:"responseSize"`
+
+	// Resource describes the configured resource matching the request,
+	// or is empty if none matched.
+	Resource string `json:"resource,omitempty"`
+}
+
+// Filter selects the entries to show. Empty fields match all entries.
+type Filter struct {
+	Method string
+
+	// Path matches entries whose path contains it.
+	Path string
+
+	// Status matches an exact status code, such as `404`, or
+	// a class of status codes, such as `4xx`.
+	Status string
+
+	// Unmatched only matches entries without a matching resource.
+	Unmatched bool
+}
+
+// ParseFilter parses a filter expression, made up of comma or space
+// separated terms, such as `method=GET,status=4xx,path=/pets,unmatched`.
+func ParseFilter(expr string) (Filter, error) {
+	var filter Filter
+	terms := strings.FieldsFunc(expr, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+	for _, term := range terms {
+		key, value, _ := strings.Cut(term, "=")
+		switch strings.ToLower(key) {
+		case "method":
+			filter.Method = strings.ToUpper(value)
+		case "path":
+			filter.Path = value
+		case "status":
+			if !isValidStatusFilter(value) {
+				return Filter{}, fmt.Errorf("invalid status filter: %s - must be a status code, such as 404, or a class, such as 4xx", value)
+			}
+			filter.Status = strings.ToLower(value)
+		case "unmatched":
+			filter.Unmatched = true
+		default:
+			return Filter{}, fmt.Errorf("invalid filter term: %s - valid terms are method, path, status and unmatched", term)
+		}
+	}
+	return filter, nil
+}
+
+func isValidStatusFilter(value string) bool {
+	if len(value) != 3 {
+		return false
+	}
+	if strings.HasSuffix(strings.ToLower(value), "xx") {
+		return value[0] >= '1' && value[0] <= '5'
+	}
+	_, err := strconv.Atoi(value)
+	return err == nil
+}
+
+// Matches returns true if the entry satisfies the filter.
+func (f Filter) Matches(entry Entry) bool {
+	if f.Method != "" && f.Method != entry.Method {
+		return false
+	}
+	if f.Path != "" && !strings.Contains(entry.Path, f.Path) {
+		return false
+	}
+	if f.Status != "" {
+		status := strconv.Itoa(entry.Status)
+		if strings.HasSuffix(f.Status, "xx") {
+			if status[0] != f.Status[0] {
+				return false
+			}
+		} else if f.Status != status {
+			return false
+		}
+	}
+	if f.Unmatched && entry.Resource != "" {
+		return false
+	}
+	return true
+}
+
+// Printer writes entries as rows of a table.
+type Printer struct {
+	out           io.Writer
+	headerPrinted bool
+}
+
+func NewPrinter(out io.Writer) *Printer {
+	return &Printer{out: out}
+}
+
+const rowFormat = "%-8s  %-7s  %-40s  %6s  %8s  %s\n"
+
+func (p *Printer) Print(entry Entry) {
+	if !p.headerPrinted {
+		_, _ = fmt.Fprintf(p.out, rowFormat, "TIME", "METHOD", "PATH", "STATUS", "LATENCY", "RESOURCE")
+		p.headerPrinted = true
+	}
+	path := entry.Path
+	if entry.Query != "" {
+		path += "?" + entry.Query
+	}
+	status := "-"
+	if entry.Status != 0 {
+		status = strconv.Itoa(entry.Status)
+	}
+	resource := entry.Resource
+	if resource == "" {
+		resource = "(no matching resource)"
+	}
+	_, _ = fmt.Fprintf(p.out, rowFormat,
+		entry.Time.Format("15:04:05"),
+		entry.Method,
+		path,
+		status,
+		fmt.Sprintf("%dms", entry.LatencyMs),
+		resource,
+	)
+}
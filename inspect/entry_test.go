@@ -0,0 +1,65 @@
+package inspect
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestParseFilter(t *testing.T) {
+	filter, err := ParseFilter("method=get, status=4xx path=/pets,unmatched")
+	require.NoError(t, err)
+	require.Equal(t, Filter{Method: "GET", Path: "/pets", Status: "4xx", Unmatched: true}, filter)
+
+	filter, err = ParseFilter("")
+	require.NoError(t, err)
+	require.Equal(t, Filter{}, filter)
+
+	_, err = ParseFilter("status=4x")
+	require.ErrorContains(t, err, "invalid status filter")
+
+	_, err = ParseFilter("colour=blue")
+	require.ErrorContains(t, err, "invalid filter term")
+}
+
+func TestFilter_Matches(t *testing.T) {
+	entry := Entry{Method: "GET", Path: "/pets/1", Status: 404}
+	tests := []struct {
+		name   string
+		filter Filter
+		want   bool
+	}{
+		{name: "empty filter", filter: Filter{}, want: true},
+		{name: "method", filter: Filter{Method: "GET"}, want: true},
+		{name: "other method", filter: Filter{Method: "POST"}, want: false},
+		{name: "path", filter: Filter{Path: "/pets"}, want: true},
+		{name: "other path", filter: Filter{Path: "/orders"}, want: false},
+		{name: "status", filter: Filter{Status: "404"}, want: true},
+		{name: "status class", filter: Filter{Status: "4xx"}, want: true},
+		{name: "other status class", filter: Filter{Status: "2xx"}, want: false},
+		{name: "unmatched", filter: Filter{Unmatched: true}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.filter.Matches(entry))
+		})
+	}
+
+	entry.Resource = "pets-config.yaml: GET /pets/{id}"
+	require.False(t, Filter{Unmatched: true}.Matches(entry))
+}
+
+func TestPrinter_Print(t *testing.T) {
+	var out bytes.Buffer
+	printer := NewPrinter(&out)
+	printer.Print(Entry{Time: time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC), Method: "GET", Path: "/pets", Query: "limit=1", Status: 200, LatencyMs: 12})
+	printer.Print(Entry{Time: time.Date(2026, 1, 2, 15, 4, 6, 0, time.UTC), Method: "POST", Path: "/pets", Status: 201, Resource: "pets-config.yaml: POST /pets"})
+
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	require.Len(t, lines, 3, "header should be printed once")
+	require.Contains(t, string(lines[0]), "RESOURCE")
+	require.Contains(t, string(lines[1]), "/pets?limit=1")
+	require.Contains(t, string(lines[1]), "(no matching resource)")
+	require.Contains(t, string(lines[2]), "pets-config.yaml: POST /pets")
+}
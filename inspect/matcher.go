@@ -0,0 +1,190 @@
+package inspect
+
+import (
+	"fmt"
+	"gatehill.io/imposter/stringutil"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sigs.k8s.io/yaml"
+	"strings"
+	"sync"
+	"time"
+)
+
+const matcherReloadInterval = time.Second
+
+var configFileSuffixes = []string{"-config.yaml", "-config.yml", "-config.json"}
+
+type configFile struct {
+	Plugin    string     `json:"plugin"`
+	BasePath  string     `json:"basePath,omitempty"`
+	Path      string     `json:"path,omitempty"`
+	Method    string     `json:"method,omitempty"`
+	Resources []resource `json:"resources,omitempty"`
+}
+
+type resource struct {
+	Path   string `json:"path,omitempty"`
+	Method string `json:"method,omitempty"`
+}
+
+type resourceRef struct {
+	configFile string
+	plugin     string
+	method     string
+	path       string
+}
+
+// Matcher finds the resource in the mock configuration that a request
+// is expected to match. It approximates the matching performed by the
+// engine, using only the method and path of each resource.
+type Matcher struct {
+	configDir string
+	recursive bool
+
+	lock       sync.Mutex
+	resources  []resourceRef
+	specs      []resourceRef
+	lastLoaded time.Time
+}
+
+func NewMatcher(configDir string, recursive bool) *Matcher {
+	return &Matcher{configDir: configDir, recursive: recursive}
+}
+
+// Match returns a description of the resource matching the request,
+// or the empty string if none matches. Resources with more literal path
+// segments are preferred over those with path parameters or wildcards,
+// then resources that specify the method over those that do not.
+//
+// If no resource matches, requests to which the engine did not respond
+// with a 404 status are attributed to a specification, if there is one.
+func (m *Matcher) Match(method string, path string, status int) string {
+	resources, specs := m.load()
+
+	var best *resourceRef
+	bestScore := -1
+	for i, r := range resources {
+		if r.method != "" && !strings.EqualFold(r.method, method) {
+			continue
+		}
+		score, ok := matchPath(r.path, path)
+		if !ok {
+			continue
+		}
+		// prefer resources that specify the method
+		score *= 2
+		if r.method != "" {
+			score++
+		}
+		if score > bestScore {
+			best = &resources[i]
+			bestScore = score
+		}
+	}
+	if best != nil {
+		resourceMethod := best.method
+		if resourceMethod == "" {
+			resourceMethod = "*"
+		}
+		return fmt.Sprintf("%s: %s %s", best.configFile, strings.ToUpper(resourceMethod), best.path)
+	}
+
+	// specification-driven plugins serve paths that are not listed
+	// as resources in the config file, but a 404 means none matched
+	if len(specs) > 0 && status != http.StatusNotFound {
+		return fmt.Sprintf("%s: %s specification", specs[0].configFile, specs[0].plugin)
+	}
+	return ""
+}
+
+func (m *Matcher) load() ([]resourceRef, []resourceRef) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if time.Since(m.lastLoaded) < matcherReloadInterval {
+		return m.resources, m.specs
+	}
+	m.resources, m.specs = loadResources(m.configDir, m.recursive)
+	m.lastLoaded = time.Now()
+	return m.resources, m.specs
+}
+
+func loadResources(configDir string, recursive bool) (resources []resourceRef, specs []resourceRef) {
+	_ = filepath.WalkDir(configDir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if filePath != configDir && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if stringutil.GetMatchingSuffix(d.Name(), configFileSuffixes) == "" {
+			return nil
+		}
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			logger.Debugf("failed to read config file: %s: %s", filePath, err)
+			return nil
+		}
+		var config configFile
+		if err = yaml.Unmarshal(data, &config); err != nil {
+			logger.Debugf("failed to parse config file: %s: %s", filePath, err)
+			return nil
+		}
+		relPath, _ := filepath.Rel(configDir, filePath)
+
+		all := config.Resources
+		if config.Path != "" {
+			all = append(all, resource{Path: config.Path, Method: config.Method})
+		}
+		for _, r := range all {
+			resources = append(resources, resourceRef{
+				configFile: relPath,
+				plugin:     config.Plugin,
+				method:     r.Method,
+				path:       joinPath(config.BasePath, r.Path),
+			})
+		}
+		if config.Plugin == "openapi" || config.Plugin == "soap" || config.Plugin == "grpc" {
+			specs = append(specs, resourceRef{configFile: relPath, plugin: config.Plugin})
+		}
+		return nil
+	})
+	return resources, specs
+}
+
+func joinPath(basePath string, path string) string {
+	if basePath == "" {
+		return path
+	}
+	return strings.TrimSuffix(basePath, "/") + "/" + strings.TrimPrefix(path, "/")
+}
+
+// matchPath returns true if the request path matches the resource path,
+// in which path parameters, such as `{id}`, match a single segment and
+// a trailing `*` matches the remainder of the path. The score is the
+// number of literal segments matched.
+func matchPath(resourcePath string, requestPath string) (score int, matched bool) {
+	resourceSegments := strings.Split(strings.Trim(resourcePath, "/"), "/")
+	requestSegments := strings.Split(strings.Trim(requestPath, "/"), "/")
+	for i, segment := range resourceSegments {
+		if segment == "*" && i == len(resourceSegments)-1 {
+			return score, true
+		}
+		if i >= len(requestSegments) {
+			return 0, false
+		}
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			continue
+		}
+		if segment != requestSegments[i] {
+			return 0, false
+		}
+		score++
+	}
+	return score, len(resourceSegments) == len(requestSegments)
+}
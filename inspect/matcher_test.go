@@ -0,0 +1,64 @@
+package inspect
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMatcher_Match(t *testing.T) {
+	configDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "pets-config.yaml"), []byte(`plugin: rest
+basePath: /api
+resources:
+  - path: /pets
+    method: GET
+  - path: /pets/{id}
+  - path: /pets/{id}
+    method: DELETE
+  - path: /pets/search
+    method: GET
+  - path: /files/*
+`), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(configDir, "nested"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "nested", "orders-config.yaml"), []byte(`plugin: rest
+path: /orders
+`), 0644))
+
+	tests := []struct {
+		name      string
+		recursive bool
+		method    string
+		path      string
+		want      string
+	}{
+		{name: "exact path", method: "GET", path: "/api/pets", want: "pets-config.yaml: GET /api/pets"},
+		{name: "method mismatch", method: "POST", path: "/api/pets", want: ""},
+		{name: "path parameter", method: "GET", path: "/api/pets/1", want: "pets-config.yaml: * /api/pets/{id}"},
+		{name: "prefer method", method: "DELETE", path: "/api/pets/1", want: "pets-config.yaml: DELETE /api/pets/{id}"},
+		{name: "prefer literal segment", method: "GET", path: "/api/pets/search", want: "pets-config.yaml: GET /api/pets/search"},
+		{name: "wildcard", method: "GET", path: "/api/files/a/b", want: "pets-config.yaml: * /api/files/*"},
+		{name: "no match", method: "GET", path: "/api/other", want: ""},
+		{name: "nested config not scanned", method: "GET", path: "/orders", want: ""},
+		{name: "nested config scanned", recursive: true, method: "GET", path: "/orders", want: filepath.Join("nested", "orders-config.yaml") + ": * /orders"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher := NewMatcher(configDir, tt.recursive)
+			require.Equal(t, tt.want, matcher.Match(tt.method, tt.path, http.StatusOK))
+		})
+	}
+}
+
+func TestMatcher_MatchSpecification(t *testing.T) {
+	configDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "petstore-config.yaml"), []byte(`plugin: openapi
+specFile: petstore.yaml
+`), 0644))
+
+	matcher := NewMatcher(configDir, false)
+	require.Equal(t, "petstore-config.yaml: openapi specification", matcher.Match("GET", "/pets", http.StatusOK))
+	require.Equal(t, "", matcher.Match("GET", "/unknown", http.StatusNotFound))
+}
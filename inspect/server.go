@@ -0,0 +1,130 @@
+package inspect
+
+import (
+	"gatehill.io/imposter/logging"
+	"gatehill.io/imposter/proxy"
+	"io"
+	"net/http"
+	"time"
+)
+
+var logger = logging.GetLogger()
+
+// Options control how requests are shown and recorded.
+type Options struct {
+	ConfigDir       string
+	RecursiveConfig bool
+	Filter          Filter
+
+	// SavePath is the path of a file to which entries are appended
+	// as JSON lines, in addition to the session log.
+	SavePath string
+}
+
+// Start begins recording the requests handled by next, printing an entry
+// for each request that satisfies the filter, and recording all entries to
+// the session log for the port on which the mock listens. It returns a
+// handler that records each exchange before passing it to next.
+func Start(port int, options Options, printer *Printer, next http.Handler) (http.Handler, error) {
+	session, err := CreateSession(port)
+	if err != nil {
+		return nil, err
+	}
+	writers := []*SessionWriter{session}
+	if options.SavePath != "" {
+		saved, err := OpenSessionFile(options.SavePath)
+		if err != nil {
+			return nil, err
+		}
+		writers = append(writers, saved)
+	}
+
+	entryC := make(chan Entry)
+	go func() {
+		for entry := range entryC {
+			for _, w := range writers {
+				if err := w.Write(entry); err != nil {
+					logger.Warnf("failed to record request: %s", err)
+				}
+			}
+			if options.Filter.Matches(entry) {
+				printer.Print(entry)
+			}
+		}
+	}()
+
+	logger.Infof("inspecting requests on port %d - session log: %s", port, session.Path())
+	return NewHandler(next, NewMatcher(options.ConfigDir, options.RecursiveConfig), entryC), nil
+}
+
+// NewHandler returns a handler that passes requests to next, sending an
+// entry for each exchange to entryC. WebSocket connections are passed
+// through without being recorded.
+func NewHandler(next http.Handler, matcher *Matcher, entryC chan Entry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if proxy.IsWebSocketUpgrade(req) {
+			next.ServeHTTP(w, req)
+			return
+		}
+		startTime := time.Now()
+		body := &countingReader{ReadCloser: req.Body}
+		req.Body = body
+		recorder := &recordingWriter{ResponseWriter: w}
+
+		// record the exchange even if the handler aborts the response
+		defer func() {
+			entryC <- Entry{
+				Time:         startTime,
+				Method:       req.Method,
+				Path:         req.URL.Path,
+				Query:        req.URL.RawQuery,
+				Status:       recorder.status,
+				LatencyMs:    time.Since(startTime).Milliseconds(),
+				RequestSize:  body.count,
+				ResponseSize: recorder.size,
+				Resource:     matcher.Match(req.Method, req.URL.Path, recorder.status),
+			}
+		}()
+		next.ServeHTTP(recorder, req)
+	})
+}
+
+type countingReader struct {
+	io.ReadCloser
+	count int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.count += n
+	return n, err
+}
+
+// recordingWriter captures the status and size of a response. The status
+// is zero if no response was sent, such as when the connection was closed.
+type recordingWriter struct {
+	http.ResponseWriter
+	status int
+	size   int
+}
+
+func (w *recordingWriter) WriteHeader(statusCode int) {
+	if w.status == 0 {
+		w.status = statusCode
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(data)
+	w.size += n
+	return n, err
+}
+
+// Unwrap allows http.ResponseController to reach the underlying writer.
+func (w *recordingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,163 @@
+package inspect
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"gatehill.io/imposter/library"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const sessionFileExtension = ".jsonl"
+const followInterval = 500 * time.Millisecond
+
+// SessionWriter appends entries to a file as JSON lines.
+type SessionWriter struct {
+	lock sync.Mutex
+	file *os.File
+}
+
+// CreateSession truncates the session log for the port, which is read
+// by `imposter tail`.
+func CreateSession(port int) (*SessionWriter, error) {
+	sessionDir, err := getSessionDir()
+	if err != nil {
+		return nil, err
+	}
+	sessionPath := filepath.Join(sessionDir, strconv.Itoa(port)+sessionFileExtension)
+	file, err := os.Create(sessionPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session log: %s: %v", sessionPath, err)
+	}
+	return &SessionWriter{file: file}, nil
+}
+
+// OpenSessionFile opens the file for appending entries.
+func OpenSessionFile(path string) (*SessionWriter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open session file: %s: %v", path, err)
+	}
+	return &SessionWriter{file: file}, nil
+}
+
+func (w *SessionWriter) Path() string {
+	return w.file.Name()
+}
+
+func (w *SessionWriter) Write(entry Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal entry: %v", err)
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	_, err = w.file.Write(append(line, '\n'))
+	return err
+}
+
+func getSessionDir() (string, error) {
+	return library.EnsureDirUsingConfig("inspect.dir", ".imposter/inspect")
+}
+
+// FindSession returns the path of the session log for the port, or the
+// most recently updated session log if port is zero.
+func FindSession(port int) (string, error) {
+	sessionDir, err := getSessionDir()
+	if err != nil {
+		return "", err
+	}
+	if port != 0 {
+		sessionPath := filepath.Join(sessionDir, strconv.Itoa(port)+sessionFileExtension)
+		if _, err := os.Stat(sessionPath); err != nil {
+			return "", fmt.Errorf("no session found for port %d - start the mock with 'imposter up --inspect'", port)
+		}
+		return sessionPath, nil
+	}
+
+	entries, err := os.ReadDir(sessionDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read session directory: %s: %v", sessionDir, err)
+	}
+	type candidate struct {
+		path     string
+		modified time.Time
+	}
+	var candidates []candidate
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), sessionFileExtension) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		candidates = append(candidates, candidate{path: filepath.Join(sessionDir, e.Name()), modified: info.ModTime()})
+	}
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no sessions found - start the mock with 'imposter up --inspect'")
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].modified.After(candidates[j].modified)
+	})
+	return candidates[0].path, nil
+}
+
+// Tail reads the entries in the session log, calling onEntry for each.
+// If follow is true, it continues to wait for new entries until stopC
+// is closed.
+func Tail(sessionPath string, follow bool, stopC chan bool, onEntry func(entry Entry)) error {
+	file, err := os.Open(sessionPath)
+	if err != nil {
+		return fmt.Errorf("failed to open session log: %s: %v", sessionPath, err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	var partial string
+	var offset int64
+	for {
+		line, err := reader.ReadString('\n')
+		offset += int64(len(line))
+		if err == io.EOF {
+			// keep any incomplete line until the rest is written
+			partial += line
+			if !follow {
+				return nil
+			}
+			// start again if the session log was truncated by a new session
+			if info, err := file.Stat(); err == nil && info.Size() < offset {
+				if _, err = file.Seek(0, io.SeekStart); err != nil {
+					return fmt.Errorf("failed to read session log: %s: %v", sessionPath, err)
+				}
+				reader.Reset(file)
+				partial = ""
+				offset = 0
+			}
+			select {
+			case <-stopC:
+				return nil
+			case <-time.After(followInterval):
+				continue
+			}
+		} else if err != nil {
+			return fmt.Errorf("failed to read session log: %s: %v", sessionPath, err)
+		}
+		line = partial + line
+		partial = ""
+
+		var entry Entry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			logger.Debugf("skipping invalid session log entry: %s", err)
+			continue
+		}
+		onEntry(entry)
+	}
+}
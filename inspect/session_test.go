@@ -0,0 +1,71 @@
+package inspect
+
+import (
+	"bytes"
+	"gatehill.io/imposter/proxy"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewHandler(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("not found"))
+	}))
+	defer upstream.Close()
+
+	configDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "pets-config.yaml"), []byte("plugin: rest\npath: /pets\n"), 0644))
+
+	entryC := make(chan Entry, 1)
+	server := httptest.NewServer(NewHandler(proxy.PassThrough(upstream.URL), NewMatcher(configDir, false), entryC))
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/pets?limit=1", "text/plain", bytes.NewReader([]byte("body")))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode, "status should be relayed")
+
+	entry := <-entryC
+	require.Equal(t, "POST", entry.Method)
+	require.Equal(t, "/pets", entry.Path)
+	require.Equal(t, "limit=1", entry.Query)
+	require.Equal(t, http.StatusNotFound, entry.Status)
+	require.Equal(t, 4, entry.RequestSize)
+	require.Equal(t, 9, entry.ResponseSize)
+	require.Equal(t, "pets-config.yaml: * /pets", entry.Resource)
+}
+
+func TestSession(t *testing.T) {
+	viper.Set("inspect.dir", t.TempDir())
+	t.Cleanup(func() {
+		viper.Set("inspect.dir", "")
+	})
+
+	_, err := FindSession(0)
+	require.ErrorContains(t, err, "no sessions found")
+
+	session, err := CreateSession(8080)
+	require.NoError(t, err)
+	require.NoError(t, session.Write(Entry{Method: "GET", Path: "/pets", Status: 200}))
+	require.NoError(t, session.Write(Entry{Method: "GET", Path: "/orders", Status: 404}))
+
+	sessionPath, err := FindSession(0)
+	require.NoError(t, err)
+	require.Equal(t, session.Path(), sessionPath)
+
+	_, err = FindSession(9090)
+	require.ErrorContains(t, err, "no session found for port 9090")
+
+	var paths []string
+	err = Tail(sessionPath, false, nil, func(entry Entry) {
+		paths = append(paths, entry.Path)
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"/pets", "/orders"}, paths)
+}
@@ -0,0 +1,180 @@
+package library
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const checksumsStoreFileName = "checksums.json"
+
+const quarantineDir = ".imposter/quarantine"
+
+// ChecksumRecord holds the SHA-256 hash of a downloaded file, recorded
+// at the time of download.
+type ChecksumRecord struct {
+	Path       string    `json:"path"`
+	Sha256     string    `json:"sha256"`
+	Source     string    `json:"source,omitempty"`
+	Verified   bool      `json:"verified"`
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+type ChecksumStatus string
+
+const (
+	ChecksumOk       ChecksumStatus = "ok"
+	ChecksumMismatch ChecksumStatus = "mismatch"
+	ChecksumMissing  ChecksumStatus = "missing"
+)
+
+// RecordChecksum hashes the file at localPath and persists the hash,
+// so the file can be checked for tampering later.
+func RecordChecksum(localPath string, source string, verified bool) error {
+	hash, err := HashFile(localPath)
+	if err != nil {
+		return err
+	}
+	absPath, err := filepath.Abs(localPath)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path of: %s: %v", localPath, err)
+	}
+	return updateChecksumStore(func(records map[string]ChecksumRecord) {
+		records[absPath] = ChecksumRecord{
+			Path:       absPath,
+			Sha256:     hash,
+			Source:     source,
+			Verified:   verified,
+			RecordedAt: time.Now().UTC(),
+		}
+	})
+}
+
+// GetChecksumRecord returns the checksum record for the file, if one exists.
+func GetChecksumRecord(localPath string) (*ChecksumRecord, error) {
+	absPath, err := filepath.Abs(localPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path of: %s: %v", localPath, err)
+	}
+	records, err := loadChecksumStore()
+	if err != nil {
+		return nil, err
+	}
+	if record, found := records[absPath]; found {
+		return &record, nil
+	}
+	return nil, nil
+}
+
+// ForgetChecksum removes the checksum record for the file.
+func ForgetChecksum(localPath string) error {
+	absPath, err := filepath.Abs(localPath)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path of: %s: %v", localPath, err)
+	}
+	return updateChecksumStore(func(records map[string]ChecksumRecord) {
+		delete(records, absPath)
+	})
+}
+
+// ListChecksumRecords returns all checksum records, ordered by path.
+func ListChecksumRecords() ([]ChecksumRecord, error) {
+	records, err := loadChecksumStore()
+	if err != nil {
+		return nil, err
+	}
+	var list []ChecksumRecord
+	for _, record := range records {
+		list = append(list, record)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Path < list[j].Path
+	})
+	return list, nil
+}
+
+// VerifyChecksumRecord re-hashes the file and compares it to the recorded hash.
+func VerifyChecksumRecord(record ChecksumRecord) (ChecksumStatus, error) {
+	if _, err := os.Stat(record.Path); err != nil {
+		if os.IsNotExist(err) {
+			return ChecksumMissing, nil
+		}
+		return "", fmt.Errorf("failed to stat: %s: %v", record.Path, err)
+	}
+	hash, err := HashFile(record.Path)
+	if err != nil {
+		return "", err
+	}
+	if hash != record.Sha256 {
+		return ChecksumMismatch, nil
+	}
+	return ChecksumOk, nil
+}
+
+// QuarantineFile moves the file to the quarantine directory, so it cannot
+// be used, and removes its checksum record. It returns the new path of the file.
+func QuarantineFile(localPath string) (string, error) {
+	dir, err := EnsureDirUsingConfig("download.quarantineDir", quarantineDir)
+	if err != nil {
+		return "", err
+	}
+	dest := filepath.Join(dir, fmt.Sprintf("%s.%s", filepath.Base(localPath), time.Now().UTC().Format("20060102T150405.000000000")))
+	if err = os.Rename(localPath, dest); err != nil {
+		return "", fmt.Errorf("failed to quarantine file: %s: %v", localPath, err)
+	}
+	if err = ForgetChecksum(localPath); err != nil {
+		logger.Warn(err)
+	}
+	return dest, nil
+}
+
+func getChecksumStorePath() (string, error) {
+	dir, err := EnsureDirUsingConfig("prefs.dir", ".imposter")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, checksumsStoreFileName), nil
+}
+
+func loadChecksumStore() (map[string]ChecksumRecord, error) {
+	storePath, err := getChecksumStorePath()
+	if err != nil {
+		return nil, err
+	}
+	records := make(map[string]ChecksumRecord)
+	data, err := os.ReadFile(storePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return records, nil
+		}
+		return nil, fmt.Errorf("failed to read checksum store: %s: %v", storePath, err)
+	}
+	if err = json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse checksum store: %s: %v", storePath, err)
+	}
+	return records, nil
+}
+
+func updateChecksumStore(update func(records map[string]ChecksumRecord)) error {
+	records, err := loadChecksumStore()
+	if err != nil {
+		return err
+	}
+	update(records)
+
+	storePath, err := getChecksumStorePath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal checksum store: %v", err)
+	}
+	if err = os.WriteFile(storePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write checksum store: %s: %v", storePath, err)
+	}
+	return nil
+}
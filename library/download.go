@@ -1,64 +1,134 @@
 package library
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 )
 
 type DownloadConfig struct {
 	LatestBaseUrlTemplate    string
 	VersionedBaseUrlTemplate string
+
+	// ChecksumsFileName is the name of the SHA-256 checksums file published
+	// alongside the binaries (default: "checksums.txt")
+	ChecksumsFileName string
 }
 
 func DownloadBinaryWithConfig(config DownloadConfig, localPath string, remoteFileName string, version string, fallbackRemoteFileName string) error {
 	logger.Tracef("attempting to download %s version %s to %s", remoteFileName, version, localPath)
-	file, err := os.Create(localPath)
+
+	var baseUrl string
+	if version == "latest" {
+		baseUrl = config.LatestBaseUrlTemplate
+	} else {
+		baseUrl = fmt.Sprintf(config.VersionedBaseUrlTemplate, version)
+	}
+	baseUrl = ApplyMirror(baseUrl)
+
+	url := baseUrl + "/" + remoteFileName
+	resp, err := makeHttpRequest(url, nil)
 	if err != nil {
-		return fmt.Errorf("error creating file: %v: %v", localPath, err)
+		return err
 	}
-	defer func() {
-		_ = file.Close()
-		if stat, err := os.Stat(localPath); err == nil && stat.Size() == 0 {
-			logger.Tracef("removing empty file: %s", localPath)
-			_ = os.Remove(localPath)
-		}
-	}()
 
-	var url string
-	var resp *http.Response
-	if version == "latest" {
-		url = config.LatestBaseUrlTemplate + "/" + remoteFileName
-		resp, err = makeHttpRequest(url, err)
+	// fallback to versioned binary filename
+	if version != "latest" && resp.StatusCode == 404 && fallbackRemoteFileName != "" {
+		_ = resp.Body.Close()
+		logger.Tracef("binary not found at: %v - retrying with fallback filename", url)
+		remoteFileName = fallbackRemoteFileName
+		url = baseUrl + "/" + remoteFileName
+		resp, err = makeHttpRequest(url, nil)
 		if err != nil {
 			return err
 		}
+	}
+	defer resp.Body.Close()
 
-	} else {
-		versionedBaseUrl := fmt.Sprintf(config.VersionedBaseUrlTemplate, version)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &StatusError{Url: url, StatusCode: resp.StatusCode}
+	}
+	tempPath, err := writeDownload(localPath, resp.Body)
+	if err != nil || tempPath == "" {
+		return err
+	}
 
-		url := versionedBaseUrl + "/" + remoteFileName
-		resp, err = makeHttpRequest(url, err)
-		if err != nil {
-			return err
+	// the download is only moved into place once it has been verified
+	verified, err := verifyDownload(config, baseUrl, remoteFileName, tempPath)
+	if err != nil {
+		if quarantinePath, qErr := QuarantineFile(tempPath); qErr != nil {
+			logger.Warn(qErr)
+			_ = os.Remove(tempPath)
+		} else {
+			logger.Warnf("quarantined download of %s to %s", localPath, quarantinePath)
 		}
+		return err
+	}
+	if err = os.Rename(tempPath, localPath); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("error moving download into place: %v: %v", localPath, err)
+	}
+	return RecordChecksum(localPath, url, verified)
+}
 
-		// fallback to versioned binary filename
-		if resp.StatusCode == 404 && fallbackRemoteFileName != "" {
-			logger.Tracef("binary not found at: %v - retrying with fallback filename", url)
-			url = versionedBaseUrl + "/" + fallbackRemoteFileName
-			resp, err = makeHttpRequest(url, err)
-			if err != nil {
-				return err
-			}
-		}
+// StatusError is returned when a download fails with an HTTP status
+// other than 2xx.
+type StatusError struct {
+	Url        string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("error downloading from: %v: status code: %d", e.Url, e.StatusCode)
+}
+
+// IsNotFound returns true if the error is a download that failed
+// because the file does not exist.
+func IsNotFound(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound
+}
+
+// writeDownload writes the body to a temporary file in the same directory
+// as localPath, returning its path. An empty body is not kept, in which
+// case the path is empty.
+func writeDownload(localPath string, body io.Reader) (tempPath string, err error) {
+	file, err := os.CreateTemp(filepath.Dir(localPath), "."+filepath.Base(localPath)+".*.download")
+	if err != nil {
+		return "", fmt.Errorf("error creating file for download: %v: %v", localPath, err)
+	}
+	tempPath = file.Name()
+	size, err := io.Copy(file, body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tempPath)
+		return "", fmt.Errorf("error writing file: %v: %v", tempPath, err)
+	}
+	if size == 0 {
+		logger.Tracef("removing empty download: %s", tempPath)
+		_ = os.Remove(tempPath)
+		return "", nil
 	}
+	return tempPath, nil
+}
 
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return fmt.Errorf("error downloading from: %v: status code: %d", url, resp.StatusCode)
+// fetchOptional downloads a small file, such as a checksums file, returning
+// nil if it does not exist.
+func fetchOptional(url string) ([]byte, error) {
+	resp, err := makeHttpRequest(url, nil)
+	if err != nil {
+		return nil, err
 	}
 	defer resp.Body.Close()
-	_, err = io.Copy(file, resp.Body)
-	return err
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	} else if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("error downloading from: %v: status code: %d", url, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
 }
@@ -18,6 +18,18 @@ func DownloadBinaryWithFallback(localPath string, remoteFileName string, version
 	return DownloadBinaryWithConfig(defaultConfig, localPath, remoteFileName, version, fallbackRemoteFileName)
 }
 
+// GetDownloadUrl returns the URL from which the file for the version is
+// downloaded, using the configured mirror, if any.
+func GetDownloadUrl(remoteFileName string, version string) string {
+	var baseUrl string
+	if version == "latest" {
+		baseUrl = defaultConfig.LatestBaseUrlTemplate
+	} else {
+		baseUrl = fmt.Sprintf(defaultConfig.VersionedBaseUrlTemplate, version)
+	}
+	return ApplyMirror(baseUrl) + "/" + remoteFileName
+}
+
 func makeHttpRequest(url string, err error) (*http.Response, error) {
 	logger.Debugf("downloading %v", url)
 	resp, err := http.Get(url)
@@ -0,0 +1,33 @@
+package library
+
+import (
+	"github.com/spf13/viper"
+	"strings"
+)
+
+const (
+	githubBaseUrl    = "https://github.com"
+	githubApiBaseUrl = "https://api.github.com"
+)
+
+// ApplyMirror rewrites a GitHub URL to use the mirror configured
+// with the `download.mirror` key, if any. Mirrors follow the GitHub
+// path layout, with API paths under `/api`, for example:
+//
+//	https://github.com/outofcoffee/imposter/releases/download/v4.0.0/imposter.jar
+//	-> https://mirror.example.com/outofcoffee/imposter/releases/download/v4.0.0/imposter.jar
+//
+//	https://api.github.com/repos/outofcoffee/imposter/releases/latest
+//	-> https://mirror.example.com/api/repos/outofcoffee/imposter/releases/latest
+func ApplyMirror(url string) string {
+	mirror := strings.TrimSuffix(viper.GetString("download.mirror"), "/")
+	if mirror == "" {
+		return url
+	}
+	if strings.HasPrefix(url, githubApiBaseUrl+"/") {
+		return mirror + "/api" + strings.TrimPrefix(url, githubApiBaseUrl)
+	} else if strings.HasPrefix(url, githubBaseUrl+"/") {
+		return mirror + strings.TrimPrefix(url, githubBaseUrl)
+	}
+	return url
+}
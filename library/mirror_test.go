@@ -0,0 +1,51 @@
+package library
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestApplyMirror(t *testing.T) {
+	tests := []struct {
+		name   string
+		mirror string
+		url    string
+		want   string
+	}{
+		{
+			name:   "no mirror",
+			mirror: "",
+			url:    "https://github.com/outofcoffee/imposter/releases/latest/download/imposter.jar",
+			want:   "https://github.com/outofcoffee/imposter/releases/latest/download/imposter.jar",
+		},
+		{
+			name:   "release download",
+			mirror: "https://mirror.example.com/",
+			url:    "https://github.com/outofcoffee/imposter/releases/download/v4.0.0/imposter.jar",
+			want:   "https://mirror.example.com/outofcoffee/imposter/releases/download/v4.0.0/imposter.jar",
+		},
+		{
+			name:   "api",
+			mirror: "https://mirror.example.com",
+			url:    "https://api.github.com/repos/outofcoffee/imposter/releases/latest",
+			want:   "https://mirror.example.com/api/repos/outofcoffee/imposter/releases/latest",
+		},
+		{
+			name:   "other host",
+			mirror: "https://mirror.example.com",
+			url:    "https://example.org/imposter.jar",
+			want:   "https://example.org/imposter.jar",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set("download.mirror", tt.mirror)
+			defer viper.Set("download.mirror", nil)
+
+			if got := ApplyMirror(tt.url); got != tt.want {
+				t.Errorf("ApplyMirror() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
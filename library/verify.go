@@ -0,0 +1,179 @@
+package library
+
+import (
+	"bufio"
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"github.com/spf13/viper"
+	"io"
+	"os"
+	"strings"
+)
+
+type VerifyMode string
+
+const (
+	// VerifyModeStrict fails downloads that cannot be verified
+	VerifyModeStrict VerifyMode = "strict"
+
+	// VerifyModeWarn verifies downloads if a checksums file is published,
+	// and warns if it is not
+	VerifyModeWarn VerifyMode = "warn"
+
+	// VerifyModeOff skips verification
+	VerifyModeOff VerifyMode = "off"
+)
+
+const defaultChecksumsFileName = "checksums.txt"
+
+// signatureSuffix is appended to the checksums file name to locate its signature.
+const signatureSuffix = ".sig"
+
+func getVerifyMode() VerifyMode {
+	switch mode := VerifyMode(viper.GetString("download.verify")); mode {
+	case VerifyModeStrict, VerifyModeOff:
+		return mode
+	case VerifyModeWarn, "":
+		return VerifyModeWarn
+	default:
+		logger.Warnf("unsupported download verification mode: %s - using %s", mode, VerifyModeWarn)
+		return VerifyModeWarn
+	}
+}
+
+// verifyDownload checks the SHA-256 hash of the downloaded file against
+// the checksums file published at baseUrl. If a public key is configured,
+// the signature of the checksums file is verified first. It returns
+// true if the file was verified.
+func verifyDownload(config DownloadConfig, baseUrl string, remoteFileName string, localPath string) (bool, error) {
+	mode := getVerifyMode()
+	if mode == VerifyModeOff {
+		logger.Tracef("skipping verification of %s", remoteFileName)
+		return false, nil
+	}
+
+	checksumsFileName := config.ChecksumsFileName
+	if checksumsFileName == "" {
+		checksumsFileName = defaultChecksumsFileName
+	}
+	checksumsUrl := baseUrl + "/" + checksumsFileName
+	checksums, err := fetchOptional(checksumsUrl)
+	if err != nil {
+		return false, err
+	}
+	if checksums == nil {
+		if mode == VerifyModeStrict {
+			return false, fmt.Errorf("no checksums file found at: %s - cannot verify %s", checksumsUrl, remoteFileName)
+		}
+		logger.Warnf("no checksums file found at: %s - %s has not been verified", checksumsUrl, remoteFileName)
+		return false, nil
+	}
+
+	if publicKeyPath := viper.GetString("download.publicKey"); publicKeyPath != "" {
+		signature, err := fetchOptional(checksumsUrl + signatureSuffix)
+		if err != nil {
+			return false, err
+		}
+		if signature == nil {
+			return false, fmt.Errorf("no signature found at: %s%s - cannot verify %s", checksumsUrl, signatureSuffix, remoteFileName)
+		}
+		if err = VerifySignature(publicKeyPath, checksums, signature); err != nil {
+			return false, fmt.Errorf("failed to verify signature of %s: %v", checksumsUrl, err)
+		}
+		logger.Debugf("verified signature of %s", checksumsUrl)
+	}
+
+	expected, found := findChecksum(checksums, remoteFileName)
+	if !found {
+		if mode == VerifyModeStrict {
+			return false, fmt.Errorf("no checksum for %s found in: %s", remoteFileName, checksumsUrl)
+		}
+		logger.Warnf("no checksum for %s found in: %s - file has not been verified", remoteFileName, checksumsUrl)
+		return false, nil
+	}
+	actual, err := HashFile(localPath)
+	if err != nil {
+		return false, err
+	}
+	if !strings.EqualFold(expected, actual) {
+		return false, fmt.Errorf("checksum mismatch for %s: expected %s, got %s", remoteFileName, expected, actual)
+	}
+	logger.Debugf("verified checksum of %s", remoteFileName)
+	return true, nil
+}
+
+// findChecksum returns the checksum for the file from the contents of a
+// checksums file, in the format produced by sha256sum.
+func findChecksum(checksums []byte, fileName string) (string, bool) {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == fileName {
+			return fields[0], true
+		}
+	}
+	return "", false
+}
+
+// HashFile returns the hex encoded SHA-256 hash of the file.
+func HashFile(filePath string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %s: %v", filePath, err)
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if _, err = io.Copy(hash, f); err != nil {
+		return "", fmt.Errorf("failed to hash file: %s: %v", filePath, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// VerifySignature checks the signature of content using the PEM encoded
+// public key at publicKeyPath. RSA (PKCS #1 v1.5), ECDSA and Ed25519 keys are
+// supported, with signatures either raw or base64 encoded. RSA and ECDSA
+// signatures are over the SHA-256 digest of the content.
+func VerifySignature(publicKeyPath string, content []byte, signature []byte) error {
+	keyPem, err := os.ReadFile(publicKeyPath)
+	if err != nil {
+		return fmt.Errorf("failed to read public key: %s: %v", publicKeyPath, err)
+	}
+	block, _ := pem.Decode(keyPem)
+	if block == nil {
+		return fmt.Errorf("no PEM data found in public key: %s", publicKeyPath)
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse public key: %s: %v", publicKeyPath, err)
+	}
+
+	if decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature))); err == nil {
+		signature = decoded
+	}
+	digest := sha256.Sum256(content)
+
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		err = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature)
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest[:], signature) {
+			err = fmt.Errorf("invalid signature")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, content, signature) {
+			err = fmt.Errorf("invalid signature")
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T in: %s", publicKey, publicKeyPath)
+	}
+	return err
+}
@@ -0,0 +1,190 @@
+package library
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+const testBinaryContent = "binary content"
+
+// setupVerifyTest configures temporary prefs and quarantine directories,
+// and starts a server publishing the binary and the given files.
+func setupVerifyTest(t *testing.T, mode VerifyMode, files map[string]string) (DownloadConfig, string) {
+	tempDir := t.TempDir()
+	viper.Set("prefs.dir", filepath.Join(tempDir, "prefs"))
+	viper.Set("download.quarantineDir", filepath.Join(tempDir, "quarantine"))
+	viper.Set("download.verify", string(mode))
+	t.Cleanup(func() {
+		viper.Set("prefs.dir", nil)
+		viper.Set("download.quarantineDir", nil)
+		viper.Set("download.verify", nil)
+		viper.Set("download.publicKey", nil)
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/latest/imposter.jar" {
+			_, _ = w.Write([]byte(testBinaryContent))
+			return
+		}
+		if content, found := files[filepath.Base(r.URL.Path)]; found {
+			_, _ = w.Write([]byte(content))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+
+	config := DownloadConfig{
+		LatestBaseUrlTemplate:    server.URL + "/latest",
+		VersionedBaseUrlTemplate: server.URL + "/v%v",
+	}
+	return config, filepath.Join(tempDir, "imposter.jar")
+}
+
+func checksumOf(content string) string {
+	hash := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(hash[:])
+}
+
+func TestDownloadBinaryWithConfig_Verified(t *testing.T) {
+	checksums := fmt.Sprintf("%s *imposter.jar\n%s  other.zip\n", checksumOf(testBinaryContent), checksumOf("other"))
+	config, localPath := setupVerifyTest(t, VerifyModeStrict, map[string]string{"checksums.txt": checksums})
+
+	require.NoError(t, DownloadBinaryWithConfig(config, localPath, "imposter.jar", "latest", ""))
+	require.FileExists(t, localPath)
+
+	record, err := GetChecksumRecord(localPath)
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	require.True(t, record.Verified)
+	require.Equal(t, checksumOf(testBinaryContent), record.Sha256)
+}
+
+func TestDownloadBinaryWithConfig_Mismatch(t *testing.T) {
+	checksums := fmt.Sprintf("%s  imposter.jar\n", checksumOf("tampered"))
+	config, localPath := setupVerifyTest(t, VerifyModeWarn, map[string]string{"checksums.txt": checksums})
+
+	err := DownloadBinaryWithConfig(config, localPath, "imposter.jar", "latest", "")
+	require.ErrorContains(t, err, "checksum mismatch")
+	require.NoFileExists(t, localPath)
+	requireNoDownloads(t, localPath)
+
+	quarantined, err := os.ReadDir(viper.GetString("download.quarantineDir"))
+	require.NoError(t, err)
+	require.Len(t, quarantined, 1)
+}
+
+func TestDownloadBinaryWithConfig_MissingChecksums(t *testing.T) {
+	t.Run("warn mode keeps the file", func(t *testing.T) {
+		config, localPath := setupVerifyTest(t, VerifyModeWarn, nil)
+		require.NoError(t, DownloadBinaryWithConfig(config, localPath, "imposter.jar", "latest", ""))
+
+		record, err := GetChecksumRecord(localPath)
+		require.NoError(t, err)
+		require.NotNil(t, record)
+		require.False(t, record.Verified)
+	})
+	t.Run("strict mode fails", func(t *testing.T) {
+		config, localPath := setupVerifyTest(t, VerifyModeStrict, nil)
+		err := DownloadBinaryWithConfig(config, localPath, "imposter.jar", "latest", "")
+		require.ErrorContains(t, err, "no checksums file found")
+		require.NoFileExists(t, localPath)
+		requireNoDownloads(t, localPath)
+	})
+}
+
+func TestDownloadBinaryWithConfig_KeepsExistingOnFailure(t *testing.T) {
+	checksums := fmt.Sprintf("%s  imposter.jar\n", checksumOf("tampered"))
+	config, localPath := setupVerifyTest(t, VerifyModeStrict, map[string]string{"checksums.txt": checksums})
+	require.NoError(t, os.WriteFile(localPath, []byte("existing"), 0644))
+
+	err := DownloadBinaryWithConfig(config, localPath, "imposter.jar", "latest", "")
+	require.ErrorContains(t, err, "checksum mismatch")
+
+	content, err := os.ReadFile(localPath)
+	require.NoError(t, err)
+	require.Equal(t, "existing", string(content), "existing file should not be replaced by an unverified download")
+}
+
+// requireNoDownloads fails if a download, complete or partial, was left in
+// the directory of localPath.
+func requireNoDownloads(t *testing.T, localPath string) {
+	entries, err := os.ReadDir(filepath.Dir(localPath))
+	require.NoError(t, err)
+	for _, entry := range entries {
+		require.NotContains(t, entry.Name(), filepath.Base(localPath), "download should not be left in place")
+	}
+}
+
+func TestDownloadBinaryWithConfig_Signature(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	keyPath := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: keyDer}), 0644))
+
+	checksums := fmt.Sprintf("%s  imposter.jar\n", checksumOf(testBinaryContent))
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, []byte(checksums)))
+
+	t.Run("valid signature", func(t *testing.T) {
+		config, localPath := setupVerifyTest(t, VerifyModeStrict, map[string]string{
+			"checksums.txt":     checksums,
+			"checksums.txt.sig": signature,
+		})
+		viper.Set("download.publicKey", keyPath)
+		require.NoError(t, DownloadBinaryWithConfig(config, localPath, "imposter.jar", "latest", ""))
+	})
+	t.Run("invalid signature", func(t *testing.T) {
+		config, localPath := setupVerifyTest(t, VerifyModeStrict, map[string]string{
+			"checksums.txt":     checksums + "\n",
+			"checksums.txt.sig": signature,
+		})
+		viper.Set("download.publicKey", keyPath)
+		err := DownloadBinaryWithConfig(config, localPath, "imposter.jar", "latest", "")
+		require.ErrorContains(t, err, "failed to verify signature")
+	})
+	t.Run("missing signature", func(t *testing.T) {
+		config, localPath := setupVerifyTest(t, VerifyModeWarn, map[string]string{"checksums.txt": checksums})
+		viper.Set("download.publicKey", keyPath)
+		err := DownloadBinaryWithConfig(config, localPath, "imposter.jar", "latest", "")
+		require.ErrorContains(t, err, "no signature found")
+	})
+}
+
+func TestVerifyChecksumRecord(t *testing.T) {
+	_, localPath := setupVerifyTest(t, VerifyModeOff, nil)
+	require.NoError(t, os.WriteFile(localPath, []byte(testBinaryContent), 0644))
+	require.NoError(t, RecordChecksum(localPath, "", false))
+
+	record, err := GetChecksumRecord(localPath)
+	require.NoError(t, err)
+
+	status, err := VerifyChecksumRecord(*record)
+	require.NoError(t, err)
+	require.Equal(t, ChecksumOk, status)
+
+	require.NoError(t, os.WriteFile(localPath, []byte("tampered"), 0644))
+	status, err = VerifyChecksumRecord(*record)
+	require.NoError(t, err)
+	require.Equal(t, ChecksumMismatch, status)
+
+	require.NoError(t, os.Remove(localPath))
+	status, err = VerifyChecksumRecord(*record)
+	require.NoError(t, err)
+	require.Equal(t, ChecksumMissing, status)
+}
@@ -0,0 +1,353 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lockfile
+
+import (
+	"fmt"
+	"gatehill.io/imposter/engine"
+	"gatehill.io/imposter/library"
+	"gatehill.io/imposter/logging"
+	"gatehill.io/imposter/plugin"
+	"gatehill.io/imposter/semverutil"
+	"gatehill.io/imposter/stringutil"
+	"github.com/spf13/viper"
+	"os"
+	"path/filepath"
+	"sigs.k8s.io/yaml"
+	"sort"
+	"strings"
+)
+
+// FileName is the name of the lockfile, which is written to the
+// same directory as the `.imposter.yaml` file.
+const FileName = "imposter.lock"
+
+const fileHeader = "# This file is generated by 'imposter lock'. Do not edit.\n"
+
+var logger = logging.GetLogger()
+
+// Lockfile records the engine and plugin versions resolved for a
+// configuration directory, so all users get the same versions.
+type Lockfile struct {
+	Engine  LockedEngine   `json:"engine"`
+	Plugins []LockedPlugin `json:"plugins,omitempty"`
+}
+
+type LockedEngine struct {
+	Type    engine.EngineType `json:"type"`
+	Version string            `json:"version"`
+
+	// Checksums holds the SHA-256 hash of each engine artifact, keyed by
+	// file name. Engines that are not held as files, such as Docker
+	// images, have no checksums.
+	Checksums map[string]string `json:"checksums,omitempty"`
+
+	// Digest is the repository digest of the engine image, for engines
+	// that are container images.
+	Digest string `json:"digest,omitempty"`
+}
+
+// LockedPlugin is a plugin for the locked engine version.
+type LockedPlugin struct {
+	Name     string `json:"name"`
+	Checksum string `json:"checksum,omitempty"`
+}
+
+// IsFrozen returns true if the lockfile must exist and match the
+// configuration, as set by the `--frozen` flag or `lock.frozen` key.
+func IsFrozen() bool {
+	return viper.GetBool("lock.frozen")
+}
+
+// Load reads the lockfile from the directory, returning nil
+// if it does not exist.
+func Load(dir string) (*Lockfile, error) {
+	lockPath := filepath.Join(dir, FileName)
+	data, err := os.ReadFile(lockPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read lockfile: %s: %v", lockPath, err)
+	}
+	var lock Lockfile
+	if err = yaml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse lockfile: %s: %v", lockPath, err)
+	}
+	return &lock, nil
+}
+
+// Save writes the lockfile to the directory, returning its path.
+func Save(dir string, lock *Lockfile) (string, error) {
+	lockPath := filepath.Join(dir, FileName)
+	data, err := yaml.Marshal(lock)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal lockfile: %v", err)
+	}
+	if err = os.WriteFile(lockPath, append([]byte(fileHeader), data...), 0644); err != nil {
+		return "", fmt.Errorf("failed to write lockfile: %s: %v", lockPath, err)
+	}
+	return lockPath, nil
+}
+
+// Generate creates a lockfile for the engine version and plugins,
+// fetching them if required so their checksums can be recorded.
+func Generate(engineType engine.EngineType, version string, plugins []string) (*Lockfile, error) {
+	if err := engine.GetLibrary(engineType).GetProvider(version).Provide(engine.PullIfNotPresent); err != nil {
+		return nil, fmt.Errorf("failed to pull engine type: %s version %s: %v", engineType, version, err)
+	}
+	checksums, err := hashEngineArtifacts(engineType, version)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := getEngineDigest(engineType, version)
+	if err != nil {
+		return nil, err
+	}
+	lock := &Lockfile{
+		Engine: LockedEngine{
+			Type:      engineType,
+			Version:   version,
+			Checksums: checksums,
+			Digest:    digest,
+		},
+	}
+
+	plugins = stringutil.Unique(plugins)
+	sort.Strings(plugins)
+	if _, err = plugin.EnsurePlugins(plugins, version, false); err != nil {
+		return nil, err
+	}
+	for _, pluginName := range plugins {
+		checksum, err := hashPlugin(pluginName, version)
+		if err != nil {
+			return nil, err
+		}
+		lock.Plugins = append(lock.Plugins, LockedPlugin{
+			Name:     pluginName,
+			Checksum: checksum,
+		})
+	}
+	return lock, nil
+}
+
+// GetConfiguredType returns the engine type, in order of precedence, from
+// the override, the CLI configuration, the lockfile in the directory, or
+// the default engine type.
+func GetConfiguredType(dir string, override string) engine.EngineType {
+	if override != "" || viper.GetString("engine") != "" {
+		return engine.GetConfiguredType(override)
+	}
+	lock, err := Load(dir)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	if lock != nil {
+		return lock.Engine.Type
+	}
+	return engine.GetConfiguredType("")
+}
+
+// ResolveVersion returns the engine version to use for the directory. If a
+// lockfile exists, its version is used, unless overridden, and any drift
+// between the lockfile and the configuration is reported. In frozen mode,
+// drift, or a missing lockfile, is an error.
+func ResolveVersion(dir string, engineType engine.EngineType, override string, allowCached bool) (*Lockfile, string, error) {
+	lock, err := Load(dir)
+	if err != nil {
+		return nil, "", err
+	}
+	if lock == nil {
+		if IsFrozen() {
+			return nil, "", fmt.Errorf("no lockfile found in: %s - run 'imposter lock' to create one", dir)
+		}
+		return nil, engine.GetConfiguredVersionForType(override, engineType, allowCached), nil
+	}
+
+	configured := engine.GetConfiguredVersionOrResolve(override, allowCached, false)
+	drift := lock.checkConfigDrift(engineType, configured, plugin.ListMockPlugins(dir))
+	if err = reportDrift(dir, drift); err != nil {
+		return nil, "", err
+	}
+
+	version := lock.Engine.Version
+	if override != "" && override != version && !semverutil.IsConstraint(override) && override != "latest" {
+		version = override
+	}
+	logger.Debugf("using engine version %s from lockfile in: %s", version, dir)
+	return lock, version, nil
+}
+
+// VerifyArtifacts compares the checksums of the engine and plugin files
+// against those in the lockfile. If lock is nil, there is nothing to verify.
+func VerifyArtifacts(dir string, lock *Lockfile, engineType engine.EngineType, version string) error {
+	if lock == nil || version != lock.Engine.Version {
+		return nil
+	}
+	var drift []string
+	if engineType == lock.Engine.Type && (len(lock.Engine.Checksums) > 0 || lock.Engine.Digest != "") {
+		if err := engine.GetLibrary(engineType).GetProvider(version).Provide(engine.PullIfNotPresent); err != nil {
+			return err
+		}
+		checksums, err := hashEngineArtifacts(engineType, version)
+		if err != nil {
+			return err
+		}
+		for fileName, expected := range lock.Engine.Checksums {
+			if actual := checksums[fileName]; actual != expected {
+				drift = append(drift, fmt.Sprintf("engine file %s has checksum %s, but lockfile has %s", fileName, describeChecksum(actual), expected))
+			}
+		}
+		if lock.Engine.Digest != "" {
+			digest, err := getEngineDigest(engineType, version)
+			if err != nil {
+				return err
+			}
+			if digest != lock.Engine.Digest {
+				drift = append(drift, fmt.Sprintf("engine image has digest %s, but lockfile has %s", describeChecksum(digest), lock.Engine.Digest))
+			}
+		}
+	}
+	for _, locked := range lock.Plugins {
+		if locked.Checksum == "" {
+			continue
+		}
+		actual, err := hashPlugin(locked.Name, version)
+		if err != nil {
+			return err
+		}
+		if actual != locked.Checksum {
+			drift = append(drift, fmt.Sprintf("plugin %s has checksum %s, but lockfile has %s", locked.Name, describeChecksum(actual), locked.Checksum))
+		}
+	}
+	return reportDrift(dir, drift)
+}
+
+// PluginNames returns the names of the plugins in the lockfile.
+func (l *Lockfile) PluginNames() []string {
+	var names []string
+	for _, p := range l.Plugins {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+// checkConfigDrift returns a description of each difference between the
+// lockfile and the configured engine type, version and plugins.
+func (l *Lockfile) checkConfigDrift(engineType engine.EngineType, configuredVersion string, configuredPlugins []string) []string {
+	var drift []string
+	if isGolang(engineType) != isGolang(l.Engine.Type) {
+		drift = append(drift, fmt.Sprintf("engine type is %s, but lockfile has %s", engineType, l.Engine.Type))
+	}
+
+	switch {
+	case configuredVersion == "latest":
+		// any locked version satisfies 'latest'
+	case semverutil.IsConstraint(configuredVersion):
+		c, err := semverutil.ParseConstraint(configuredVersion)
+		if err != nil {
+			drift = append(drift, err.Error())
+		} else if c.Highest([]string{l.Engine.Version}) == "" {
+			drift = append(drift, fmt.Sprintf("engine version constraint %s is not satisfied by lockfile version %s", configuredVersion, l.Engine.Version))
+		}
+	case configuredVersion != l.Engine.Version:
+		drift = append(drift, fmt.Sprintf("engine version is %s, but lockfile has %s", configuredVersion, l.Engine.Version))
+	}
+
+	locked := l.PluginNames()
+	for _, p := range configuredPlugins {
+		if !stringutil.Contains(locked, p) {
+			drift = append(drift, fmt.Sprintf("plugin %s is not in lockfile", p))
+		}
+	}
+	return drift
+}
+
+func reportDrift(dir string, drift []string) error {
+	if len(drift) == 0 {
+		return nil
+	}
+	if IsFrozen() {
+		return fmt.Errorf("lockfile in %s is out of date:\n  %s\nrun 'imposter lock' to update it", dir, strings.Join(drift, "\n  "))
+	}
+	for _, d := range drift {
+		logger.Warnf("lockfile drift: %s", d)
+	}
+	logger.Warnf("lockfile in %s is out of date - run 'imposter lock' to update it", dir)
+	return nil
+}
+
+// isGolang returns true for the golang engine, whose versions are
+// independent of those of the other engine types.
+func isGolang(engineType engine.EngineType) bool {
+	return engineType == engine.EngineTypeGolang
+}
+
+func hashEngineArtifacts(engineType engine.EngineType, version string) (map[string]string, error) {
+	cachingLibrary, ok := engine.GetLibrary(engineType).(engine.CachingLibrary)
+	if !ok {
+		return nil, nil
+	}
+	versionDir, err := cachingLibrary.GetVersionDir(version)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(versionDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read engine directory: %s: %v", versionDir, err)
+	}
+	checksums := make(map[string]string)
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		hash, err := library.HashFile(filepath.Join(versionDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		checksums[entry.Name()] = hash
+	}
+	return checksums, nil
+}
+
+// getEngineDigest returns the repository digest of the engine image, for
+// engines that are container images, otherwise an empty string.
+func getEngineDigest(engineType engine.EngineType, version string) (string, error) {
+	digestLibrary, ok := engine.GetLibrary(engineType).(engine.DigestLibrary)
+	if !ok {
+		return "", nil
+	}
+	return digestLibrary.GetImageDigest(version)
+}
+
+func hashPlugin(pluginName string, version string) (string, error) {
+	pluginFilePath, err := plugin.GetPluginFilePath(pluginName, version)
+	if err != nil {
+		return "", err
+	}
+	if _, err = os.Stat(pluginFilePath); os.IsNotExist(err) {
+		return "", nil
+	}
+	return library.HashFile(pluginFilePath)
+}
+
+func describeChecksum(checksum string) string {
+	if checksum == "" {
+		return "(missing)"
+	}
+	return checksum
+}
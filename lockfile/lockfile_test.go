@@ -0,0 +1,138 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lockfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gatehill.io/imposter/engine"
+	"gatehill.io/imposter/library"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func setFrozen(t *testing.T, frozen bool) {
+	viper.Set("lock.frozen", frozen)
+	t.Cleanup(func() {
+		viper.Set("lock.frozen", nil)
+	})
+}
+
+func TestSaveLoad(t *testing.T) {
+	dir := t.TempDir()
+	lock := &Lockfile{
+		Engine: LockedEngine{
+			Type:      engine.EngineTypeJvmSingleJar,
+			Version:   "4.2.2",
+			Checksums: map[string]string{"imposter.jar": "abc123"},
+			Digest:    "sha256:789abc",
+		},
+		Plugins: []LockedPlugin{{Name: "store-redis", Checksum: "def456"}},
+	}
+	lockPath, err := Save(dir, lock)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, FileName), lockPath)
+
+	loaded, err := Load(dir)
+	require.NoError(t, err)
+	require.Equal(t, lock, loaded)
+
+	missing, err := Load(t.TempDir())
+	require.NoError(t, err)
+	require.Nil(t, missing)
+}
+
+func TestLockfile_checkConfigDrift(t *testing.T) {
+	lock := &Lockfile{
+		Engine:  LockedEngine{Type: engine.EngineTypeJvmSingleJar, Version: "4.2.2"},
+		Plugins: []LockedPlugin{{Name: "store-redis"}},
+	}
+	tests := []struct {
+		name       string
+		engineType engine.EngineType
+		version    string
+		plugins    []string
+		wantDrift  int
+	}{
+		{name: "latest matches", engineType: engine.EngineTypeJvmSingleJar, version: "latest", plugins: []string{"store-redis"}},
+		{name: "compatible engine type", engineType: engine.EngineTypeDockerCore, version: "4.2.2"},
+		{name: "constraint satisfied", engineType: engine.EngineTypeJvmSingleJar, version: "^4.2"},
+		{name: "constraint not satisfied", engineType: engine.EngineTypeJvmSingleJar, version: "^5", wantDrift: 1},
+		{name: "different version", engineType: engine.EngineTypeJvmSingleJar, version: "4.3.0", wantDrift: 1},
+		{name: "incompatible engine type", engineType: engine.EngineTypeGolang, version: "latest", wantDrift: 1},
+		{name: "unlocked plugin", engineType: engine.EngineTypeJvmSingleJar, version: "latest", plugins: []string{"store-dynamodb"}, wantDrift: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			drift := lock.checkConfigDrift(tt.engineType, tt.version, tt.plugins)
+			require.Len(t, drift, tt.wantDrift, "drift: %v", drift)
+		})
+	}
+}
+
+func TestResolveVersion(t *testing.T) {
+	dir := t.TempDir()
+	_, err := Save(dir, &Lockfile{Engine: LockedEngine{Type: engine.EngineTypeJvmSingleJar, Version: "4.2.2"}})
+	require.NoError(t, err)
+
+	t.Run("uses locked version", func(t *testing.T) {
+		lock, version, err := ResolveVersion(dir, engine.EngineTypeJvmSingleJar, "", true)
+		require.NoError(t, err)
+		require.NotNil(t, lock)
+		require.Equal(t, "4.2.2", version)
+	})
+	t.Run("override wins if not frozen", func(t *testing.T) {
+		_, version, err := ResolveVersion(dir, engine.EngineTypeJvmSingleJar, "4.3.0", true)
+		require.NoError(t, err)
+		require.Equal(t, "4.3.0", version)
+	})
+	t.Run("frozen fails on drift", func(t *testing.T) {
+		setFrozen(t, true)
+		_, _, err := ResolveVersion(dir, engine.EngineTypeJvmSingleJar, "4.3.0", true)
+		require.ErrorContains(t, err, "out of date")
+	})
+	t.Run("frozen fails without lockfile", func(t *testing.T) {
+		setFrozen(t, true)
+		_, _, err := ResolveVersion(t.TempDir(), engine.EngineTypeJvmSingleJar, "", true)
+		require.ErrorContains(t, err, "no lockfile found")
+	})
+}
+
+func TestVerifyArtifacts(t *testing.T) {
+	pluginDir := t.TempDir()
+	viper.Set("plugin.dir", pluginDir)
+	t.Cleanup(func() {
+		viper.Set("plugin.dir", nil)
+	})
+	pluginPath := filepath.Join(pluginDir, "imposter-plugin-store-redis.jar")
+	require.NoError(t, os.WriteFile(pluginPath, []byte("plugin"), 0644))
+	checksum, err := library.HashFile(pluginPath)
+	require.NoError(t, err)
+
+	lock := &Lockfile{
+		Engine:  LockedEngine{Type: engine.EngineTypeDockerCore, Version: "4.2.2"},
+		Plugins: []LockedPlugin{{Name: "store-redis", Checksum: checksum}},
+	}
+	setFrozen(t, true)
+	require.NoError(t, VerifyArtifacts(t.TempDir(), lock, engine.EngineTypeDockerCore, "4.2.2"))
+
+	require.NoError(t, os.WriteFile(pluginPath, []byte("tampered"), 0644))
+	err = VerifyArtifacts(t.TempDir(), lock, engine.EngineTypeDockerCore, "4.2.2")
+	require.ErrorContains(t, err, "plugin store-redis has checksum")
+}
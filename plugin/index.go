@@ -0,0 +1,212 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"gatehill.io/imposter/stringutil"
+	"github.com/spf13/viper"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const indexFileName = "index.json"
+const indexMaxAge = 24 * time.Hour
+
+// maxSuggestionDistance is the largest number of edits between an
+// unknown plugin name and an indexed plugin for it to be suggested.
+const maxSuggestionDistance = 2
+
+// Index lists the plugins available for download.
+type Index struct {
+	Plugins []IndexEntry `json:"plugins"`
+}
+
+type IndexEntry struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	// EngineVersions is a version constraint, such as `>=3.35.0`,
+	// for the engine versions with which the plugin is compatible.
+	EngineVersions string `json:"engineVersions,omitempty"`
+
+	// Format is the plugin archive format - "jar" (default) or "zip".
+	Format string `json:"format,omitempty"`
+}
+
+// defaultIndex is used if the index cannot be fetched and there is
+// no cached copy.
+var defaultIndex = Index{
+	Plugins: []IndexEntry{
+		{Name: "fake-data", Description: "Generates fake data, such as names and addresses, in responses"},
+		{Name: "js-graal", Description: "JavaScript scripting using the GraalVM engine", Format: "zip"},
+		{Name: "js-nashorn", Description: "JavaScript scripting using the Nashorn engine"},
+		{Name: "store-dynamodb", Description: "Stores backed by AWS DynamoDB"},
+		{Name: "store-graphql", Description: "GraphQL queries against stores"},
+		{Name: "store-redis", Description: "Stores backed by Redis"},
+		{Name: "wiremock", Description: "Mocks using WireMock mapping files"},
+	},
+}
+
+// InstallName returns the name used to install the plugin, which
+// includes the archive format, if it is not the default.
+func (e IndexEntry) InstallName() string {
+	if e.Format == "zip" {
+		return e.Name + ":zip"
+	}
+	return e.Name
+}
+
+// LoadIndex returns the plugin index. If no index URL is configured with
+// the `plugin.indexUrl` key, the built-in index is used. Otherwise, the
+// cached copy is used if it is recent enough, unless refresh is true. If
+// the index cannot be fetched, any cached copy is used, regardless of
+// age, falling back to the built-in index.
+func LoadIndex(refresh bool) (*Index, error) {
+	indexUrl := viper.GetString("plugin.indexUrl")
+	if indexUrl == "" {
+		logger.Tracef("no plugin index URL configured - using built-in plugin index")
+		return &defaultIndex, nil
+	}
+	indexPath, err := getIndexPath()
+	if err != nil {
+		return nil, err
+	}
+	if !refresh {
+		if info, err := os.Stat(indexPath); err == nil && time.Since(info.ModTime()) < indexMaxAge {
+			index, err := readIndex(indexPath)
+			if err == nil {
+				logger.Tracef("using cached plugin index: %s", indexPath)
+				return index, nil
+			}
+			logger.Debugf("ignoring cached plugin index: %s", err)
+		}
+	}
+
+	index, err := fetchIndex(indexUrl, indexPath)
+	if err == nil {
+		return index, nil
+	}
+	logger.Debugf("failed to fetch plugin index: %s", err)
+
+	if cached, cacheErr := readIndex(indexPath); cacheErr == nil {
+		logger.Debugf("using stale cached plugin index: %s", indexPath)
+		return cached, nil
+	}
+	logger.Debugf("using built-in plugin index")
+	return &defaultIndex, nil
+}
+
+func getIndexPath() (string, error) {
+	basePluginDir, err := GetBasePluginDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(basePluginDir, indexFileName), nil
+}
+
+func readIndex(indexPath string) (*Index, error) {
+	data, err := os.ReadFile(indexPath)
+	if err != nil {
+		return nil, err
+	}
+	return parseIndex(data)
+}
+
+func parseIndex(data []byte) (*Index, error) {
+	var index Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse plugin index: %s", err)
+	}
+	return &index, nil
+}
+
+// fetchIndex downloads the index from indexUrl, and caches it at indexPath.
+func fetchIndex(indexUrl string, indexPath string) (*Index, error) {
+	logger.Tracef("fetching plugin index from: %s", indexUrl)
+	resp, err := http.Get(indexUrl)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading from: %v: %v", indexUrl, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("error downloading from: %v: status code: %d", indexUrl, resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading plugin index from: %v: %v", indexUrl, err)
+	}
+	index, err := parseIndex(data)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.WriteFile(indexPath, data, 0644); err != nil {
+		logger.Warnf("failed to cache plugin index: %s", err)
+	}
+	return index, nil
+}
+
+// Find returns the entry for the named plugin, ignoring any archive
+// format suffix, such as `:zip`.
+func (i *Index) Find(pluginName string) (IndexEntry, bool) {
+	pluginName = strings.TrimSuffix(pluginName, ":zip")
+	for _, entry := range i.Plugins {
+		if entry.Name == pluginName {
+			return entry, true
+		}
+	}
+	return IndexEntry{}, false
+}
+
+// Search returns the entries whose name or description contains the
+// query, ignoring case, sorted by name. An empty query matches all entries.
+func (i *Index) Search(query string) []IndexEntry {
+	query = strings.ToLower(query)
+	var matches []IndexEntry
+	for _, entry := range i.Plugins {
+		if strings.Contains(strings.ToLower(entry.Name), query) || strings.Contains(strings.ToLower(entry.Description), query) {
+			matches = append(matches, entry)
+		}
+	}
+	sort.Slice(matches, func(a, b int) bool {
+		return matches[a].Name < matches[b].Name
+	})
+	return matches
+}
+
+// Suggest returns the name of the indexed plugin closest to the
+// given name, or the empty string if none are close enough.
+func (i *Index) Suggest(pluginName string) string {
+	pluginName = strings.TrimSuffix(pluginName, ":zip")
+	var suggestion string
+	closest := maxSuggestionDistance + 1
+	for _, entry := range i.Plugins {
+		if distance := stringutil.EditDistance(pluginName, entry.Name); distance < closest {
+			suggestion = entry.Name
+			closest = distance
+		}
+	}
+	return suggestion
+}
+
+// suggestPlugin returns the install name of the indexed plugin closest
+// to the given name, if the name is not itself in the index, such as
+// when it is misspelt. Otherwise, it returns the empty string.
+func suggestPlugin(pluginName string) string {
+	index, err := LoadIndex(false)
+	if err != nil {
+		logger.Debugf("failed to load plugin index: %s", err)
+		return ""
+	}
+	if _, found := index.Find(pluginName); found {
+		return ""
+	}
+	if entry, found := index.Find(index.Suggest(pluginName)); found {
+		return entry.InstallName()
+	}
+	return ""
+}
@@ -0,0 +1,148 @@
+package plugin
+
+import (
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestIndex_Find(t *testing.T) {
+	entry, found := defaultIndex.Find("js-graal:zip")
+	require.True(t, found)
+	require.Equal(t, "js-graal", entry.Name)
+	require.Equal(t, "js-graal:zip", entry.InstallName())
+
+	_, found = defaultIndex.Find("does-not-exist")
+	require.False(t, found)
+}
+
+func TestIndex_Search(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{name: "match name", query: "store-", want: []string{"store-dynamodb", "store-graphql", "store-redis"}},
+		{name: "match description ignoring case", query: "dynamodb", want: []string{"store-dynamodb"}},
+		{name: "match all", query: "", want: []string{"fake-data", "js-graal", "js-nashorn", "store-dynamodb", "store-graphql", "store-redis", "wiremock"}},
+		{name: "no match", query: "nothing", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+			for _, entry := range defaultIndex.Search(tt.query) {
+				names = append(names, entry.Name)
+			}
+			require.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestIndex_Suggest(t *testing.T) {
+	require.Equal(t, "store-redis", defaultIndex.Suggest("stor-redis"))
+	require.Equal(t, "store-redis", defaultIndex.Suggest("store-rdis:zip"))
+	require.Equal(t, "", defaultIndex.Suggest("something-else"))
+}
+
+func TestLoadIndex(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"plugins":[{"name":"store-example","description":"Example store"}]}`))
+	}))
+	defer server.Close()
+
+	baseDir := t.TempDir()
+	viper.Set("plugin.baseDir", baseDir)
+	viper.Set("plugin.indexUrl", server.URL)
+	t.Cleanup(func() {
+		viper.Set("plugin.baseDir", "")
+		viper.Set("plugin.indexUrl", "")
+	})
+
+	index, err := LoadIndex(false)
+	require.NoError(t, err)
+	_, found := index.Find("store-example")
+	require.True(t, found, "fetched index should be used")
+	require.FileExists(t, filepath.Join(baseDir, indexFileName))
+
+	_, err = LoadIndex(false)
+	require.NoError(t, err)
+	require.Equal(t, 1, requests, "cached index should be used")
+
+	_, err = LoadIndex(true)
+	require.NoError(t, err)
+	require.Equal(t, 2, requests, "index should be refreshed")
+
+	// a stale cached copy is used if the index cannot be fetched
+	stale := time.Now().Add(-2 * indexMaxAge)
+	require.NoError(t, os.Chtimes(filepath.Join(baseDir, indexFileName), stale, stale))
+	server.Close()
+	index, err = LoadIndex(false)
+	require.NoError(t, err)
+	_, found = index.Find("store-example")
+	require.True(t, found, "stale cached index should be used")
+
+	// the built-in index is used if there is no cached copy
+	require.NoError(t, os.Remove(filepath.Join(baseDir, indexFileName)))
+	index, err = LoadIndex(false)
+	require.NoError(t, err)
+	_, found = index.Find("store-redis")
+	require.True(t, found, "built-in index should be used")
+
+	// nothing is fetched if no index URL is configured
+	viper.Set("plugin.indexUrl", "")
+	index, err = LoadIndex(true)
+	require.NoError(t, err)
+	require.Equal(t, &defaultIndex, index)
+	require.NoFileExists(t, filepath.Join(baseDir, indexFileName))
+}
+
+func TestEnsurePlugin_Suggestion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	viper.Set("plugin.dir", t.TempDir())
+	viper.Set("plugin.baseDir", t.TempDir())
+	viper.Set("download.mirror", server.URL)
+	t.Cleanup(func() {
+		viper.Set("plugin.dir", "")
+		viper.Set("plugin.baseDir", "")
+		viper.Set("download.mirror", "")
+	})
+
+	err := EnsurePlugin("stor-redis", "4.2.2")
+	require.EqualError(t, err, `unknown plugin "stor-redis" - did you mean "store-redis"?`)
+
+	err = EnsurePlugin("js-grall", "4.2.2")
+	require.EqualError(t, err, `unknown plugin "js-grall" - did you mean "js-graal:zip"?`)
+
+	// indexed plugins that are not published for the version are not misspelt
+	err = EnsurePlugin("store-redis", "4.2.2")
+	require.ErrorContains(t, err, "status code: 404")
+}
+
+func TestRemove(t *testing.T) {
+	pluginDir := t.TempDir()
+	viper.Set("plugin.dir", pluginDir)
+	t.Cleanup(func() {
+		viper.Set("plugin.dir", "")
+	})
+	require.NoError(t, os.WriteFile(filepath.Join(pluginDir, "imposter-plugin-store-redis.jar"), []byte("test"), 0644))
+
+	removed, err := Remove("store-redis", "4.2.2")
+	require.NoError(t, err)
+	require.Equal(t, 1, removed)
+	require.NoFileExists(t, filepath.Join(pluginDir, "imposter-plugin-store-redis.jar"))
+
+	removed, err = Remove("store-redis", "4.2.2")
+	require.NoError(t, err)
+	require.Equal(t, 0, removed)
+}
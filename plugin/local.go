@@ -0,0 +1,206 @@
+package plugin
+
+import (
+	"archive/zip"
+	"fmt"
+	"gatehill.io/imposter/fileutil"
+	"gatehill.io/imposter/library"
+	"gatehill.io/imposter/stringutil"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// IsLocalOrRemoteSource returns true if the argument refers to a plugin
+// file or URL, rather than the name of a published plugin.
+func IsLocalOrRemoteSource(arg string) bool {
+	return isRemoteSource(arg) || stringutil.GetMatchingSuffix(arg, supportedPluginExtensions) != "" || strings.ContainsRune(arg, os.PathSeparator)
+}
+
+func isRemoteSource(arg string) bool {
+	return strings.HasPrefix(arg, "http://") || strings.HasPrefix(arg, "https://")
+}
+
+// InstallFromSource installs the plugin archive at the given path or URL
+// into the versioned plugin directory, returning the plugin name. If link
+// is true, the plugin file is a symlink to the local file, so a rebuilt
+// archive is used without reinstalling.
+func InstallFromSource(source string, version string, link bool) (string, error) {
+	var fileName string
+	if isRemoteSource(source) {
+		u, err := url.Parse(source)
+		if err != nil {
+			return "", fmt.Errorf("invalid plugin URL: %s: %v", source, err)
+		}
+		fileName = path.Base(u.Path)
+	} else {
+		fileName = filepath.Base(source)
+	}
+	extension := stringutil.GetMatchingSuffix(fileName, supportedPluginExtensions)
+	if extension == "" {
+		return "", fmt.Errorf("unsupported plugin file: %s - must be one of: %v", fileName, supportedPluginExtensions)
+	}
+	pluginName := strings.TrimPrefix(strings.TrimSuffix(fileName, extension), "imposter-plugin-")
+
+	pluginDir, err := EnsurePluginDir(version)
+	if err != nil {
+		return "", err
+	}
+	pluginFilePath := filepath.Join(pluginDir, "imposter-plugin-"+pluginName+extension)
+
+	if isRemoteSource(source) {
+		if link {
+			return "", fmt.Errorf("cannot link to a remote plugin: %s", source)
+		}
+		err = installFromUrl(source, pluginFilePath)
+	} else {
+		err = installFromFile(source, pluginFilePath, link)
+	}
+	if err != nil {
+		return "", err
+	}
+	if link {
+		logger.Infof("linked plugin %s version %s to %s", pluginName, version, source)
+	} else {
+		logger.Infof("installed plugin %s version %s from %s", pluginName, version, source)
+	}
+	return pluginName, nil
+}
+
+func installFromFile(source string, pluginFilePath string, link bool) error {
+	absSource, err := filepath.Abs(source)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path of: %s: %v", source, err)
+	}
+	if err = validateArchive(absSource); err != nil {
+		return err
+	}
+	if link {
+		return replacePluginFile(pluginFilePath, func(tempPath string) error {
+			// the link replaces the placeholder temporary file
+			_ = os.Remove(tempPath)
+			if err := os.Symlink(absSource, tempPath); err != nil {
+				return fmt.Errorf("error linking plugin file: %v: %v", absSource, err)
+			}
+			return nil
+		})
+	}
+	err = replacePluginFile(pluginFilePath, func(tempPath string) error {
+		return fileutil.CopyFile(absSource, tempPath)
+	})
+	if err != nil {
+		return err
+	}
+	return library.RecordChecksum(pluginFilePath, absSource, false)
+}
+
+func installFromUrl(source string, pluginFilePath string) error {
+	logger.Debugf("downloading %v", source)
+	resp, err := http.Get(source)
+	if err != nil {
+		return fmt.Errorf("error downloading from: %v: %v", source, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("error downloading from: %v: status code: %d", source, resp.StatusCode)
+	}
+
+	// download to a temporary file, so an invalid archive is not installed
+	err = replacePluginFile(pluginFilePath, func(tempPath string) error {
+		tempFile, err := os.OpenFile(tempPath, os.O_WRONLY|os.O_TRUNC, 0644)
+		if err != nil {
+			return fmt.Errorf("error opening file: %v: %v", tempPath, err)
+		}
+		_, err = io.Copy(tempFile, resp.Body)
+		_ = tempFile.Close()
+		if err != nil {
+			return fmt.Errorf("error writing file: %v: %v", tempPath, err)
+		}
+		return validateArchive(tempPath)
+	})
+	if err != nil {
+		return err
+	}
+	return library.RecordChecksum(pluginFilePath, source, false)
+}
+
+// replacePluginFile calls write with the path of a temporary file in the
+// plugin directory, then moves it over any existing plugin file. If write
+// fails, the existing plugin file is left in place.
+func replacePluginFile(pluginFilePath string, write func(tempPath string) error) error {
+	tempFile, err := os.CreateTemp(filepath.Dir(pluginFilePath), ".install-*")
+	if err != nil {
+		return fmt.Errorf("error creating temporary file: %v", err)
+	}
+	tempPath := tempFile.Name()
+	_ = tempFile.Close()
+	defer os.Remove(tempPath)
+
+	if err = write(tempPath); err != nil {
+		return err
+	}
+	if _, err := os.Lstat(pluginFilePath); err == nil {
+		logger.Debugf("replacing existing plugin file: %s", pluginFilePath)
+	}
+	if err = os.Rename(tempPath, pluginFilePath); err != nil {
+		return fmt.Errorf("error moving plugin file to: %v: %v", pluginFilePath, err)
+	}
+	return nil
+}
+
+// validateArchive checks that the plugin file is a non-empty zip archive,
+// which includes JAR files.
+func validateArchive(filePath string) error {
+	reader, err := zip.OpenReader(filePath)
+	if err != nil {
+		return fmt.Errorf("invalid plugin archive: %s: %v", filePath, err)
+	}
+	defer reader.Close()
+	if len(reader.File) == 0 {
+		return fmt.Errorf("invalid plugin archive: %s: archive is empty", filePath)
+	}
+	return nil
+}
+
+// ListLinkedPlugins returns the plugins used by the mock in the config dir
+// that are linked to local files, keyed by plugin file name, with the path
+// of the linked file as the value.
+func ListLinkedPlugins(configDir string, version string) (map[string]string, error) {
+	pluginDir, err := getFullPluginDir(version)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(pluginDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading plugin directory: %v: %v", pluginDir, err)
+	}
+	_, declared := ListProjectPlugins(configDir)
+	var projectFileNames []string
+	for _, pluginName := range ListMockPlugins(configDir) {
+		fullPluginFileName, _, err := getPluginFilePath(pluginName, version)
+		if err != nil {
+			return nil, err
+		}
+		projectFileNames = append(projectFileNames, fullPluginFileName)
+	}
+
+	linked := make(map[string]string)
+	for _, entry := range entries {
+		if entry.Type()&os.ModeSymlink == 0 || (declared && !stringutil.Contains(projectFileNames, entry.Name())) {
+			continue
+		}
+		target, err := os.Readlink(filepath.Join(pluginDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("error reading plugin link: %v: %v", entry.Name(), err)
+		}
+		linked[entry.Name()] = target
+	}
+	return linked, nil
+}
@@ -0,0 +1,128 @@
+package plugin
+
+import (
+	"archive/zip"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsLocalOrRemoteSource(t *testing.T) {
+	tests := []struct {
+		arg  string
+		want bool
+	}{
+		{arg: "store-redis", want: false},
+		{arg: "js-graal:zip", want: false},
+		{arg: "my-plugin.jar", want: true},
+		{arg: "./target/my-plugin.jar", want: true},
+		{arg: "target/my-plugin", want: true},
+		{arg: "https://example.com/my-plugin.zip", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			require.Equal(t, tt.want, IsLocalOrRemoteSource(tt.arg))
+		})
+	}
+}
+
+func TestInstallFromSource(t *testing.T) {
+	pluginDir := t.TempDir()
+	viper.Set("plugin.dir", pluginDir)
+	t.Cleanup(func() {
+		viper.Set("plugin.dir", "")
+	})
+	sourceDir := t.TempDir()
+	archivePath := writeTestArchive(t, filepath.Join(sourceDir, "my-plugin.jar"))
+
+	t.Run("copy local file", func(t *testing.T) {
+		pluginName, err := InstallFromSource(archivePath, "4.2.2", false)
+		require.NoError(t, err)
+		require.Equal(t, "my-plugin", pluginName)
+
+		info, err := os.Lstat(filepath.Join(pluginDir, "imposter-plugin-my-plugin.jar"))
+		require.NoError(t, err)
+		require.True(t, info.Mode().IsRegular(), "plugin file should be a copy")
+	})
+
+	t.Run("link local file", func(t *testing.T) {
+		_, err := InstallFromSource(archivePath, "4.2.2", true)
+		require.NoError(t, err)
+
+		target, err := os.Readlink(filepath.Join(pluginDir, "imposter-plugin-my-plugin.jar"))
+		require.NoError(t, err)
+		require.Equal(t, archivePath, target)
+
+		linked, err := ListLinkedPlugins(t.TempDir(), "4.2.2")
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"imposter-plugin-my-plugin.jar": archivePath}, linked)
+	})
+
+	t.Run("download from URL", func(t *testing.T) {
+		archive, err := os.ReadFile(archivePath)
+		require.NoError(t, err)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(archive)
+		}))
+		defer server.Close()
+
+		pluginName, err := InstallFromSource(server.URL+"/imposter-plugin-remote.zip?token=abc", "4.2.2", false)
+		require.NoError(t, err)
+		require.Equal(t, "remote", pluginName)
+		require.FileExists(t, filepath.Join(pluginDir, "imposter-plugin-remote.zip"))
+	})
+
+	t.Run("reject invalid archive", func(t *testing.T) {
+		invalidPath := filepath.Join(sourceDir, "invalid.jar")
+		require.NoError(t, os.WriteFile(invalidPath, []byte("not an archive"), 0644))
+
+		_, err := InstallFromSource(invalidPath, "4.2.2", false)
+		require.ErrorContains(t, err, "invalid plugin archive")
+		require.NoFileExists(t, filepath.Join(pluginDir, "imposter-plugin-invalid.jar"))
+	})
+
+	t.Run("keep existing plugin if replacement is invalid", func(t *testing.T) {
+		invalidPath := filepath.Join(t.TempDir(), "my-plugin.jar")
+		require.NoError(t, os.WriteFile(invalidPath, []byte("not an archive"), 0644))
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		_, err := InstallFromSource(archivePath, "4.2.2", false)
+		require.NoError(t, err)
+		_, err = InstallFromSource(invalidPath, "4.2.2", false)
+		require.ErrorContains(t, err, "invalid plugin archive")
+		_, err = InstallFromSource(server.URL+"/my-plugin.jar", "4.2.2", false)
+		require.ErrorContains(t, err, "status code: 404")
+
+		require.NoError(t, validateArchive(filepath.Join(pluginDir, "imposter-plugin-my-plugin.jar")))
+		entries, err := os.ReadDir(pluginDir)
+		require.NoError(t, err)
+		for _, entry := range entries {
+			require.NotContains(t, entry.Name(), ".install-", "temporary file should be removed")
+		}
+	})
+
+	t.Run("reject unsupported file", func(t *testing.T) {
+		_, err := InstallFromSource(filepath.Join(sourceDir, "my-plugin.txt"), "4.2.2", false)
+		require.ErrorContains(t, err, "unsupported plugin file")
+	})
+}
+
+func writeTestArchive(t *testing.T, archivePath string) string {
+	file, err := os.Create(archivePath)
+	require.NoError(t, err)
+	defer file.Close()
+	writer := zip.NewWriter(file)
+	entry, err := writer.Create("META-INF/MANIFEST.MF")
+	require.NoError(t, err)
+	_, err = entry.Write([]byte("Manifest-Version: 1.0\n"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	return archivePath
+}
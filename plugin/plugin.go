@@ -49,12 +49,20 @@ func EnsurePlugins(plugins []string, version string, saveDefault bool) (int, err
 // config, as well those within the current configuration context, such
 // as config files within the working directory
 func EnsureConfiguredPlugins(version string) (int, error) {
+	return EnsurePlugins(ListConfiguredPlugins(), version, false)
+}
+
+// ListConfiguredPlugins returns the plugins from both the global CLI
+// config, as well those within the current configuration context,
+// including the project plugins.
+func ListConfiguredPlugins() []string {
 	// this includes the config from the current configuration context,
 	// not just the global CLI config file, so it includes any
 	// configuration in the working directory
-	plugins := viper.GetStringSlice(defaultPluginsConfigKey)
+	configured := append(viper.GetStringSlice(defaultPluginsConfigKey), viper.GetStringSlice(projectPluginsConfigKey)...)
 
-	for _, plugin := range plugins {
+	var plugins []string
+	for _, plugin := range configured {
 		// work-around for https://github.com/spf13/viper/issues/380
 		if strings.Contains(plugin, ",") {
 			for _, p := range strings.Split(plugin, ",") {
@@ -67,7 +75,7 @@ func EnsureConfiguredPlugins(version string) (int, error) {
 	plugins = stringutil.Unique(plugins)
 
 	logger.Tracef("found %d configured plugin(s): %v", len(plugins), plugins)
-	return EnsurePlugins(plugins, version, false)
+	return plugins
 }
 
 func EnsurePlugin(pluginName string, version string) error {
@@ -86,6 +94,11 @@ func EnsurePlugin(pluginName string, version string) error {
 	logger.Debugf("plugin %s version %s is not installed", pluginName, version)
 	err = downloadPlugin(pluginName, version)
 	if err != nil {
+		if library.IsNotFound(err) {
+			if suggestion := suggestPlugin(pluginName); suggestion != "" {
+				return fmt.Errorf("unknown plugin %q - did you mean %q?", pluginName, suggestion)
+			}
+		}
 		return err
 	}
 	return nil
@@ -108,7 +121,7 @@ func getFullPluginDir(version string) (dir string, err error) {
 	// use IMPOSTER_PLUGIN_DIR directly, if set
 	fullPluginDir := viper.GetString("plugin.dir")
 	if fullPluginDir == "" {
-		basePluginDir, err := getBasePluginDir()
+		basePluginDir, err := GetBasePluginDir()
 		if err != nil {
 			return "", err
 		}
@@ -117,7 +130,9 @@ func getFullPluginDir(version string) (dir string, err error) {
 	return fullPluginDir, nil
 }
 
-func getBasePluginDir() (string, error) {
+// GetBasePluginDir returns the directory holding the versioned
+// directories for plugin files.
+func GetBasePluginDir() (string, error) {
 	return library.EnsureDirUsingConfig("plugin.baseDir", pluginBaseDir)
 }
 
@@ -134,6 +149,13 @@ func downloadPlugin(pluginName string, version string) error {
 	return nil
 }
 
+// GetPluginFilePath returns the path at which the plugin file for the
+// given version is installed, whether or not it exists.
+func GetPluginFilePath(pluginName string, version string) (string, error) {
+	_, pluginFilePath, err := getPluginFilePath(pluginName, version)
+	return pluginFilePath, err
+}
+
 func getPluginFilePath(pluginName string, version string) (fullPluginFileName string, pluginFilePath string, err error) {
 	pluginDir, err := EnsurePluginDir(version)
 	if err != nil {
@@ -180,6 +202,27 @@ func ListDefaultPlugins() ([]string, error) {
 	}
 }
 
+// RemoveDefaultPlugin removes the plugin from the list of default
+// plugins, if present, and writes the configuration file. It returns
+// true if the plugin was a default plugin.
+func RemoveDefaultPlugin(pluginName string) (bool, error) {
+	existing, err := ListDefaultPlugins()
+	if err != nil {
+		return false, fmt.Errorf("failed to load default plugins: %s", err)
+	}
+	pluginName = strings.TrimSuffix(pluginName, ":zip")
+	var remaining []string
+	for _, p := range existing {
+		if strings.TrimSuffix(p, ":zip") != pluginName {
+			remaining = append(remaining, p)
+		}
+	}
+	if len(remaining) == len(existing) {
+		return false, nil
+	}
+	return true, writeDefaultPlugins(remaining)
+}
+
 func writeDefaultPlugins(plugins []string) error {
 	v, err := parseConfigFile()
 	if err != nil {
@@ -243,7 +286,7 @@ func List(version string) ([]PluginMetadata, error) {
 // the plugin base dir. This is only the list of versions, not fully qualified
 // paths.
 func ListVersionDirs() ([]string, error) {
-	basePluginDir, err := getBasePluginDir()
+	basePluginDir, err := GetBasePluginDir()
 	if err != nil {
 		return nil, err
 	}
@@ -260,3 +303,43 @@ func ListVersionDirs() ([]string, error) {
 	}
 	return dirs, nil
 }
+
+// RemoveVersionDir deletes the versioned directory under the plugin base
+// dir, and the plugins within it.
+func RemoveVersionDir(version string) error {
+	basePluginDir, err := GetBasePluginDir()
+	if err != nil {
+		return err
+	}
+	versionDir := filepath.Join(basePluginDir, version)
+	if err = os.RemoveAll(versionDir); err != nil {
+		return fmt.Errorf("error removing plugin directory: %v: %v", versionDir, err)
+	}
+	return nil
+}
+
+// Remove deletes the plugin files for the named plugin from the
+// versioned directory, returning the number of files removed.
+func Remove(pluginName string, version string) (int, error) {
+	pluginDir, err := getFullPluginDir(version)
+	if err != nil {
+		return 0, err
+	}
+	pluginName = strings.TrimSuffix(pluginName, ":zip")
+	var removed int
+	for _, extension := range supportedPluginExtensions {
+		pluginFilePath := filepath.Join(pluginDir, "imposter-plugin-"+pluginName+extension)
+		if err := os.Remove(pluginFilePath); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return removed, fmt.Errorf("error removing plugin file: %v: %v", pluginFilePath, err)
+		}
+		if err := library.ForgetChecksum(pluginFilePath); err != nil {
+			logger.Warnf("failed to remove checksum record for: %s: %s", pluginFilePath, err)
+		}
+		logger.Debugf("removed plugin file: %s", pluginFilePath)
+		removed++
+	}
+	return removed, nil
+}
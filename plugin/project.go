@@ -0,0 +1,137 @@
+package plugin
+
+import (
+	"fmt"
+	"gatehill.io/imposter/config"
+	"gatehill.io/imposter/fileutil"
+	"gatehill.io/imposter/library"
+	"gatehill.io/imposter/stringutil"
+	"github.com/spf13/viper"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const projectPluginsConfigKey = "plugins"
+const projectPluginBaseDir = ".imposter/project-plugins/"
+
+// ListProjectPlugins returns the plugins declared under the `plugins` key
+// in the `.imposter.yaml` file in the config dir. If the key is not set,
+// declared is false.
+func ListProjectPlugins(configDir string) (plugins []string, declared bool) {
+	v := viper.New()
+	v.AddConfigPath(configDir)
+	v.SetConfigName(config.LocalDirConfigFileName)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, false
+	}
+	if !v.IsSet(projectPluginsConfigKey) {
+		return nil, false
+	}
+	for _, p := range v.GetStringSlice(projectPluginsConfigKey) {
+		// work-around for https://github.com/spf13/viper/issues/380
+		plugins = append(plugins, strings.Split(p, ",")...)
+	}
+	return stringutil.Unique(plugins), true
+}
+
+// ListMockPlugins returns the plugins used by the mock in the config dir.
+// These are the configured plugins, including the default plugins, and
+// any plugins declared in its `.imposter.yaml` file.
+func ListMockPlugins(configDir string) []string {
+	projectPlugins, _ := ListProjectPlugins(configDir)
+	return stringutil.Unique(append(ListConfiguredPlugins(), projectPlugins...))
+}
+
+// EnsureMockPluginFiles installs the plugins used by the mock in the config
+// dir, if required, returning the paths of their files.
+func EnsureMockPluginFiles(configDir string, version string) ([]string, error) {
+	plugins := ListMockPlugins(configDir)
+	if _, err := EnsurePlugins(plugins, version, false); err != nil {
+		return nil, err
+	}
+	var pluginFiles []string
+	for _, pluginName := range plugins {
+		pluginFile, err := GetPluginFilePath(pluginName, version)
+		if err != nil {
+			return nil, err
+		}
+		pluginFiles = append(pluginFiles, pluginFile)
+	}
+	logger.Debugf("bundling %d plugin(s): %v", len(plugins), plugins)
+	return pluginFiles, nil
+}
+
+// EnsureMockPluginDir returns the plugin directory for the mock in the
+// config dir. If its `.imposter.yaml` file declares plugins, this is a
+// directory holding only the plugins returned by ListMockPlugins, linked
+// from the shared versioned directory, so the mock does not load plugins
+// installed for other projects. Otherwise, it is the shared versioned
+// directory.
+func EnsureMockPluginDir(configDir string, version string) (string, error) {
+	if _, declared := ListProjectPlugins(configDir); !declared {
+		return EnsurePluginDir(version)
+	}
+	plugins := ListMockPlugins(configDir)
+	projectDir, err := getProjectPluginDir(configDir, version)
+	if err != nil {
+		return "", err
+	}
+	if err = os.RemoveAll(projectDir); err != nil {
+		return "", fmt.Errorf("error clearing project plugin directory: %v: %v", projectDir, err)
+	}
+	if err = library.EnsureDir(projectDir); err != nil {
+		return "", err
+	}
+
+	for _, pluginName := range plugins {
+		fullPluginFileName, pluginFilePath, err := getPluginFilePath(pluginName, version)
+		if err != nil {
+			return "", err
+		}
+		if _, err := os.Stat(pluginFilePath); err != nil {
+			return "", fmt.Errorf("plugin %s version %s is used by the mock in %s but is not installed - run: imposter plugin install %s", pluginName, version, configDir, pluginName)
+		}
+		if err = linkOrCopy(pluginFilePath, filepath.Join(projectDir, fullPluginFileName)); err != nil {
+			return "", err
+		}
+	}
+	logger.Debugf("using %d project plugin(s) for %s: %v", len(plugins), configDir, plugins)
+	return projectDir, nil
+}
+
+// getProjectPluginDir returns the versioned plugin directory for the
+// project in the config dir.
+func getProjectPluginDir(configDir string, version string) (string, error) {
+	absConfigDir, err := filepath.Abs(configDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path of: %s: %v", configDir, err)
+	}
+	baseDir, err := library.GetDirPath("plugin.projectDir", projectPluginBaseDir)
+	if err != nil {
+		return "", err
+	}
+	projectId := stringutil.Sha1hashString(absConfigDir)[:12]
+	return filepath.Join(baseDir, projectId, version), nil
+}
+
+// linkOrCopy hard links the file, falling back to a copy, such as when the
+// destination is on a different filesystem. Hard links are used instead of
+// symlinks so the directory can be bind mounted into a container. Plugins
+// that are themselves symlinks, such as those installed with `--link`,
+// are linked to the same file.
+func linkOrCopy(src string, dest string) error {
+	if info, err := os.Lstat(src); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(src)
+		if err != nil {
+			return fmt.Errorf("error reading plugin link: %v: %v", src, err)
+		}
+		return os.Symlink(target, dest)
+	}
+	err := os.Link(src, dest)
+	if err == nil {
+		return nil
+	}
+	logger.Tracef("failed to link %s to %s - copying instead: %s", src, dest, err)
+	return fileutil.CopyFile(src, dest)
+}
@@ -0,0 +1,94 @@
+package plugin
+
+import (
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestListProjectPlugins(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       string
+		wantPlugins  []string
+		wantDeclared bool
+	}{
+		{name: "no config file", config: "", wantPlugins: nil, wantDeclared: false},
+		{name: "no plugins declared", config: "engine: jvm\n", wantPlugins: nil, wantDeclared: false},
+		{name: "empty plugins", config: "plugins: []\n", wantPlugins: nil, wantDeclared: true},
+		{name: "plugins", config: "plugins:\n  - store-redis\n  - js-graal:zip\n", wantPlugins: []string{"store-redis", "js-graal:zip"}, wantDeclared: true},
+		{name: "default plugins", config: "default:\n  plugins:\n    - store-redis\n", wantPlugins: nil, wantDeclared: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configDir := t.TempDir()
+			if tt.config != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(configDir, ".imposter.yaml"), []byte(tt.config), 0644))
+			}
+			plugins, declared := ListProjectPlugins(configDir)
+			require.Equal(t, tt.wantDeclared, declared)
+			require.Equal(t, tt.wantPlugins, plugins)
+		})
+	}
+}
+
+func TestEnsureMockPluginDir(t *testing.T) {
+	sharedDir := t.TempDir()
+	viper.Set("plugin.dir", sharedDir)
+	viper.Set("plugin.projectDir", t.TempDir())
+	t.Cleanup(func() {
+		viper.Set("plugin.dir", "")
+		viper.Set("plugin.projectDir", "")
+	})
+	for _, name := range []string{"imposter-plugin-store-redis.jar", "imposter-plugin-store-dynamodb.jar"} {
+		require.NoError(t, os.WriteFile(filepath.Join(sharedDir, name), []byte("test"), 0644))
+	}
+
+	t.Run("shared dir if no plugins declared", func(t *testing.T) {
+		pluginDir, err := EnsureMockPluginDir(t.TempDir(), "4.2.2")
+		require.NoError(t, err)
+		require.Equal(t, sharedDir, pluginDir)
+	})
+
+	t.Run("project dir holds only declared plugins", func(t *testing.T) {
+		configDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(configDir, ".imposter.yaml"), []byte("plugins:\n  - store-redis\n"), 0644))
+
+		pluginDir, err := EnsureMockPluginDir(configDir, "4.2.2")
+		require.NoError(t, err)
+		require.NotEqual(t, sharedDir, pluginDir)
+
+		entries, err := os.ReadDir(pluginDir)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		require.Equal(t, "imposter-plugin-store-redis.jar", entries[0].Name())
+	})
+
+	t.Run("project dir includes default plugins", func(t *testing.T) {
+		viper.Set("default.plugins", []string{"store-dynamodb"})
+		t.Cleanup(func() { viper.Set("default.plugins", nil) })
+		configDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(configDir, ".imposter.yaml"), []byte("plugins:\n  - store-redis\n"), 0644))
+
+		pluginDir, err := EnsureMockPluginDir(configDir, "4.2.2")
+		require.NoError(t, err)
+
+		entries, err := os.ReadDir(pluginDir)
+		require.NoError(t, err)
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		require.ElementsMatch(t, []string{"imposter-plugin-store-redis.jar", "imposter-plugin-store-dynamodb.jar"}, names)
+	})
+
+	t.Run("error if declared plugin is not installed", func(t *testing.T) {
+		configDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(configDir, ".imposter.yaml"), []byte("plugins:\n  - wiremock\n"), 0644))
+
+		_, err := EnsureMockPluginDir(configDir, "4.2.2")
+		require.ErrorContains(t, err, "not installed")
+	})
+}
@@ -0,0 +1,87 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"io"
+)
+
+// grpcFrameHeaderLength is the size of the compressed-flag and
+// message-length prefix on each gRPC message.
+const grpcFrameHeaderLength = 5
+
+// DecodeGrpcMessages decodes the length-prefixed messages in a gRPC request
+// or response body to JSON. A single message is returned as a JSON object;
+// multiple messages, such as from a streaming call, as a JSON array.
+func DecodeGrpcMessages(body []byte, md protoreflect.MessageDescriptor) ([]byte, error) {
+	var messages []json.RawMessage
+	for len(body) > 0 {
+		if len(body) < grpcFrameHeaderLength {
+			return nil, fmt.Errorf("truncated gRPC message frame for %s", md.FullName())
+		}
+		compressed := body[0] == 1
+		length := binary.BigEndian.Uint32(body[1:grpcFrameHeaderLength])
+		if uint64(len(body)-grpcFrameHeaderLength) < uint64(length) {
+			return nil, fmt.Errorf("truncated gRPC message for %s", md.FullName())
+		}
+		data := body[grpcFrameHeaderLength : grpcFrameHeaderLength+length]
+		body = body[grpcFrameHeaderLength+length:]
+
+		if compressed {
+			decompressed, err := gunzip(data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decompress gRPC message for %s: %v", md.FullName(), err)
+			}
+			data = decompressed
+		}
+		msg := dynamicpb.NewMessage(md)
+		if err := proto.Unmarshal(data, msg); err != nil {
+			return nil, fmt.Errorf("failed to decode gRPC message as %s: %v", md.FullName(), err)
+		}
+		j, err := protojson.Marshal(msg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert gRPC message %s to JSON: %v", md.FullName(), err)
+		}
+		messages = append(messages, j)
+	}
+
+	var decoded interface{}
+	if len(messages) == 1 {
+		decoded = messages[0]
+	} else {
+		decoded = messages
+	}
+	return json.MarshalIndent(decoded, "", "  ")
+}
+
+func gunzip(data []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
@@ -0,0 +1,50 @@
+package protobuf
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"testing"
+)
+
+func TestDecodeGrpcMessages(t *testing.T) {
+	method := findTestMethod(t, "/orders.OrderService/GetOrder")
+
+	req := dynamicpb.NewMessage(method.Input)
+	req.Set(method.Input.Fields().ByName("id"), protoreflect.ValueOfString("abc"))
+	data, err := proto.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := DecodeGrpcMessages(buildGrpcFrame(data), method.Input)
+	if err != nil {
+		t.Fatalf("DecodeGrpcMessages() error = %v", err)
+	}
+	var single map[string]string
+	if err = json.Unmarshal(decoded, &single); err != nil || single["id"] != "abc" {
+		t.Errorf("DecodeGrpcMessages() = %s, want single object with id abc", decoded)
+	}
+
+	stream := append(buildGrpcFrame(data), buildGrpcFrame(data)...)
+	decoded, err = DecodeGrpcMessages(stream, method.Input)
+	if err != nil {
+		t.Fatalf("DecodeGrpcMessages() error = %v", err)
+	}
+	var multiple []map[string]string
+	if err = json.Unmarshal(decoded, &multiple); err != nil || len(multiple) != 2 {
+		t.Errorf("DecodeGrpcMessages() = %s, want array of two objects", decoded)
+	}
+
+	if _, err = DecodeGrpcMessages(buildGrpcFrame(data)[:7], method.Input); err == nil {
+		t.Errorf("DecodeGrpcMessages() should fail for truncated message")
+	}
+}
+
+func buildGrpcFrame(data []byte) []byte {
+	frame := make([]byte, 5, 5+len(data))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(data)))
+	return append(frame, data...)
+}
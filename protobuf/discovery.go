@@ -0,0 +1,35 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"gatehill.io/imposter/fileutil"
+	"gatehill.io/imposter/logging"
+	"path/filepath"
+)
+
+var logger = logging.GetLogger()
+
+// DiscoverProtoFiles finds protobuf definition files within the given
+// directory. It returns fully qualified paths to the files discovered.
+func DiscoverProtoFiles(configDir string) []string {
+	var protoFiles []string
+	for _, candidate := range fileutil.FindFilesWithExtension(configDir, ".proto") {
+		protoFiles = append(protoFiles, filepath.Join(configDir, candidate))
+	}
+	return protoFiles
+}
@@ -0,0 +1,13 @@
+package protobuf
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDiscoverProtoFiles(t *testing.T) {
+	protoFiles := DiscoverProtoFiles("testdata")
+	if len(protoFiles) != 1 || filepath.Base(protoFiles[0]) != "order_service.proto" {
+		t.Errorf("DiscoverProtoFiles() = %v, want [order_service.proto]", protoFiles)
+	}
+}
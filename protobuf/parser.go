@@ -0,0 +1,120 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"context"
+	"fmt"
+	"github.com/bufbuild/protocompile"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"path/filepath"
+)
+
+// Method describes a single RPC within a gRPC service.
+type Method struct {
+	// Service is the fully qualified service name, such as "orders.OrderService"
+	Service string
+	Name    string
+
+	// Path is the HTTP/2 request path for the method, such as "/orders.OrderService/GetOrder"
+	Path string
+
+	Input           protoreflect.MessageDescriptor
+	Output          protoreflect.MessageDescriptor
+	ClientStreaming bool
+	ServerStreaming bool
+}
+
+// Registry holds the methods from a set of protobuf files,
+// indexed by their request path.
+type Registry struct {
+	methods map[string]Method
+}
+
+// Parse compiles the given protobuf files. Imports are resolved relative to
+// each file's directory, then the importPaths, then the well-known types.
+func Parse(protoFiles []string, importPaths []string) ([]protoreflect.FileDescriptor, error) {
+	var descriptors []protoreflect.FileDescriptor
+	for _, protoFile := range protoFiles {
+		absPath, err := filepath.Abs(protoFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get absolute path for: %s: %v", protoFile, err)
+		}
+		compiler := protocompile.Compiler{
+			Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{
+				ImportPaths: append([]string{filepath.Dir(absPath)}, importPaths...),
+			}),
+		}
+		files, err := compiler.Compile(context.Background(), filepath.Base(absPath))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse protobuf file: %s: %v", protoFile, err)
+		}
+		for _, f := range files {
+			descriptors = append(descriptors, f)
+		}
+	}
+	logger.Tracef("parsed %d protobuf file(s)", len(descriptors))
+	return descriptors, nil
+}
+
+// ListMethods returns the methods of all services in the given files.
+func ListMethods(files []protoreflect.FileDescriptor) []Method {
+	var methods []Method
+	for _, f := range files {
+		services := f.Services()
+		for i := 0; i < services.Len(); i++ {
+			service := services.Get(i)
+			serviceMethods := service.Methods()
+			for j := 0; j < serviceMethods.Len(); j++ {
+				m := serviceMethods.Get(j)
+				methods = append(methods, Method{
+					Service:         string(service.FullName()),
+					Name:            string(m.Name()),
+					Path:            fmt.Sprintf("/%s/%s", service.FullName(), m.Name()),
+					Input:           m.Input(),
+					Output:          m.Output(),
+					ClientStreaming: m.IsStreamingClient(),
+					ServerStreaming: m.IsStreamingServer(),
+				})
+			}
+		}
+	}
+	return methods
+}
+
+// LoadRegistry parses the given protobuf files and indexes their methods.
+func LoadRegistry(protoFiles []string, importPaths []string) (*Registry, error) {
+	files, err := Parse(protoFiles, importPaths)
+	if err != nil {
+		return nil, err
+	}
+	registry := &Registry{methods: make(map[string]Method)}
+	for _, method := range ListMethods(files) {
+		registry.methods[method.Path] = method
+	}
+	logger.Debugf("loaded %d gRPC method(s) from %d protobuf file(s)", len(registry.methods), len(protoFiles))
+	return registry, nil
+}
+
+// FindMethod returns the method for the given request path, if known.
+func (r *Registry) FindMethod(path string) (Method, bool) {
+	if r == nil {
+		return Method{}, false
+	}
+	method, found := r.methods[path]
+	return method, found
+}
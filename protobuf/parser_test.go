@@ -0,0 +1,55 @@
+package protobuf
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+var testProtoFile = filepath.Join("testdata", "order_service.proto")
+
+func TestLoadRegistry(t *testing.T) {
+	registry, err := LoadRegistry([]string{testProtoFile}, nil)
+	if err != nil {
+		t.Fatalf("LoadRegistry() error = %v", err)
+	}
+
+	method, found := registry.FindMethod("/orders.OrderService/WatchOrders")
+	if !found {
+		t.Fatalf("FindMethod() should find WatchOrders")
+	}
+	if method.Service != "orders.OrderService" || method.Name != "WatchOrders" {
+		t.Errorf("FindMethod() = %s/%s, want orders.OrderService/WatchOrders", method.Service, method.Name)
+	}
+	if method.ClientStreaming || !method.ServerStreaming {
+		t.Errorf("FindMethod() should be server streaming only")
+	}
+	if method.Output.FullName() != "orders.Order" {
+		t.Errorf("FindMethod() output = %s, want orders.Order", method.Output.FullName())
+	}
+
+	if _, found = registry.FindMethod("/orders.OrderService/Unknown"); found {
+		t.Errorf("FindMethod() should not find unknown method")
+	}
+	var nilRegistry *Registry
+	if _, found = nilRegistry.FindMethod("/orders.OrderService/GetOrder"); found {
+		t.Errorf("FindMethod() on nil registry should not find method")
+	}
+}
+
+func TestParse_invalidFile(t *testing.T) {
+	if _, err := Parse([]string{filepath.Join("testdata", "missing.proto")}, nil); err == nil {
+		t.Errorf("Parse() should fail for missing file")
+	}
+}
+
+func findTestMethod(t *testing.T, path string) Method {
+	registry, err := LoadRegistry([]string{testProtoFile}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	method, found := registry.FindMethod(path)
+	if !found {
+		t.Fatalf("method %s not found", path)
+	}
+	return method
+}
@@ -0,0 +1,118 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// maxSampleDepth limits how far nested and recursive messages are populated.
+const maxSampleDepth = 3
+
+// GenerateSampleJson returns a JSON representation of the given message type,
+// with every field populated with an example value.
+func GenerateSampleJson(md protoreflect.MessageDescriptor) ([]byte, error) {
+	msg := dynamicpb.NewMessage(md)
+	populateSample(msg, 0)
+	j, err := protojson.MarshalOptions{Multiline: true, Indent: "  ", EmitUnpopulated: true}.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate sample for %s: %v", md.FullName(), err)
+	}
+	return j, nil
+}
+
+func populateSample(msg protoreflect.Message, depth int) {
+	fields := msg.Descriptor().Fields()
+	populatedOneofs := make(map[protoreflect.FullName]bool)
+
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if oneof := fd.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+			// only one member of a oneof can be set
+			if populatedOneofs[oneof.FullName()] {
+				continue
+			}
+			populatedOneofs[oneof.FullName()] = true
+		}
+		if fd.Message() != nil && !canPopulate(fd.Message(), depth) {
+			continue
+		}
+
+		switch {
+		case fd.IsMap():
+			m := msg.Mutable(fd).Map()
+			key := sampleScalar(fd.MapKey()).MapKey()
+			if fd.MapValue().Message() != nil {
+				if !canPopulate(fd.MapValue().Message(), depth) {
+					continue
+				}
+				value := m.NewValue()
+				populateSample(value.Message(), depth+1)
+				m.Set(key, value)
+			} else {
+				m.Set(key, sampleScalar(fd.MapValue()))
+			}
+		case fd.IsList():
+			l := msg.Mutable(fd).List()
+			if fd.Message() != nil {
+				elem := l.NewElement()
+				populateSample(elem.Message(), depth+1)
+				l.Append(elem)
+			} else {
+				l.Append(sampleScalar(fd))
+			}
+		case fd.Message() != nil:
+			populateSample(msg.Mutable(fd).Message(), depth+1)
+		default:
+			msg.Set(fd, sampleScalar(fd))
+		}
+	}
+}
+
+// canPopulate determines whether a nested message should be populated.
+// Any messages are skipped, as they require a resolvable type URL.
+func canPopulate(md protoreflect.MessageDescriptor, depth int) bool {
+	return depth < maxSampleDepth && md.FullName() != "google.protobuf.Any"
+}
+
+func sampleScalar(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(true)
+	case protoreflect.EnumKind:
+		return protoreflect.ValueOfEnum(fd.Enum().Values().Get(0).Number())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(1)
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(1)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(1)
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(1)
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(1.5)
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(1.5)
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(fd.Name()))
+	default:
+		return protoreflect.ValueOfString(string(fd.Name()))
+	}
+}
@@ -0,0 +1,44 @@
+package protobuf
+
+import (
+	"encoding/json"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"testing"
+)
+
+func TestGenerateSampleJson(t *testing.T) {
+	method := findTestMethod(t, "/orders.OrderService/GetOrder")
+	sample, err := GenerateSampleJson(method.Output)
+	if err != nil {
+		t.Fatalf("GenerateSampleJson() error = %v", err)
+	}
+
+	var order map[string]interface{}
+	if err = json.Unmarshal(sample, &order); err != nil {
+		t.Fatalf("GenerateSampleJson() should produce valid JSON: %v", err)
+	}
+	if order["id"] != "id" {
+		t.Errorf("GenerateSampleJson() id = %v, want id", order["id"])
+	}
+	if order["status"] != "ORDER_STATUS_UNSPECIFIED" {
+		t.Errorf("GenerateSampleJson() status = %v, want first enum value", order["status"])
+	}
+	if items, ok := order["items"].([]interface{}); !ok || len(items) != 1 {
+		t.Errorf("GenerateSampleJson() items = %v, want one item", order["items"])
+	}
+	if labels, ok := order["labels"].(map[string]interface{}); !ok || len(labels) != 1 {
+		t.Errorf("GenerateSampleJson() labels = %v, want one entry", order["labels"])
+	}
+	if order["cardNumber"] != "card_number" {
+		t.Errorf("GenerateSampleJson() cardNumber = %v, want first oneof member set", order["cardNumber"])
+	}
+	if _, set := order["voucherCode"]; set {
+		t.Errorf("GenerateSampleJson() should only set one oneof member")
+	}
+
+	// the sample must be usable as a response by the grpc plugin
+	if err = protojson.Unmarshal(sample, dynamicpb.NewMessage(method.Output)); err != nil {
+		t.Errorf("GenerateSampleJson() should round trip: %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"golang.org/x/net/http2"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// h2cTransport speaks HTTP/2 without TLS, for plain HTTP upstreams.
+var h2cTransport = &http2.Transport{
+	AllowHTTP: true,
+	DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, addr)
+	},
+}
+
+// h2Transport speaks HTTP/2 over TLS, for HTTPS upstreams.
+var h2Transport = &http2.Transport{}
+
+// IsGrpcRequest returns true if the request is a gRPC call. gRPC-Web
+// requests are excluded, as they are regular HTTP/1.1 requests.
+func IsGrpcRequest(req *http.Request) bool {
+	contentType := req.Header.Get("Content-Type")
+	return strings.HasPrefix(contentType, "application/grpc") && !strings.HasPrefix(contentType, "application/grpc-web")
+}
+
+// HandleGrpc forwards a gRPC call to the upstream over HTTP/2 and relays the
+// response, including its trailers, to the client. Request and response
+// messages are buffered, so streaming calls are relayed once complete.
+func HandleGrpc(
+	upstream string,
+	w http.ResponseWriter,
+	req *http.Request,
+	listener func(reqBody *[]byte, statusCode int, respBody *[]byte, respHeaders *http.Header) (*[]byte, *http.Header),
+) {
+	startTime := time.Now()
+
+	client := req.RemoteAddr
+	logger.Debugf("received gRPC request %v %v from client %v", req.Method, req.URL, client)
+
+	path, _, clientReqHeaders, requestBody, err := parseRequest(req)
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	resp, responseBody, err := forwardGrpc(upstream, path, clientReqHeaders, requestBody)
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusBadGateway)
+		return
+	}
+
+	// the listener only sees the response headers; trailers are relayed as-is
+	recordedHeaders := resp.Header.Clone()
+	listener(requestBody, resp.StatusCode, responseBody, &recordedHeaders)
+
+	clientRespHeaders := w.Header()
+	copyHeaders(&resp.Header, &clientRespHeaders)
+	w.WriteHeader(resp.StatusCode)
+	if _, err = w.Write(*responseBody); err != nil {
+		logger.Errorf("error writing gRPC response to client %v: %v", client, err)
+		return
+	}
+	for trailerName, trailerValues := range resp.Trailer {
+		for _, trailerValue := range trailerValues {
+			clientRespHeaders.Add(http.TrailerPrefix+trailerName, trailerValue)
+		}
+	}
+
+	elapsed := time.Since(startTime)
+	logger.Infof("proxied gRPC %v to upstream [status: %v, grpc-status: %v, body %v bytes] for client %v in %v",
+		req.URL, resp.StatusCode, grpcStatus(resp), len(*responseBody), client, elapsed)
+}
+
+func forwardGrpc(upstream string, path string, clientRequestHeaders *http.Header, requestBody *[]byte) (*http.Response, *[]byte, error) {
+	upstreamUrl, err := url.JoinPath(upstream, path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build upstream URL: %v", err)
+	}
+	logger.Debugf("invoking gRPC upstream %s [body: %v bytes]", upstreamUrl, len(*requestBody))
+
+	req, err := http.NewRequest(http.MethodPost, upstreamUrl, bytes.NewReader(*requestBody))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build upstream request: %v", err)
+	}
+	upstreamReqHeaders := req.Header
+	copyHeaders(clientRequestHeaders, &upstreamReqHeaders)
+
+	// required by gRPC servers, but stripped as a hop-by-hop header
+	req.Header.Set("TE", "trailers")
+
+	// compressed messages could not be recorded in a readable form
+	req.Header.Del("Grpc-Accept-Encoding")
+
+	var grpcTransport http.RoundTripper = h2cTransport
+	if req.URL.Scheme == "https" {
+		grpcTransport = h2Transport
+	}
+	resp, err := grpcTransport.RoundTrip(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to invoke gRPC upstream %s: %v", upstreamUrl, err)
+	}
+	defer resp.Body.Close()
+
+	// trailers are only populated once the body has been read
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading gRPC response body: %v", err)
+	}
+	logger.Debugf("gRPC upstream responded to %s with status %d [grpc-status: %v, body %v bytes]", upstreamUrl, resp.StatusCode, grpcStatus(resp), len(respBody))
+	return resp, &respBody, nil
+}
+
+// grpcStatus returns the gRPC status code, which is sent as a trailer,
+// or as a header for responses without a body.
+func grpcStatus(resp *http.Response) string {
+	if status := resp.Trailer.Get("Grpc-Status"); status != "" {
+		return status
+	}
+	return resp.Header.Get("Grpc-Status")
+}
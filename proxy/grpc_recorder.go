@@ -0,0 +1,67 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"gatehill.io/imposter/protobuf"
+	"net/http"
+)
+
+// StartGrpcRecorder records gRPC exchanges to a grpc plugin config file.
+// Messages for methods found in the registry are decoded to JSON; other
+// messages are recorded in their binary wire format.
+func StartGrpcRecorder(upstream string, dir string, options RecorderOptions, registry *protobuf.Registry) (chan HttpExchange, error) {
+	return startRecorder(upstream, dir, options, "-grpc-config.yaml", "grpc", func(exchange HttpExchange) HttpExchange {
+		return decodeGrpcExchange(exchange, registry)
+	})
+}
+
+func decodeGrpcExchange(exchange HttpExchange, registry *protobuf.Registry) HttpExchange {
+	method, found := registry.FindMethod(exchange.Request.URL.Path)
+	if !found {
+		logger.Debugf("no protobuf descriptor for gRPC method %s - recording binary messages", exchange.Request.URL.Path)
+		return exchange
+	}
+
+	req := exchange.Request.Clone(exchange.Request.Context())
+	if exchange.RequestBody != nil && len(*exchange.RequestBody) > 0 {
+		reqJson, err := protobuf.DecodeGrpcMessages(*exchange.RequestBody, method.Input)
+		if err != nil {
+			logger.Warn(err)
+		} else {
+			exchange.RequestBody = &reqJson
+			req.Header.Set("Content-Type", "application/json")
+		}
+	}
+	exchange.Request = req
+
+	if exchange.ResponseBody != nil && len(*exchange.ResponseBody) > 0 {
+		respJson, err := protobuf.DecodeGrpcMessages(*exchange.ResponseBody, method.Output)
+		if err != nil {
+			logger.Warn(err)
+		} else {
+			respHeaders := http.Header{}
+			if exchange.ResponseHeaders != nil {
+				respHeaders = exchange.ResponseHeaders.Clone()
+			}
+			respHeaders.Set("Content-Type", "application/json")
+			exchange.ResponseBody = &respJson
+			exchange.ResponseHeaders = &respHeaders
+		}
+	}
+	return exchange
+}
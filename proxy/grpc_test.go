@@ -0,0 +1,170 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"gatehill.io/imposter/protobuf"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsGrpcRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		want        bool
+	}{
+		{name: "json request", contentType: "application/json", want: false},
+		{name: "grpc request", contentType: "application/grpc", want: true},
+		{name: "grpc request with codec", contentType: "application/grpc+proto", want: true},
+		{name: "grpc-web request", contentType: "application/grpc-web+proto", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &http.Request{Header: http.Header{"Content-Type": []string{tt.contentType}}}
+			if got := IsGrpcRequest(req); got != tt.want {
+				t.Errorf("IsGrpcRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleGrpc(t *testing.T) {
+	registry, err := protobuf.LoadRegistry([]string{filepath.Join("..", "protobuf", "testdata", "order_service.proto")}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	method, _ := registry.FindMethod("/orders.OrderService/GetOrder")
+
+	upstream := httptest.NewServer(h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor != 2 || r.Header.Get("TE") != "trailers" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/grpc")
+		w.Header().Set("Trailer", "Grpc-Status")
+		_, _ = w.Write(buildTestGrpcMessage(t, method.Output, "abc"))
+		w.Header().Set("Grpc-Status", "0")
+	}), &http2.Server{}))
+	defer upstream.Close()
+
+	outputDir, err := os.MkdirTemp(os.TempDir(), "imposter-cli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	recordC, err := StartGrpcRecorder(upstream.URL, outputDir, RecorderOptions{}, registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxyServer := httptest.NewServer(h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		HandleGrpc(upstream.URL, w, r, func(reqBody *[]byte, statusCode int, respBody *[]byte, respHeaders *http.Header) (*[]byte, *http.Header) {
+			recordC <- HttpExchange{Request: r, RequestBody: reqBody, StatusCode: statusCode, ResponseBody: respBody, ResponseHeaders: respHeaders}
+			return respBody, respHeaders
+		})
+	}), &http2.Server{}))
+	defer proxyServer.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, proxyServer.URL+method.Path, bytes.NewReader(buildTestGrpcMessage(t, method.Input, "abc")))
+	req.Header.Set("Content-Type", "application/grpc")
+	client := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("HandleGrpc() status = %d, want 200", resp.StatusCode)
+	}
+	if !bytes.Equal(respBody, buildTestGrpcMessage(t, method.Output, "abc")) {
+		t.Errorf("HandleGrpc() should relay response message unchanged")
+	}
+	if resp.Trailer.Get("Grpc-Status") != "0" {
+		t.Errorf("HandleGrpc() grpc-status trailer = %q, want 0", resp.Trailer.Get("Grpc-Status"))
+	}
+
+	responseFile := filepath.Join(outputDir, "orders.OrderService", "POST-GetOrder.json")
+	recorded := waitFor(func() bool {
+		contents, err := os.ReadFile(responseFile)
+		return err == nil && strings.Contains(string(contents), `"id": "abc"`)
+	})
+	if !recorded {
+		t.Errorf("HandleGrpc() should record response decoded to JSON at %s", responseFile)
+	}
+	configFile := filepath.Join(outputDir, strings.ReplaceAll(strings.TrimPrefix(upstream.URL, "http://"), ":", "-")+"-grpc-config.yaml")
+	if !waitFor(func() bool { _, err := os.Stat(configFile); return err == nil }) {
+		t.Errorf("HandleGrpc() should write grpc config file %s", configFile)
+	}
+}
+
+func TestPassThrough_Grpc(t *testing.T) {
+	upstream := httptest.NewServer(h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor != 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/grpc")
+		w.Header().Set("Trailer", "Grpc-Status")
+		_, _ = w.Write([]byte{0, 0, 0, 0, 0})
+		w.Header().Set("Grpc-Status", "5")
+	}), &http2.Server{}))
+	defer upstream.Close()
+
+	proxyServer := httptest.NewServer(h2c.NewHandler(PassThrough(upstream.URL), &http2.Server{}))
+	defer proxyServer.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, proxyServer.URL+"/orders.OrderService/GetOrder", bytes.NewReader([]byte{0, 0, 0, 0, 0}))
+	req.Header.Set("Content-Type", "application/grpc")
+	client := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("PassThrough() status = %d, want 200", resp.StatusCode)
+	}
+	if resp.Trailer.Get("Grpc-Status") != "5" {
+		t.Errorf("PassThrough() grpc-status trailer = %q, want 5", resp.Trailer.Get("Grpc-Status"))
+	}
+}
+
+// buildTestGrpcMessage returns a length-prefixed gRPC message
+// of the given type, with its id field set.
+func buildTestGrpcMessage(t *testing.T, md protoreflect.MessageDescriptor, id string) []byte {
+	msg := dynamicpb.NewMessage(md)
+	msg.Set(md.Fields().ByName("id"), protoreflect.ValueOfString(id))
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	frame := make([]byte, 5, 5+len(data))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(data)))
+	return append(frame, data...)
+}
@@ -115,6 +115,27 @@ func Handle(
 	logger.Infof("proxied %s %v to upstream [status: %v, body %v bytes] for client %v in %v", req.Method, req.URL, statusCode, len(*responseBody), client, elapsed)
 }
 
+// PassThrough returns a handler that relays requests, WebSocket
+// connections and gRPC calls to the upstream, without recording them.
+// gRPC calls are only received if the handler is served over HTTP/2.
+func PassThrough(upstream string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if IsWebSocketUpgrade(req) {
+			HandleWebSocket(upstream, w, req, nil)
+			return
+		}
+		if IsGrpcRequest(req) {
+			HandleGrpc(upstream, w, req, func(reqBody *[]byte, statusCode int, respBody *[]byte, respHeaders *http.Header) (*[]byte, *http.Header) {
+				return respBody, respHeaders
+			})
+			return
+		}
+		Handle(upstream, w, req, func(reqBody *[]byte, statusCode int, respBody *[]byte, respHeaders *http.Header) (*[]byte, *http.Header) {
+			return respBody, respHeaders
+		})
+	})
+}
+
 func parseRequest(req *http.Request) (path string, queryString string, headers *http.Header, body *[]byte, err error) {
 	defer req.Body.Close()
 	requestBody, err := io.ReadAll(req.Body)
@@ -168,6 +189,7 @@ func forward(
 func sendResponse(w http.ResponseWriter, headers *http.Header, statusCode int, body *[]byte, client string) (err error) {
 	clientRespHeaders := w.Header()
 	copyHeaders(headers, &clientRespHeaders)
+	w.WriteHeader(statusCode)
 	_, err = w.Write(*body)
 	if err != nil {
 		return fmt.Errorf("error writing response: %v", err)
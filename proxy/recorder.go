@@ -39,17 +39,31 @@ type RecorderOptions struct {
 }
 
 func StartRecorder(upstream string, dir string, options RecorderOptions) (chan HttpExchange, error) {
+	return startRecorder(upstream, dir, options, "-config.yaml", "rest", nil)
+}
+
+// startRecorder writes each exchange received on the returned channel to a
+// response file, and regenerates the config file for the given plugin. If
+// prepare is set, it is applied to each exchange before it is recorded.
+func startRecorder(
+	upstream string,
+	dir string,
+	options RecorderOptions,
+	configFileSuffix string,
+	pluginName string,
+	prepare func(exchange HttpExchange) HttpExchange,
+) (chan HttpExchange, error) {
 	upstreamHost, err := formatUpstreamHostPort(upstream)
 	if err != nil {
 		return nil, err
 	}
-	configFile := path.Join(dir, upstreamHost+"-config.yaml")
+	configFile := path.Join(dir, upstreamHost+configFileSuffix)
 	if _, err := os.Stat(configFile); err == nil {
 		return nil, fmt.Errorf("config file %s already exists", configFile)
 	}
 
 	var resources []impostermodel.Resource
-	genOptions := impostermodel.ConfigGenerationOptions{PluginName: "rest"}
+	genOptions := impostermodel.ConfigGenerationOptions{PluginName: pluginName}
 
 	var requestHashes []string
 	responseHashes := make(map[string]string)
@@ -58,6 +72,9 @@ func StartRecorder(upstream string, dir string, options RecorderOptions) (chan H
 	go func() {
 		for {
 			exchange := <-recordC
+			if prepare != nil {
+				exchange = prepare(exchange)
+			}
 
 			var responseFilePrefix string
 			requestHash := getRequestHash(exchange.Request)
@@ -0,0 +1,384 @@
+/*
+Copyright © 2022 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Proxy 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+type WebSocketDirection string
+
+const (
+	WebSocketFromClient WebSocketDirection = "client"
+	WebSocketFromServer WebSocketDirection = "server"
+)
+
+// WebSocket frame opcodes, as defined in RFC 6455 section 5.2.
+const (
+	wsOpContinuation = 0x0
+	wsOpText         = 0x1
+	wsOpBinary       = 0x2
+	wsOpClose        = 0x8
+)
+
+// wsMaxMessageSize is the largest frame payload, or reassembled message,
+// that is relayed. Larger messages are rejected with close status 1009.
+const wsMaxMessageSize = 32 << 20
+
+// wsCloseMessageTooBig is the close status for a message that is too
+// large to process, as defined in RFC 6455 section 7.4.1.
+const wsCloseMessageTooBig = 1009
+
+var errWebSocketMessageTooBig = errors.New("websocket message too big")
+
+type WebSocketMessage struct {
+	Direction     WebSocketDirection `json:"direction"`
+	Type          string             `json:"type"`
+	Data          string             `json:"data"`
+	ElapsedMillis int64              `json:"elapsedMillis"`
+}
+
+// WebSocketEvent is emitted for each message relayed over a proxied
+// WebSocket connection. A final event with Closed set is emitted when
+// the connection ends.
+type WebSocketEvent struct {
+	ConnectionId string
+	Request      *http.Request
+	Message      *WebSocketMessage
+	Closed       bool
+}
+
+// IsWebSocketUpgrade returns true if the request asks to upgrade
+// the connection to the WebSocket protocol.
+func IsWebSocketUpgrade(req *http.Request) bool {
+	return headerContainsToken(req.Header, "Connection", "upgrade") &&
+		headerContainsToken(req.Header, "Upgrade", "websocket")
+}
+
+func headerContainsToken(headers http.Header, name string, token string) bool {
+	for _, value := range headers.Values(name) {
+		for _, t := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HandleWebSocket performs the WebSocket opening handshake with the upstream
+// on behalf of the client, then relays frames in both directions until either
+// side closes the connection. Each complete data message is sent to eventC.
+func HandleWebSocket(upstream string, w http.ResponseWriter, req *http.Request, eventC chan WebSocketEvent) {
+	startTime := time.Now()
+	client := req.RemoteAddr
+	logger.Debugf("received websocket upgrade request %v %v from client %v", req.Method, req.URL, client)
+
+	upstreamConn, upstreamResp, upstreamReader, err := dialWebSocketUpstream(upstream, req)
+	if err != nil {
+		logger.Error(err)
+		w.WriteHeader(http.StatusBadGateway)
+		return
+	}
+	defer upstreamConn.Close()
+
+	if upstreamResp.StatusCode != http.StatusSwitchingProtocols {
+		logger.Warnf("upstream rejected websocket upgrade for %v with status %d", req.URL, upstreamResp.StatusCode)
+		relayRejectedUpgrade(w, upstreamResp, client)
+		return
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		logger.Errorf("cannot take over client connection for websocket %v", req.URL)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	clientConn, clientBuf, err := hijacker.Hijack()
+	if err != nil {
+		logger.Errorf("failed to take over client connection for websocket %v: %v", req.URL, err)
+		return
+	}
+	defer clientConn.Close()
+
+	if err = upstreamResp.Write(clientConn); err != nil {
+		logger.Errorf("failed to write websocket handshake response to client %v: %v", client, err)
+		return
+	}
+
+	connectionId := uuid.New().String()
+	logger.Infof("proxying websocket %v to upstream for client %v [connection: %s]", req.URL, client, connectionId)
+
+	onMessage := func(msg WebSocketMessage) {
+		msg.ElapsedMillis = time.Since(startTime).Milliseconds()
+		if eventC != nil {
+			eventC <- WebSocketEvent{ConnectionId: connectionId, Request: req, Message: &msg}
+		}
+	}
+
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if err := relayFrames(clientBuf.Reader, upstreamConn, WebSocketFromClient, onMessage); err != nil {
+			logger.Tracef("websocket relay from client ended for connection %s: %v", connectionId, err)
+			if errors.Is(err, errWebSocketMessageTooBig) {
+				logger.Warnf("closing websocket for client %v: %v [connection: %s]", client, err, connectionId)
+				_ = writeCloseFrame(clientConn, wsCloseMessageTooBig, false)
+			}
+		}
+		// unblock the opposite relay
+		_ = upstreamConn.Close()
+	}()
+	go func() {
+		defer wg.Done()
+		if err := relayFrames(upstreamReader, clientConn, WebSocketFromServer, onMessage); err != nil {
+			logger.Tracef("websocket relay from upstream ended for connection %s: %v", connectionId, err)
+			if errors.Is(err, errWebSocketMessageTooBig) {
+				logger.Warnf("closing websocket to upstream for client %v: %v [connection: %s]", client, err, connectionId)
+				_ = writeCloseFrame(upstreamConn, wsCloseMessageTooBig, true)
+			}
+		}
+		_ = clientConn.Close()
+	}()
+	wg.Wait()
+
+	if eventC != nil {
+		eventC <- WebSocketEvent{ConnectionId: connectionId, Request: req, Closed: true}
+	}
+	logger.Infof("closed websocket %v for client %v in %v [connection: %s]", req.URL, client, time.Since(startTime), connectionId)
+}
+
+// dialWebSocketUpstream opens a connection to the upstream and sends the
+// upgrade request, returning the upstream handshake response.
+func dialWebSocketUpstream(upstream string, req *http.Request) (net.Conn, *http.Response, *bufio.Reader, error) {
+	upstreamUrl, err := url.Parse(upstream)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to parse upstream URL: %v", err)
+	}
+
+	var conn net.Conn
+	switch upstreamUrl.Scheme {
+	case "https", "wss":
+		conn, err = tls.Dial("tcp", hostWithDefaultPort(upstreamUrl, "443"), &tls.Config{ServerName: upstreamUrl.Hostname()})
+	default:
+		conn, err = net.Dial("tcp", hostWithDefaultPort(upstreamUrl, "80"))
+	}
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to connect to websocket upstream %s: %v", upstream, err)
+	}
+
+	upstreamPath, err := url.JoinPath("/", upstreamUrl.Path, req.URL.Path)
+	if err != nil {
+		_ = conn.Close()
+		return nil, nil, nil, fmt.Errorf("failed to build upstream URL: %v", err)
+	}
+	upgradeReq := &http.Request{
+		Method:     http.MethodGet,
+		URL:        &url.URL{Path: upstreamPath, RawQuery: req.URL.RawQuery},
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{},
+		Host:       upstreamUrl.Host,
+	}
+	copyHeaders(&req.Header, &upgradeReq.Header)
+	upgradeReq.Header.Set("Connection", "Upgrade")
+	upgradeReq.Header.Set("Upgrade", "websocket")
+
+	// compressed frames could not be recorded in a readable form
+	upgradeReq.Header.Del("Sec-WebSocket-Extensions")
+
+	if err = upgradeReq.Write(conn); err != nil {
+		_ = conn.Close()
+		return nil, nil, nil, fmt.Errorf("failed to send websocket upgrade request to %s: %v", upstream, err)
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, upgradeReq)
+	if err != nil {
+		_ = conn.Close()
+		return nil, nil, nil, fmt.Errorf("failed to read websocket upgrade response from %s: %v", upstream, err)
+	}
+	logger.Debugf("upstream responded to websocket upgrade for %s with status %d", upstreamPath, resp.StatusCode)
+	return conn, resp, reader, nil
+}
+
+func hostWithDefaultPort(u *url.URL, defaultPort string) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+	return net.JoinHostPort(u.Hostname(), defaultPort)
+}
+
+// relayRejectedUpgrade passes a non-101 upstream response back to the client.
+func relayRejectedUpgrade(w http.ResponseWriter, resp *http.Response, client string) {
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		logger.Errorf("error reading upstream response body: %v", err)
+		w.WriteHeader(http.StatusBadGateway)
+		return
+	}
+	clientRespHeaders := w.Header()
+	copyHeaders(&resp.Header, &clientRespHeaders)
+	w.WriteHeader(resp.StatusCode)
+	if _, err = w.Write(body); err != nil {
+		logger.Errorf("error writing response to client %v: %v", client, err)
+	}
+}
+
+// relayFrames copies WebSocket frames from src to dst unchanged, decoding
+// each frame on the way through so complete data messages can be passed
+// to onMessage. Fragmented messages are reassembled before being reported.
+func relayFrames(src io.Reader, dst io.Writer, direction WebSocketDirection, onMessage func(msg WebSocketMessage)) error {
+	var messageOpcode byte
+	var messageData []byte
+
+	for {
+		fin, opcode, payload, raw, err := readFrame(src)
+		if err != nil {
+			return err
+		}
+		if _, err = dst.Write(raw); err != nil {
+			return fmt.Errorf("failed to relay websocket frame: %v", err)
+		}
+
+		switch opcode {
+		case wsOpText, wsOpBinary:
+			messageOpcode = opcode
+			messageData = append([]byte{}, payload...)
+		case wsOpContinuation:
+			if len(messageData)+len(payload) > wsMaxMessageSize {
+				return fmt.Errorf("%w: fragmented message exceeds %d bytes", errWebSocketMessageTooBig, wsMaxMessageSize)
+			}
+			messageData = append(messageData, payload...)
+		case wsOpClose:
+			onMessage(WebSocketMessage{Direction: direction, Type: "close"})
+			return nil
+		default:
+			// ping and pong frames are not recorded
+			continue
+		}
+
+		if fin && messageOpcode != 0 {
+			onMessage(buildWebSocketMessage(direction, messageOpcode, messageData))
+			messageOpcode = 0
+			messageData = nil
+		}
+	}
+}
+
+func buildWebSocketMessage(direction WebSocketDirection, opcode byte, data []byte) WebSocketMessage {
+	if opcode == wsOpText {
+		return WebSocketMessage{Direction: direction, Type: "text", Data: string(data)}
+	}
+	return WebSocketMessage{Direction: direction, Type: "binary", Data: base64.StdEncoding.EncodeToString(data)}
+}
+
+// readFrame reads a single frame from r. It returns the unmasked payload
+// as well as the raw bytes of the frame, so it can be forwarded as-is.
+func readFrame(r io.Reader) (fin bool, opcode byte, payload []byte, raw []byte, err error) {
+	header := make([]byte, 2)
+	if _, err = io.ReadFull(r, header); err != nil {
+		return false, 0, nil, nil, err
+	}
+	raw = append(raw, header...)
+	fin = header[0]&0x80 != 0
+	opcode = header[0] & 0x0f
+	masked := header[1]&0x80 != 0
+
+	length := uint64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		ext := make([]byte, 2)
+		if _, err = io.ReadFull(r, ext); err != nil {
+			return false, 0, nil, nil, err
+		}
+		raw = append(raw, ext...)
+		length = uint64(binary.BigEndian.Uint16(ext))
+	case 127:
+		ext := make([]byte, 8)
+		if _, err = io.ReadFull(r, ext); err != nil {
+			return false, 0, nil, nil, err
+		}
+		raw = append(raw, ext...)
+		length = binary.BigEndian.Uint64(ext)
+	}
+
+	if length > wsMaxMessageSize {
+		return false, 0, nil, nil, fmt.Errorf("%w: frame payload of %d bytes exceeds %d bytes", errWebSocketMessageTooBig, length, wsMaxMessageSize)
+	}
+
+	var maskKey []byte
+	if masked {
+		maskKey = make([]byte, 4)
+		if _, err = io.ReadFull(r, maskKey); err != nil {
+			return false, 0, nil, nil, err
+		}
+		raw = append(raw, maskKey...)
+	}
+
+	body := make([]byte, length)
+	if _, err = io.ReadFull(r, body); err != nil {
+		return false, 0, nil, nil, err
+	}
+	raw = append(raw, body...)
+
+	payload = make([]byte, length)
+	copy(payload, body)
+	if masked {
+		for i := range payload {
+			payload[i] ^= maskKey[i%4]
+		}
+	}
+	return fin, opcode, payload, raw, nil
+}
+
+// writeCloseFrame sends a close frame with the status code. Frames sent
+// to a server must be masked.
+func writeCloseFrame(w io.Writer, code uint16, masked bool) error {
+	payload := binary.BigEndian.AppendUint16(nil, code)
+	frame := []byte{0x80 | wsOpClose, byte(len(payload))}
+	if masked {
+		maskKey := make([]byte, 4)
+		if _, err := rand.Read(maskKey); err != nil {
+			return err
+		}
+		frame[1] |= 0x80
+		frame = append(frame, maskKey...)
+		for i := range payload {
+			payload[i] ^= maskKey[i%4]
+		}
+	}
+	_, err := w.Write(append(frame, payload...))
+	return err
+}
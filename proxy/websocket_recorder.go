@@ -0,0 +1,138 @@
+/*
+Copyright © 2022 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Proxy 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+)
+
+// WebSocketSession is the recorded message sequence for a single
+// proxied WebSocket connection.
+type WebSocketSession struct {
+	Path        string             `json:"path"`
+	QueryParams map[string]string  `json:"queryParams,omitempty"`
+	Messages    []WebSocketMessage `json:"messages"`
+}
+
+// StartWebSocketRecorder records the messages relayed over each proxied WebSocket
+// connection to its own file in dir. The file is rewritten as each message
+// arrives, so the recording survives the proxy being stopped.
+func StartWebSocketRecorder(upstream string, dir string, options RecorderOptions) (chan WebSocketEvent, error) {
+	upstreamHost, err := formatUpstreamHostPort(upstream)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make(map[string]*WebSocketSession)
+	sessionFiles := make(map[string]string)
+
+	eventC := make(chan WebSocketEvent)
+	go func() {
+		for {
+			event := <-eventC
+
+			if event.Closed {
+				if sessionFile := sessionFiles[event.ConnectionId]; sessionFile != "" {
+					logger.Infof("recorded %d websocket message(s) to %s", len(sessions[event.ConnectionId].Messages), sessionFile)
+				}
+				delete(sessions, event.ConnectionId)
+				delete(sessionFiles, event.ConnectionId)
+				continue
+			}
+
+			session := sessions[event.ConnectionId]
+			if session == nil {
+				session = newWebSocketSession(event)
+				sessionFile, err := generateWebSocketFileName(upstreamHost, dir, options, event)
+				if err != nil {
+					logger.Warn(err)
+					continue
+				}
+				sessions[event.ConnectionId] = session
+				sessionFiles[event.ConnectionId] = sessionFile
+			}
+			session.Messages = append(session.Messages, *event.Message)
+
+			if err := writeWebSocketSession(sessionFiles[event.ConnectionId], session); err != nil {
+				logger.Warn(err)
+			}
+		}
+	}()
+
+	return eventC, nil
+}
+
+func newWebSocketSession(event WebSocketEvent) *WebSocketSession {
+	session := &WebSocketSession{
+		Path: event.Request.URL.Path,
+	}
+	if len(event.Request.URL.Query()) > 0 {
+		session.QueryParams = make(map[string]string)
+		for qk, qvs := range event.Request.URL.Query() {
+			if len(qvs) > 0 {
+				session.QueryParams[qk] = qvs[0]
+			}
+		}
+	}
+	return session
+}
+
+// generateWebSocketFileName returns a unique filename for the recording of
+// the given connection, following the same layout as response files.
+func generateWebSocketFileName(upstreamHost string, dir string, options RecorderOptions, event WebSocketEvent) (string, error) {
+	req := event.Request
+	sanitisedParent := strings.TrimPrefix(path.Dir(req.URL.EscapedPath()), "/")
+	if sanitisedParent == "." {
+		sanitisedParent = ""
+	}
+
+	baseFileName := path.Base(req.URL.EscapedPath())
+	if baseFileName == "/" || baseFileName == "." {
+		baseFileName = "index"
+	}
+	connectionSuffix := "-" + strings.Split(event.ConnectionId, "-")[0] + ".json"
+
+	if options.FlatResponseFileStructure {
+		flatParent := strings.ReplaceAll(sanitisedParent, "/", "_")
+		if len(flatParent) > 0 {
+			flatParent += "_"
+		}
+		return path.Join(dir, upstreamHost+"-WS-"+flatParent+baseFileName+connectionSuffix), nil
+	}
+
+	parentDir := path.Join(dir, sanitisedParent)
+	if err := ensureDirExists(parentDir); err != nil {
+		return "", err
+	}
+	return path.Join(parentDir, "WS-"+baseFileName+connectionSuffix), nil
+}
+
+func writeWebSocketSession(sessionFile string, session *WebSocketSession) error {
+	j, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal websocket session for %s: %v", session.Path, err)
+	}
+	if err = os.WriteFile(sessionFile, j, 0644); err != nil {
+		return fmt.Errorf("failed to write websocket session file %s: %v", sessionFile, err)
+	}
+	logger.Debugf("wrote websocket session file %s for %s [%d messages]", sessionFile, session.Path, len(session.Messages))
+	return nil
+}
@@ -0,0 +1,254 @@
+package proxy
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestIsWebSocketUpgrade(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers http.Header
+		want    bool
+	}{
+		{
+			name:    "plain request",
+			headers: http.Header{},
+			want:    false,
+		},
+		{
+			name: "websocket upgrade",
+			headers: http.Header{
+				"Connection": []string{"Upgrade"},
+				"Upgrade":    []string{"websocket"},
+			},
+			want: true,
+		},
+		{
+			name: "websocket upgrade with multiple connection tokens",
+			headers: http.Header{
+				"Connection": []string{"keep-alive, Upgrade"},
+				"Upgrade":    []string{"WebSocket"},
+			},
+			want: true,
+		},
+		{
+			name: "non-websocket upgrade",
+			headers: http.Header{
+				"Connection": []string{"Upgrade"},
+				"Upgrade":    []string{"h2c"},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &http.Request{Header: tt.headers}
+			if got := IsWebSocketUpgrade(req); got != tt.want {
+				t.Errorf("IsWebSocketUpgrade() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_relayFrames(t *testing.T) {
+	src := new(bytes.Buffer)
+	src.Write(buildFrame(false, wsOpText, []byte("hello "), true))
+	src.Write(buildFrame(true, wsOpContinuation, []byte("world"), true))
+	src.Write(buildFrame(true, wsOpBinary, []byte{0x01, 0x02}, false))
+	src.Write(buildFrame(true, wsOpClose, nil, true))
+	raw := append([]byte{}, src.Bytes()...)
+
+	dst := new(bytes.Buffer)
+	var messages []WebSocketMessage
+	err := relayFrames(src, dst, WebSocketFromClient, func(msg WebSocketMessage) {
+		messages = append(messages, msg)
+	})
+	if err != nil {
+		t.Fatalf("relayFrames() error = %v", err)
+	}
+	if !bytes.Equal(raw, dst.Bytes()) {
+		t.Errorf("relayFrames() should forward frames unchanged")
+	}
+	if len(messages) != 3 {
+		t.Fatalf("relayFrames() wanted 3 messages, got: %d", len(messages))
+	}
+	if messages[0].Type != "text" || messages[0].Data != "hello world" {
+		t.Errorf("relayFrames() first message = %+v, want reassembled text", messages[0])
+	}
+	if messages[1].Type != "binary" || messages[1].Data != "AQI=" {
+		t.Errorf("relayFrames() second message = %+v, want base64 binary", messages[1])
+	}
+	if messages[2].Type != "close" {
+		t.Errorf("relayFrames() third message = %+v, want close", messages[2])
+	}
+}
+
+func Test_readFrame_TooBig(t *testing.T) {
+	// a 64-bit length that could not be allocated
+	header := []byte{0x80 | wsOpBinary, 127, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	if _, _, _, _, err := readFrame(bytes.NewReader(header)); !errors.Is(err, errWebSocketMessageTooBig) {
+		t.Errorf("readFrame() error = %v, want %v", err, errWebSocketMessageTooBig)
+	}
+}
+
+func TestHandleWebSocket_TooBig(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(echoWebSocket))
+	defer upstream.Close()
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		HandleWebSocket(upstream.URL, w, r, nil)
+	}))
+	defer proxyServer.Close()
+
+	proxyUrl, _ := url.Parse(proxyServer.URL)
+	conn, err := net.Dial("tcp", proxyUrl.Host)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	_, _ = conn.Write([]byte("GET /notifications HTTP/1.1\r\nHost: " + proxyUrl.Host +
+		"\r\nConnection: Upgrade\r\nUpgrade: websocket\r\nSec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n\r\n"))
+	reader := bufio.NewReader(conn)
+	if _, err = http.ReadResponse(reader, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	header := []byte{0x80 | wsOpBinary, 0x80 | 127}
+	header = binary.BigEndian.AppendUint64(header, wsMaxMessageSize+1)
+	_, _ = conn.Write(append(header, 0x11, 0x22, 0x33, 0x44))
+
+	_, opcode, payload, _, err := readFrame(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opcode != wsOpClose || len(payload) != 2 || binary.BigEndian.Uint16(payload) != wsCloseMessageTooBig {
+		t.Errorf("HandleWebSocket() should close with status %d, got opcode %d payload %v", wsCloseMessageTooBig, opcode, payload)
+	}
+}
+
+func TestHandleWebSocket(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(echoWebSocket))
+	defer upstream.Close()
+
+	outputDir, err := os.MkdirTemp(os.TempDir(), "imposter-cli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	eventC, err := StartWebSocketRecorder(upstream.URL, outputDir, RecorderOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		HandleWebSocket(upstream.URL, w, r, eventC)
+	}))
+	defer proxyServer.Close()
+
+	proxyUrl, _ := url.Parse(proxyServer.URL)
+	conn, err := net.Dial("tcp", proxyUrl.Host)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	_, _ = conn.Write([]byte("GET /notifications HTTP/1.1\r\nHost: " + proxyUrl.Host +
+		"\r\nConnection: Upgrade\r\nUpgrade: websocket\r\nSec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n\r\n"))
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("HandleWebSocket() handshake status = %d, want 101", resp.StatusCode)
+	}
+
+	_, _ = conn.Write(buildFrame(true, wsOpText, []byte("ping"), true))
+	_, _, payload, _, err := readFrame(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(payload) != "ping" {
+		t.Errorf("HandleWebSocket() echoed payload = %s, want ping", payload)
+	}
+
+	sessionFile := filepath.Join(outputDir, "WS-notifications-*.json")
+	var session WebSocketSession
+	recorded := waitFor(func() bool {
+		matches, _ := filepath.Glob(sessionFile)
+		if len(matches) == 0 {
+			return false
+		}
+		contents, err := os.ReadFile(matches[0])
+		if err != nil || json.Unmarshal(contents, &session) != nil {
+			return false
+		}
+		return len(session.Messages) == 2
+	})
+	if !recorded {
+		t.Fatalf("HandleWebSocket() should record both messages, got: %+v", session)
+	}
+	if session.Messages[0].Direction != WebSocketFromClient || session.Messages[1].Direction != WebSocketFromServer {
+		t.Errorf("HandleWebSocket() recorded directions = %+v", session.Messages)
+	}
+}
+
+// echoWebSocket is a minimal WebSocket server that echoes each frame
+// back to the client, unmasked.
+func echoWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, buf, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	_, _ = conn.Write([]byte("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: websocket\r\n\r\n"))
+	for {
+		fin, opcode, payload, _, err := readFrame(buf.Reader)
+		if err != nil {
+			return
+		}
+		if _, err = conn.Write(buildFrame(fin, opcode, payload, false)); err != nil {
+			return
+		}
+	}
+}
+
+func buildFrame(fin bool, opcode byte, payload []byte, masked bool) []byte {
+	var first byte = opcode
+	if fin {
+		first |= 0x80
+	}
+	frame := []byte{first, byte(len(payload))}
+	if !masked {
+		return append(frame, payload...)
+	}
+	frame[1] |= 0x80
+	maskKey := []byte{0x11, 0x22, 0x33, 0x44}
+	frame = append(frame, maskKey...)
+	for i, b := range payload {
+		frame = append(frame, b^maskKey[i%4])
+	}
+	return frame
+}
+
+func waitFor(condition func() bool) bool {
+	for i := 0; i < 50; i++ {
+		if condition() {
+			return true
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return false
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"gatehill.io/imposter/engine"
 	"gatehill.io/imposter/engine/awslambda"
+	"gatehill.io/imposter/lockfile"
 	"gatehill.io/imposter/remote"
 	"gatehill.io/imposter/stringutil"
 	"github.com/aws/aws-sdk-go/aws"
@@ -30,13 +31,19 @@ func (m LambdaRemote) Deploy() error {
 
 	roleArn, err := ensureIamRole(sess, roleName)
 	if err != nil {
-		logger.Fatal(err)
+		return err
 	}
 
-	engineVersion := engine.GetConfiguredVersion(m.Config[configKeyEngineVersion], true)
+	lock, engineVersion, err := lockfile.ResolveVersion(m.Dir, engine.EngineTypeAwsLambda, m.Config[configKeyEngineVersion], true)
+	if err != nil {
+		return err
+	}
+	if err = lockfile.VerifyArtifacts(m.Dir, lock, engine.EngineTypeAwsLambda, engineVersion); err != nil {
+		return err
+	}
 	zipContents, err := awslambda.CreateDeploymentPackage(engineVersion, m.Dir)
 	if err != nil {
-		logger.Fatal(err)
+		return err
 	}
 
 	var location codeLocation
@@ -50,6 +57,9 @@ func (m LambdaRemote) Deploy() error {
 			objectKey: objectKey,
 		}
 	} else {
+		if err = awslambda.CheckDirectUploadSize(zipContents); err != nil {
+			return fmt.Errorf("%v - upload it to S3 instead with 'imposter remote config %s=true'", err, configKeyUploadToS3)
+		}
 		location = codeLocation{
 			zipContents: zipContents,
 		}
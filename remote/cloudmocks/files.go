@@ -18,6 +18,11 @@ func (m CloudMocksRemote) syncFiles(dir string) error {
 	if err != nil {
 		return err
 	}
+	rules, err := fileutil.LoadIgnoreRules(dir)
+	if err != nil {
+		return err
+	}
+	local = rules.Filter(dir, local)
 
 	err = m.uploadFiles(local)
 	if err != nil {
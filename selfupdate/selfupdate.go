@@ -0,0 +1,289 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"gatehill.io/imposter/config"
+	"gatehill.io/imposter/library"
+	"gatehill.io/imposter/logging"
+	"gatehill.io/imposter/prefs"
+	"github.com/coreos/go-semver/semver"
+	"github.com/spf13/viper"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const latestReleaseApi = "https://api.github.com/repos/imposter-project/imposter-cli/releases/latest"
+const checkThresholdSeconds = 86_400
+const checkTimeout = 2 * time.Second
+
+var downloadConfig = library.DownloadConfig{
+	LatestBaseUrlTemplate:    "https://github.com/imposter-project/imposter-cli/releases/latest/download",
+	VersionedBaseUrlTemplate: "https://github.com/imposter-project/imposter-cli/releases/download/v%v",
+}
+
+var logger = logging.GetLogger()
+
+// executablePath returns the path of the running binary. It is a variable
+// so it can be replaced in tests.
+var executablePath = os.Executable
+
+// Update replaces the running binary with the given version of the CLI,
+// or the latest version if version is empty or 'latest'. The release
+// artifact is verified in the same way as engine downloads. It returns
+// the version installed, which is empty if the CLI is already at that
+// version and force is false.
+func Update(version string, force bool) (string, error) {
+	if version == "" || version == "latest" {
+		latest, err := fetchLatestVersion(http.DefaultClient)
+		if err != nil {
+			return "", err
+		}
+		version = latest
+	} else {
+		version = strings.TrimPrefix(version, "v")
+	}
+	if version == config.Config.Version && !force {
+		logger.Infof("CLI is already at version %s", version)
+		return "", nil
+	}
+
+	tempDir, err := os.MkdirTemp("", "imposter-self-update")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	artifactName := getArtifactName(runtime.GOOS, runtime.GOARCH)
+	archivePath := filepath.Join(tempDir, artifactName)
+	if err = library.DownloadBinaryWithConfig(downloadConfig, archivePath, artifactName, version, ""); err != nil {
+		return "", fmt.Errorf("failed to download CLI version %s: %v", version, err)
+	}
+	if err = library.ForgetChecksum(archivePath); err != nil {
+		logger.Debugf("failed to remove checksum record for: %s: %v", archivePath, err)
+	}
+
+	binaryPath := filepath.Join(tempDir, getBinaryName(runtime.GOOS))
+	if err = extractBinary(archivePath, filepath.Base(binaryPath), binaryPath); err != nil {
+		return "", err
+	}
+	if err = replaceExecutable(binaryPath); err != nil {
+		return "", err
+	}
+	return version, nil
+}
+
+// getArtifactName returns the name of the release archive for the
+// OS and architecture.
+func getArtifactName(goos string, goarch string) string {
+	extension := "tar.gz"
+	if goos == "windows" {
+		extension = "zip"
+	}
+	return fmt.Sprintf("imposter-cli_%s_%s.%s", goos, goarch, extension)
+}
+
+func getBinaryName(goos string) string {
+	if goos == "windows" {
+		return "imposter.exe"
+	}
+	return "imposter"
+}
+
+// extractBinary writes the file with the given name in the root of
+// the archive to dest.
+func extractBinary(archivePath string, binaryName string, dest string) error {
+	if strings.HasSuffix(archivePath, ".zip") {
+		return extractFromZip(archivePath, binaryName, dest)
+	}
+	return extractFromTarGz(archivePath, binaryName, dest)
+}
+
+func extractFromTarGz(archivePath string, binaryName string, dest string) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %s: %v", archivePath, err)
+	}
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("failed to read archive: %s: %v", archivePath, err)
+	}
+	defer gz.Close()
+
+	reader := tar.NewReader(gz)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("failed to read archive: %s: %v", archivePath, err)
+		}
+		if header.Typeflag == tar.TypeReg && filepath.Clean(header.Name) == binaryName {
+			return writeBinary(reader, dest)
+		}
+	}
+	return fmt.Errorf("binary %s not found in archive: %s", binaryName, archivePath)
+}
+
+func extractFromZip(archivePath string, binaryName string, dest string) error {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to read archive: %s: %v", archivePath, err)
+	}
+	defer reader.Close()
+	for _, f := range reader.File {
+		if filepath.Clean(f.Name) != binaryName {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return fmt.Errorf("failed to read %s from archive: %s: %v", binaryName, archivePath, err)
+		}
+		defer rc.Close()
+		return writeBinary(rc, dest)
+	}
+	return fmt.Errorf("binary %s not found in archive: %s", binaryName, archivePath)
+}
+
+func writeBinary(src io.Reader, dest string) error {
+	file, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %s: %v", dest, err)
+	}
+	defer file.Close()
+	if _, err = io.Copy(file, src); err != nil {
+		return fmt.Errorf("failed to write file: %s: %v", dest, err)
+	}
+	return nil
+}
+
+// replaceExecutable atomically replaces the running binary with the new
+// binary, by writing it alongside the running binary and renaming it.
+// Windows does not permit a running binary to be replaced, so it is
+// renamed out of the way first.
+func replaceExecutable(newBinary string) error {
+	exe, err := executablePath()
+	if err != nil {
+		return fmt.Errorf("failed to determine path of running binary: %v", err)
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return fmt.Errorf("failed to resolve path of running binary: %v", err)
+	}
+	info, err := os.Stat(exe)
+	if err != nil {
+		return fmt.Errorf("failed to stat running binary: %s: %v", exe, err)
+	}
+
+	staged, err := os.CreateTemp(filepath.Dir(exe), ".imposter-update-*")
+	if err != nil {
+		return fmt.Errorf("failed to stage new binary alongside %s: %v", exe, err)
+	}
+	stagedPath := staged.Name()
+	defer os.Remove(stagedPath)
+
+	src, err := os.Open(newBinary)
+	if err != nil {
+		_ = staged.Close()
+		return fmt.Errorf("failed to open new binary: %s: %v", newBinary, err)
+	}
+	_, err = io.Copy(staged, src)
+	_ = src.Close()
+	_ = staged.Close()
+	if err != nil {
+		return fmt.Errorf("failed to stage new binary: %s: %v", stagedPath, err)
+	}
+	if err = os.Chmod(stagedPath, info.Mode().Perm()|0111); err != nil {
+		return fmt.Errorf("failed to set permissions of new binary: %s: %v", stagedPath, err)
+	}
+
+	if runtime.GOOS == "windows" {
+		oldPath := exe + ".old"
+		_ = os.Remove(oldPath)
+		if err = os.Rename(exe, oldPath); err != nil {
+			return fmt.Errorf("failed to move running binary: %s: %v", exe, err)
+		}
+	}
+	if err = os.Rename(stagedPath, exe); err != nil {
+		return fmt.Errorf("failed to replace running binary: %s: %v", exe, err)
+	}
+	logger.Debugf("replaced binary at: %s", exe)
+	return nil
+}
+
+// CheckForUpdate returns the latest version of the CLI if it is newer than
+// the running version. The latest version is checked at most once a day,
+// using the prefs store for throttling. The check is disabled by setting
+// `cli.checkForUpdates` to false, and for development builds.
+func CheckForUpdate() (latest string, available bool) {
+	if config.Config.Version == config.DevCliVersion {
+		return "", false
+	}
+	viper.SetDefault("cli.checkForUpdates", true)
+	if !viper.GetBool("cli.checkForUpdates") {
+		return "", false
+	}
+
+	p := prefs.Load("prefs.json")
+	now := time.Now().Unix()
+	lastCheck, _ := p.ReadPropertyInt("last_cli_version_check")
+	if now-int64(lastCheck) < checkThresholdSeconds {
+		latest, _ = p.ReadPropertyString("latest_cli")
+	} else {
+		// record the check time first, so a failed check is not retried
+		// on every invocation
+		if err := p.WriteProperty("last_cli_version_check", now); err != nil {
+			logger.Debugf("failed to record last CLI version check time: %s", err)
+		}
+		fetched, err := fetchLatestVersion(&http.Client{Timeout: checkTimeout})
+		if err != nil {
+			logger.Debugf("failed to check for CLI update: %s", err)
+			return "", false
+		}
+		latest = fetched
+		if err = p.WriteProperty("latest_cli", latest); err != nil {
+			logger.Debugf("failed to record latest CLI version: %s", err)
+		}
+	}
+	return latest, isNewer(latest, config.Config.Version)
+}
+
+func isNewer(candidate string, current string) bool {
+	candidateVer, err := semver.NewVersion(candidate)
+	if err != nil {
+		return false
+	}
+	currentVer, err := semver.NewVersion(current)
+	if err != nil {
+		return false
+	}
+	return currentVer.LessThan(*candidateVer)
+}
+
+func fetchLatestVersion(client *http.Client) (string, error) {
+	latestReleaseApi := library.ApplyMirror(latestReleaseApi)
+	logger.Tracef("fetching latest CLI version from: %s", latestReleaseApi)
+	resp, err := client.Get(latestReleaseApi)
+	if err != nil {
+		return "", fmt.Errorf("failed to determine latest CLI version from %s: %s", latestReleaseApi, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("failed to determine latest CLI version from %s - status code: %d", latestReleaseApi, resp.StatusCode)
+	}
+	var data struct {
+		TagName string `json:"tag_name"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return "", fmt.Errorf("failed to determine latest CLI version from %s - cannot unmarshall response body: %s", latestReleaseApi, err)
+	}
+	return strings.TrimPrefix(data.TagName, "v"), nil
+}
@@ -0,0 +1,168 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"gatehill.io/imposter/config"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func Test_getArtifactName(t *testing.T) {
+	require.Equal(t, "imposter-cli_linux_amd64.tar.gz", getArtifactName("linux", "amd64"))
+	require.Equal(t, "imposter-cli_darwin_arm64.tar.gz", getArtifactName("darwin", "arm64"))
+	require.Equal(t, "imposter-cli_windows_amd64.zip", getArtifactName("windows", "amd64"))
+}
+
+func Test_isNewer(t *testing.T) {
+	require.True(t, isNewer("1.2.0", "1.1.9"))
+	require.False(t, isNewer("1.1.9", "1.1.9"))
+	require.False(t, isNewer("1.0.0", "1.1.9"))
+	require.False(t, isNewer("invalid", "1.1.9"))
+}
+
+func Test_extractBinary(t *testing.T) {
+	dir := t.TempDir()
+
+	tarPath := writeTarGz(t, filepath.Join(dir, "cli.tar.gz"), map[string]string{"README.md": "readme", "imposter": "tar binary"})
+	dest := filepath.Join(dir, "from-tar")
+	require.NoError(t, extractBinary(tarPath, "imposter", dest))
+	content, err := os.ReadFile(dest)
+	require.NoError(t, err)
+	require.Equal(t, "tar binary", string(content))
+
+	zipPath := writeZip(t, filepath.Join(dir, "cli.zip"), map[string]string{"imposter.exe": "zip binary"})
+	dest = filepath.Join(dir, "from-zip")
+	require.NoError(t, extractBinary(zipPath, "imposter.exe", dest))
+	content, err = os.ReadFile(dest)
+	require.NoError(t, err)
+	require.Equal(t, "zip binary", string(content))
+
+	require.ErrorContains(t, extractBinary(tarPath, "missing", dest), "not found in archive")
+}
+
+func TestUpdate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("release archive format differs on windows")
+	}
+	dir := t.TempDir()
+	archivePath := writeTarGz(t, filepath.Join(dir, "archive.tar.gz"), map[string]string{"imposter": "new binary"})
+	archive, err := os.ReadFile(archivePath)
+	require.NoError(t, err)
+
+	artifactName := getArtifactName(runtime.GOOS, runtime.GOARCH)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/repos/imposter-project/imposter-cli/releases/latest":
+			_, _ = w.Write([]byte(`{"tag_name":"v1.2.0"}`))
+		case "/imposter-project/imposter-cli/releases/download/v1.2.0/" + artifactName:
+			_, _ = w.Write(archive)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	exe := filepath.Join(dir, "imposter")
+	require.NoError(t, os.WriteFile(exe, []byte("old binary"), 0755))
+	executablePath = func() (string, error) { return exe, nil }
+	originalVersion := config.Config.Version
+	config.Config.Version = "1.1.0"
+	viper.Set("download.mirror", server.URL)
+	viper.Set("prefs.dir", t.TempDir())
+	t.Cleanup(func() {
+		executablePath = os.Executable
+		config.Config.Version = originalVersion
+		viper.Set("download.mirror", "")
+		viper.Set("prefs.dir", "")
+	})
+
+	installed, err := Update("", false)
+	require.NoError(t, err)
+	require.Equal(t, "1.2.0", installed)
+	content, err := os.ReadFile(exe)
+	require.NoError(t, err)
+	require.Equal(t, "new binary", string(content))
+	info, err := os.Stat(exe)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0755), info.Mode().Perm())
+
+	config.Config.Version = "1.2.0"
+	installed, err = Update("v1.2.0", false)
+	require.NoError(t, err)
+	require.Equal(t, "", installed, "should not update to the current version")
+
+	_, err = Update("9.9.9", false)
+	require.Error(t, err)
+}
+
+func TestCheckForUpdate(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = fmt.Fprint(w, `{"tag_name":"v1.2.0"}`)
+	}))
+	defer server.Close()
+
+	originalVersion := config.Config.Version
+	config.Config.Version = "1.1.0"
+	viper.Set("download.mirror", server.URL)
+	viper.Set("prefs.dir", t.TempDir())
+	t.Cleanup(func() {
+		config.Config.Version = originalVersion
+		viper.Set("download.mirror", "")
+		viper.Set("prefs.dir", "")
+		viper.Set("cli.checkForUpdates", true)
+	})
+
+	latest, available := CheckForUpdate()
+	require.True(t, available)
+	require.Equal(t, "1.2.0", latest)
+
+	_, available = CheckForUpdate()
+	require.True(t, available)
+	require.Equal(t, 1, requests, "check should be throttled")
+
+	viper.Set("cli.checkForUpdates", false)
+	_, available = CheckForUpdate()
+	require.False(t, available, "check should be disabled")
+}
+
+func writeTarGz(t *testing.T, archivePath string, files map[string]string) string {
+	file, err := os.Create(archivePath)
+	require.NoError(t, err)
+	defer file.Close()
+	gz := gzip.NewWriter(file)
+	writer := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, writer.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err = writer.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	require.NoError(t, gz.Close())
+	return archivePath
+}
+
+func writeZip(t *testing.T, archivePath string, files map[string]string) string {
+	file, err := os.Create(archivePath)
+	require.NoError(t, err)
+	defer file.Close()
+	writer := zip.NewWriter(file)
+	for name, content := range files {
+		entry, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = entry.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return archivePath
+}
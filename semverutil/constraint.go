@@ -0,0 +1,248 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package semverutil
+
+import (
+	"fmt"
+	"github.com/coreos/go-semver/semver"
+	"strconv"
+	"strings"
+)
+
+// Constraint is a range of semantic versions, such as `^4.2`, `~4.2.1`
+// or `>=4.0 <5`. Space separated comparisons must all be satisfied;
+// alternatives are separated by `||`.
+type Constraint struct {
+	raw          string
+	alternatives [][]comparison
+}
+
+type comparison struct {
+	operator string
+	version  semver.Version
+}
+
+// IsConstraint returns true if the value is a version range, rather than
+// an exact version such as `4.2.2`, or `latest`.
+func IsConstraint(value string) bool {
+	if strings.ContainsAny(value, "^~<>=|* ") {
+		return true
+	}
+	for _, part := range strings.Split(value, ".") {
+		if part == "x" || part == "X" {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseConstraint parses a version range. Supported forms are:
+//
+//	^4.2     >=4.2.0 <5.0.0
+//	~4.2.1   >=4.2.1 <4.3.0
+//	4.x      >=4.0.0 <5.0.0
+//	>=4.0 <5 (comparisons with >, >=, <, <= and =)
+//	^3 || ^4 (alternatives)
+func ParseConstraint(value string) (*Constraint, error) {
+	c := &Constraint{raw: value}
+	for _, alternative := range strings.Split(value, "||") {
+		var comparisons []comparison
+		for _, term := range strings.Fields(alternative) {
+			parsed, err := parseTerm(term)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version constraint: %s: %v", value, err)
+			}
+			comparisons = append(comparisons, parsed...)
+		}
+		if len(comparisons) == 0 {
+			return nil, fmt.Errorf("invalid version constraint: %s", value)
+		}
+		c.alternatives = append(c.alternatives, comparisons)
+	}
+	return c, nil
+}
+
+func (c *Constraint) String() string {
+	return c.raw
+}
+
+// Check returns true if the version satisfies the constraint.
+// Pre-release versions never satisfy a constraint.
+func (c *Constraint) Check(version semver.Version) bool {
+	if version.PreRelease != "" {
+		return false
+	}
+	for _, comparisons := range c.alternatives {
+		satisfied := true
+		for _, cmp := range comparisons {
+			if !cmp.check(version) {
+				satisfied = false
+				break
+			}
+		}
+		if satisfied {
+			return true
+		}
+	}
+	return false
+}
+
+// Highest returns the highest of the candidate versions that satisfies
+// the constraint, or the empty string if none do. Candidates that are
+// not valid semantic versions are ignored.
+func (c *Constraint) Highest(candidates []string) string {
+	var highest *semver.Version
+	var highestRaw string
+	for _, candidate := range candidates {
+		v, err := semver.NewVersion(candidate)
+		if err != nil || !c.Check(*v) {
+			continue
+		}
+		if highest == nil || highest.LessThan(*v) {
+			highest = v
+			highestRaw = candidate
+		}
+	}
+	return highestRaw
+}
+
+func (cmp comparison) check(version semver.Version) bool {
+	result := version.Compare(cmp.version)
+	switch cmp.operator {
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	default:
+		return result == 0
+	}
+}
+
+// parseTerm converts a single term into one or more comparisons
+// against complete versions.
+func parseTerm(term string) ([]comparison, error) {
+	var operator string
+	for _, op := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(term, op) {
+			operator = op
+			term = strings.TrimPrefix(term, op)
+			break
+		}
+	}
+	parts, err := parsePartial(strings.TrimPrefix(term, "v"))
+	if err != nil {
+		return nil, err
+	}
+	lower := toVersion(parts)
+
+	switch operator {
+	case "^":
+		// allow changes that do not modify the left-most non-zero component
+		upper := make([]int64, len(parts))
+		copy(upper, parts)
+		for i, part := range parts {
+			if part != 0 || i == len(parts)-1 {
+				return rangeOf(lower, incrementAt(upper, i)), nil
+			}
+		}
+	case "~":
+		// allow patch changes if a minor version is specified, otherwise minor changes
+		if len(parts) == 0 {
+			break
+		}
+		return rangeOf(lower, incrementAt(parts, min(len(parts), 2)-1)), nil
+	case ">=", ">", "<=", "<":
+		if len(parts) == 0 {
+			return nil, fmt.Errorf("wildcard cannot be compared: %s", term)
+		} else if len(parts) == 3 {
+			return []comparison{{operator: operator, version: lower}}, nil
+		}
+		// partial versions cover all versions with the given prefix
+		switch operator {
+		case ">=":
+			return []comparison{{operator: ">=", version: lower}}, nil
+		case ">":
+			return []comparison{{operator: ">=", version: incrementAt(parts, len(parts)-1)}}, nil
+		case "<=":
+			return []comparison{{operator: "<", version: incrementAt(parts, len(parts)-1)}}, nil
+		default:
+			return []comparison{{operator: "<", version: lower}}, nil
+		}
+	}
+
+	// exact, or all versions with the given prefix
+	if len(parts) == 3 {
+		return []comparison{{operator: "=", version: lower}}, nil
+	} else if len(parts) == 0 {
+		return []comparison{{operator: ">=", version: semver.Version{}}}, nil
+	}
+	return rangeOf(lower, incrementAt(parts, len(parts)-1)), nil
+}
+
+// parsePartial parses a version with up to three components, stopping
+// at the first wildcard component.
+func parsePartial(value string) ([]int64, error) {
+	if value == "" {
+		return nil, fmt.Errorf("missing version")
+	}
+	var parts []int64
+	for i, component := range strings.Split(value, ".") {
+		if i > 2 {
+			return nil, fmt.Errorf("too many components in version: %s", value)
+		}
+		if component == "x" || component == "X" || component == "*" {
+			break
+		}
+		n, err := strconv.ParseInt(component, 10, 64)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid version: %s", value)
+		}
+		parts = append(parts, n)
+	}
+	return parts, nil
+}
+
+func toVersion(parts []int64) semver.Version {
+	v := semver.Version{}
+	if len(parts) > 0 {
+		v.Major = parts[0]
+	}
+	if len(parts) > 1 {
+		v.Minor = parts[1]
+	}
+	if len(parts) > 2 {
+		v.Patch = parts[2]
+	}
+	return v
+}
+
+// incrementAt returns the version with the component at the given index
+// incremented and subsequent components dropped.
+func incrementAt(parts []int64, index int) semver.Version {
+	bumped := make([]int64, index+1)
+	copy(bumped, parts[:index+1])
+	bumped[index]++
+	return toVersion(bumped)
+}
+
+func rangeOf(lower semver.Version, upper semver.Version) []comparison {
+	return []comparison{{operator: ">=", version: lower}, {operator: "<", version: upper}}
+}
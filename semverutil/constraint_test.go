@@ -0,0 +1,106 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package semverutil
+
+import (
+	"testing"
+
+	"github.com/coreos/go-semver/semver"
+)
+
+func TestIsConstraint(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: "4.2.2", want: false},
+		{value: "4.2", want: false},
+		{value: "latest", want: false},
+		{value: "^4.2", want: true},
+		{value: "~4.2.1", want: true},
+		{value: ">=4.0 <5", want: true},
+		{value: "4.x", want: true},
+		{value: "^3 || ^4", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := IsConstraint(tt.value); got != tt.want {
+				t.Errorf("IsConstraint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConstraint_Check(t *testing.T) {
+	tests := []struct {
+		constraint string
+		matches    []string
+		rejects    []string
+	}{
+		{constraint: "^4.2", matches: []string{"4.2.0", "4.9.1"}, rejects: []string{"4.1.9", "5.0.0"}},
+		{constraint: "^0.2.3", matches: []string{"0.2.3", "0.2.9"}, rejects: []string{"0.3.0", "0.2.2"}},
+		{constraint: "~4.2.1", matches: []string{"4.2.1", "4.2.9"}, rejects: []string{"4.2.0", "4.3.0"}},
+		{constraint: "~4", matches: []string{"4.0.0", "4.9.0"}, rejects: []string{"5.0.0"}},
+		{constraint: ">=4.0 <5", matches: []string{"4.0.0", "4.99.1"}, rejects: []string{"3.9.9", "5.0.0"}},
+		{constraint: ">4.2", matches: []string{"4.3.0"}, rejects: []string{"4.2.9"}},
+		{constraint: "<=4.2", matches: []string{"4.2.9"}, rejects: []string{"4.3.0"}},
+		{constraint: "4.x", matches: []string{"4.0.0", "4.5.6"}, rejects: []string{"3.0.0", "5.0.0"}},
+		{constraint: "=4.2.2", matches: []string{"4.2.2"}, rejects: []string{"4.2.3"}},
+		{constraint: "^3.1 || ^4.2", matches: []string{"3.5.0", "4.2.0"}, rejects: []string{"4.1.0", "5.0.0"}},
+		{constraint: "^4", rejects: []string{"4.1.0-beta"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			c, err := ParseConstraint(tt.constraint)
+			if err != nil {
+				t.Fatalf("ParseConstraint() error = %v", err)
+			}
+			for _, v := range tt.matches {
+				if !c.Check(*semver.New(v)) {
+					t.Errorf("expected %s to satisfy %s", v, tt.constraint)
+				}
+			}
+			for _, v := range tt.rejects {
+				if c.Check(*semver.New(v)) {
+					t.Errorf("expected %s not to satisfy %s", v, tt.constraint)
+				}
+			}
+		})
+	}
+}
+
+func TestParseConstraint_Invalid(t *testing.T) {
+	for _, value := range []string{"^", "~a.b", ">=1.2.3.4", "||", ">=x"} {
+		if _, err := ParseConstraint(value); err == nil {
+			t.Errorf("expected error parsing %s", value)
+		}
+	}
+}
+
+func TestConstraint_Highest(t *testing.T) {
+	c, err := ParseConstraint("^4.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := c.Highest([]string{"4.1.0", "4.10.1", "4.3.0", "5.0.0", "latest"})
+	if got != "4.10.1" {
+		t.Errorf("Highest() = %v, want %v", got, "4.10.1")
+	}
+	if got := c.Highest([]string{"3.0.0"}); got != "" {
+		t.Errorf("Highest() = %v, want empty", got)
+	}
+}
@@ -393,3 +393,24 @@ func TestToBoolWithDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want int
+	}{
+		{name: "identical", a: "store-redis", b: "store-redis", want: 0},
+		{name: "missing character", a: "stor-redis", b: "store-redis", want: 1},
+		{name: "transposed characters", a: "store-rdeis", b: "store-redis", want: 2},
+		{name: "empty", a: "", b: "redis", want: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EditDistance(tt.a, tt.b); got != tt.want {
+				t.Errorf("EditDistance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
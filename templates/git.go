@@ -0,0 +1,65 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"fmt"
+	"gatehill.io/imposter/stringutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// gitCacheDir is the subdirectory of the templates directory
+// in which templates cloned from git are cached.
+const gitCacheDir = ".cache"
+
+func isGitUrl(ref string) bool {
+	return strings.HasPrefix(ref, "git@") ||
+		strings.HasPrefix(ref, "ssh://") ||
+		strings.HasPrefix(ref, "git://") ||
+		strings.HasSuffix(ref, ".git")
+}
+
+// ensureGitTemplate clones the repository into the cache, or updates the
+// cached copy if it exists. If the update fails, such as when offline,
+// the cached copy is used.
+func ensureGitTemplate(repoUrl string) (string, error) {
+	dir, err := getTemplatesDir()
+	if err != nil {
+		return "", err
+	}
+	cachePath := filepath.Join(dir, gitCacheDir, stringutil.Sha1hashString(repoUrl))
+
+	if _, err := os.Stat(filepath.Join(cachePath, ".git")); err == nil {
+		logger.Debugf("updating cached template from %s", repoUrl)
+		if output, err := exec.Command("git", "-C", cachePath, "pull", "--ff-only").CombinedOutput(); err != nil {
+			logger.Warnf("failed to update cached template from %s - using cached copy: %v: %s", repoUrl, err, output)
+		}
+		return cachePath, nil
+	}
+
+	logger.Infof("cloning template from %s", repoUrl)
+	if err = os.MkdirAll(filepath.Dir(cachePath), 0700); err != nil {
+		return "", fmt.Errorf("failed to create template cache directory: %v", err)
+	}
+	if output, err := exec.Command("git", "clone", "--depth", "1", repoUrl, cachePath).CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to clone template from %s: %v: %s", repoUrl, err, output)
+	}
+	return cachePath, nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"gatehill.io/imposter/fileutil"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// templateFileSuffix marks files that are rendered using the template
+// variables. Other files are copied as-is.
+const templateFileSuffix = ".tmpl"
+
+// Render writes the files in templateDir to destDir. Files with a .tmpl suffix
+// are rendered as Go templates, and have the suffix removed. File and directory
+// names may also contain template expressions. It returns the paths written.
+func Render(templateDir string, destDir string, vars TemplateVars, forceOverwrite bool) ([]string, error) {
+	var written []string
+	err := filepath.WalkDir(templateDir, func(srcPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(templateDir, srcPath)
+		if err != nil {
+			return err
+		}
+		if relPath == "." {
+			return nil
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if relPath == metadataFileName {
+			return nil
+		}
+
+		destRelPath, err := renderString(relPath, relPath, vars)
+		if err != nil {
+			return err
+		}
+		destPath := filepath.Join(destDir, strings.TrimSuffix(destRelPath, templateFileSuffix))
+		fileutil.MustNotExist(destPath, forceOverwrite)
+
+		content, err := os.ReadFile(srcPath)
+		if err != nil {
+			return fmt.Errorf("failed to read template file: %s: %v", srcPath, err)
+		}
+		if strings.HasSuffix(relPath, templateFileSuffix) {
+			rendered, err := renderString(relPath, string(content), vars)
+			if err != nil {
+				return err
+			}
+			content = []byte(rendered)
+		}
+		if err = os.MkdirAll(filepath.Dir(destPath), 0700); err != nil {
+			return fmt.Errorf("failed to create directory for: %s: %v", destPath, err)
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if err = os.WriteFile(destPath, content, info.Mode().Perm()); err != nil {
+			return fmt.Errorf("failed to write file: %s: %v", destPath, err)
+		}
+		logger.Debugf("wrote template file: %v", destPath)
+		written = append(written, destPath)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render template %s: %v", templateDir, err)
+	}
+	return written, nil
+}
+
+func renderString(name string, text string, vars TemplateVars) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %s: %v", name, err)
+	}
+	buf := &bytes.Buffer{}
+	if err = tmpl.Execute(buf, vars); err != nil {
+		return "", fmt.Errorf("failed to render template: %s: %v", name, err)
+	}
+	return buf.String(), nil
+}
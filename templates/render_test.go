@@ -0,0 +1,78 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	templateDir := t.TempDir()
+	writeTestFile(t, filepath.Join(templateDir, "template.yaml"), "description: House style\n")
+	writeTestFile(t, filepath.Join(templateDir, "{{.ServiceName}}-config.yaml.tmpl"), "plugin: {{.Plugin}}\n# port {{.Port}} team {{.Vars.team}}\n")
+	writeTestFile(t, filepath.Join(templateDir, "scripts", "common.js"), "// {{ not rendered }}\n")
+
+	destDir := filepath.Join(t.TempDir(), "orders")
+	vars, err := BuildVars(destDir, "rest", "none", map[string]string{"team": "payments", "port": "9090"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	written, err := Render(templateDir, destDir, vars, false)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if len(written) != 2 {
+		t.Errorf("Render() wrote %d files, want 2: %v", len(written), written)
+	}
+
+	config, err := os.ReadFile(filepath.Join(destDir, "orders-config.yaml"))
+	if err != nil {
+		t.Fatalf("Render() should write rendered config file: %v", err)
+	}
+	if want := "plugin: rest\n# port 9090 team payments\n"; string(config) != want {
+		t.Errorf("Render() config = %q, want %q", config, want)
+	}
+	script, err := os.ReadFile(filepath.Join(destDir, "scripts", "common.js"))
+	if err != nil || string(script) != "// {{ not rendered }}\n" {
+		t.Errorf("Render() should copy non-template files as-is, got: %q", script)
+	}
+	if _, err = os.Stat(filepath.Join(destDir, "template.yaml")); err == nil {
+		t.Errorf("Render() should not copy template metadata")
+	}
+}
+
+func TestRender_missingVariable(t *testing.T) {
+	templateDir := t.TempDir()
+	writeTestFile(t, filepath.Join(templateDir, "README.md.tmpl"), "{{.Vars.missing}}\n")
+
+	vars, _ := BuildVars(t.TempDir(), "rest", "none", nil)
+	if _, err := Render(templateDir, t.TempDir(), vars, false); err == nil {
+		t.Errorf("Render() should fail for missing variable")
+	}
+}
+
+func TestBuildVars(t *testing.T) {
+	vars, err := BuildVars("/tmp/orders", "openapi", "groovy", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vars.ServiceName != "orders" || vars.Port != 8080 || vars.Plugin != "openapi" || vars.ScriptEngine != "groovy" {
+		t.Errorf("BuildVars() = %+v", vars)
+	}
+	vars, _ = BuildVars("/tmp/orders", "rest", "none", map[string]string{"serviceName": "billing"})
+	if vars.ServiceName != "billing" {
+		t.Errorf("BuildVars() service name = %s, want billing", vars.ServiceName)
+	}
+	if _, err = BuildVars("/tmp/orders", "rest", "none", map[string]string{"port": "abc"}); err == nil {
+		t.Errorf("BuildVars() should fail for invalid port")
+	}
+}
+
+func writeTestFile(t *testing.T, filePath string, content string) {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"fmt"
+	"gatehill.io/imposter/library"
+	"gatehill.io/imposter/logging"
+	"os"
+	"path/filepath"
+	"sigs.k8s.io/yaml"
+	"strconv"
+	"strings"
+)
+
+const templatesDir = ".imposter/templates"
+
+// metadataFileName is the optional file describing a template.
+// It is not rendered into the destination directory.
+const metadataFileName = "template.yaml"
+
+var logger = logging.GetLogger()
+
+type TemplateMetadata struct {
+	Name        string
+	Description string
+	Path        string
+}
+
+// TemplateVars are the variables available to template files.
+type TemplateVars struct {
+	ServiceName  string
+	Port         int
+	Plugin       string
+	ScriptEngine string
+
+	// Vars holds all variables provided by the user
+	Vars map[string]string
+}
+
+type templateMetadataFile struct {
+	Description string `json:"description"`
+}
+
+// BuildVars returns the template variables for a mock in configDir.
+// The serviceName and port variables override the defaults.
+func BuildVars(configDir string, plugin string, scriptEngine string, userVars map[string]string) (TemplateVars, error) {
+	vars := TemplateVars{
+		ServiceName:  filepath.Base(configDir),
+		Port:         8080,
+		Plugin:       plugin,
+		ScriptEngine: scriptEngine,
+		Vars:         userVars,
+	}
+	if vars.Vars == nil {
+		vars.Vars = make(map[string]string)
+	}
+	if serviceName := userVars["serviceName"]; serviceName != "" {
+		vars.ServiceName = serviceName
+	}
+	if port := userVars["port"]; port != "" {
+		p, err := strconv.Atoi(port)
+		if err != nil {
+			return TemplateVars{}, fmt.Errorf("invalid port template variable: %s", port)
+		}
+		vars.Port = p
+	}
+	return vars, nil
+}
+
+func getTemplatesDir() (string, error) {
+	return library.EnsureDirUsingConfig("template.dir", templatesDir)
+}
+
+// List returns the templates installed in the templates directory.
+func List() ([]TemplateMetadata, error) {
+	dir, err := getTemplatesDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates directory: %s: %v", dir, err)
+	}
+	var available []TemplateMetadata
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		templatePath := filepath.Join(dir, entry.Name())
+		available = append(available, TemplateMetadata{
+			Name:        entry.Name(),
+			Description: readDescription(templatePath),
+			Path:        templatePath,
+		})
+	}
+	return available, nil
+}
+
+func readDescription(templatePath string) string {
+	content, err := os.ReadFile(filepath.Join(templatePath, metadataFileName))
+	if err != nil {
+		return ""
+	}
+	var metadata templateMetadataFile
+	if err = yaml.Unmarshal(content, &metadata); err != nil {
+		logger.Warnf("failed to parse template metadata: %s: %v", templatePath, err)
+		return ""
+	}
+	return metadata.Description
+}
+
+// Resolve returns the directory containing the template referred to by ref,
+// which is a git URL, a path to a directory, or the name of an installed template.
+func Resolve(ref string) (string, error) {
+	if isGitUrl(ref) {
+		return ensureGitTemplate(ref)
+	}
+	if info, err := os.Stat(ref); err == nil && info.IsDir() {
+		return filepath.Abs(ref)
+	}
+	dir, err := getTemplatesDir()
+	if err != nil {
+		return "", err
+	}
+	templatePath := filepath.Join(dir, ref)
+	if info, err := os.Stat(templatePath); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("template not found: %s", ref)
+	}
+	return templatePath, nil
+}
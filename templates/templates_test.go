@@ -0,0 +1,86 @@
+package templates
+
+import (
+	"github.com/spf13/viper"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestListAndResolve(t *testing.T) {
+	templatesDir := t.TempDir()
+	viper.Set("template.dir", templatesDir)
+	defer viper.Set("template.dir", nil)
+
+	writeTestFile(t, filepath.Join(templatesDir, "house-style", "template.yaml"), "description: House style\n")
+	writeTestFile(t, filepath.Join(templatesDir, ".cache", "abc", "README.md"), "cached\n")
+
+	available, err := List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(available) != 1 || available[0].Name != "house-style" || available[0].Description != "House style" {
+		t.Errorf("List() = %+v, want house-style template only", available)
+	}
+
+	resolved, err := Resolve("house-style")
+	if err != nil || resolved != filepath.Join(templatesDir, "house-style") {
+		t.Errorf("Resolve() by name = %s, %v", resolved, err)
+	}
+	resolved, err = Resolve(filepath.Join(templatesDir, "house-style"))
+	if err != nil || resolved != filepath.Join(templatesDir, "house-style") {
+		t.Errorf("Resolve() by path = %s, %v", resolved, err)
+	}
+	if _, err = Resolve("missing"); err == nil {
+		t.Errorf("Resolve() should fail for missing template")
+	}
+}
+
+func TestResolve_gitUrl(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	templatesDir := t.TempDir()
+	viper.Set("template.dir", templatesDir)
+	defer viper.Set("template.dir", nil)
+
+	repoDir := filepath.Join(t.TempDir(), "house-style.git")
+	writeTestFile(t, filepath.Join(repoDir, "README.md.tmpl"), "{{.ServiceName}}\n")
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "init"},
+	} {
+		if output, err := exec.Command("git", append([]string{"-C", repoDir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, output)
+		}
+	}
+
+	resolved, err := Resolve(repoDir)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if filepath.Dir(resolved) != filepath.Join(templatesDir, ".cache") {
+		t.Errorf("Resolve() should clone into cache, got: %s", resolved)
+	}
+
+	// resolving again uses the cached copy
+	if again, err := Resolve(repoDir); err != nil || again != resolved {
+		t.Errorf("Resolve() again = %s, %v, want %s", again, err, resolved)
+	}
+}
+
+func Test_isGitUrl(t *testing.T) {
+	tests := map[string]bool{
+		"git@github.com:example/templates.git":     true,
+		"https://github.com/example/templates.git": true,
+		"ssh://git@example.com/templates":          true,
+		"house-style":                              false,
+		"./templates/house-style":                  false,
+	}
+	for ref, want := range tests {
+		if got := isGitUrl(ref); got != want {
+			t.Errorf("isGitUrl(%s) = %v, want %v", ref, got, want)
+		}
+	}
+}
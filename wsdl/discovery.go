@@ -0,0 +1,69 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wsdl
+
+import (
+	"encoding/xml"
+	"gatehill.io/imposter/fileutil"
+	"gatehill.io/imposter/logging"
+	"os"
+	"path/filepath"
+)
+
+const (
+	wsdl11Namespace = "http://schemas.xmlsoap.org/wsdl/"
+	wsdl20Namespace = "http://www.w3.org/ns/wsdl"
+)
+
+var logger = logging.GetLogger()
+
+// DiscoverWsdlFiles finds WSDL files within the given directory, either
+// with a .wsdl extension, or XML files containing a WSDL document.
+// It returns fully qualified paths to the files discovered.
+func DiscoverWsdlFiles(configDir string) []string {
+	var wsdlFiles []string
+	for _, candidate := range fileutil.FindFilesWithExtension(configDir, ".wsdl", ".xml") {
+		fullyQualifiedPath := filepath.Join(configDir, candidate)
+		if filepath.Ext(fullyQualifiedPath) == ".wsdl" || isWsdlDocument(fullyQualifiedPath) {
+			wsdlFiles = append(wsdlFiles, fullyQualifiedPath)
+		}
+	}
+	return wsdlFiles
+}
+
+// isWsdlDocument checks if the root element of the file is a
+// WSDL 1.1 definitions or WSDL 2.0 description element.
+func isWsdlDocument(filePath string) bool {
+	f, err := os.Open(filePath)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	defer f.Close()
+
+	decoder := xml.NewDecoder(f)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			logger.Tracef("failed to parse XML file: %s: %v", filePath, err)
+			return false
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return (start.Name.Space == wsdl11Namespace && start.Name.Local == "definitions") ||
+				(start.Name.Space == wsdl20Namespace && start.Name.Local == "description")
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package wsdl
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDiscoverWsdlFiles(t *testing.T) {
+	var got []string
+	for _, wsdlFile := range DiscoverWsdlFiles("testdata") {
+		got = append(got, filepath.Base(wsdlFile))
+	}
+	want := []string{"order-service.wsdl2.xml", "petstore.wsdl"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiscoverWsdlFiles() = %v, want %v", got, want)
+	}
+}
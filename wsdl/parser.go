@@ -0,0 +1,298 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wsdl
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	soap11BindingNamespace = "http://schemas.xmlsoap.org/wsdl/soap/"
+	soap12BindingNamespace = "http://schemas.xmlsoap.org/wsdl/soap12/"
+	wsdl20SoapNamespace    = "http://www.w3.org/ns/wsdl/soap"
+)
+
+// Document is a parsed WSDL 1.1 or 2.0 file, along with the
+// XML schemas it contains or imports.
+type Document struct {
+	definitions definitions
+	schemas     *schemaSet
+}
+
+// Operation is a single operation exposed by a SOAP binding.
+type Operation struct {
+	Binding    string
+	Name       string
+	SoapAction string
+
+	// SoapVersion is either "1.1" or "1.2"
+	SoapVersion string
+
+	// Style is either "document" or "rpc"
+	Style string
+
+	// namespace is used for the wrapper element of rpc style responses
+	namespace string
+	output    []part
+}
+
+type definitions struct {
+	TargetNamespace string       `xml:"targetNamespace,attr"`
+	Types           types        `xml:"types"`
+	Messages        []message    `xml:"message"`
+	PortTypes       []portType   `xml:"portType"`
+	Interfaces      []portType   `xml:"interface"`
+	Bindings        []binding    `xml:"binding"`
+	Imports         []wsdlImport `xml:"import"`
+}
+
+type types struct {
+	Schemas []xsdSchema `xml:"schema"`
+}
+
+type wsdlImport struct {
+	Location string `xml:"location,attr"`
+}
+
+type message struct {
+	Name  string `xml:"name,attr"`
+	Parts []part `xml:"part"`
+}
+
+type part struct {
+	Name    string `xml:"name,attr"`
+	Element string `xml:"element,attr"`
+	Type    string `xml:"type,attr"`
+}
+
+// portType is a WSDL 1.1 port type, or a WSDL 2.0 interface.
+type portType struct {
+	Name       string              `xml:"name,attr"`
+	Operations []portTypeOperation `xml:"operation"`
+}
+
+type portTypeOperation struct {
+	Name   string      `xml:"name,attr"`
+	Output operationIo `xml:"output"`
+}
+
+type operationIo struct {
+	// Message is set for WSDL 1.1 documents
+	Message string `xml:"message,attr"`
+
+	// Element is set for WSDL 2.0 documents
+	Element string `xml:"element,attr"`
+}
+
+type binding struct {
+	Name       string             `xml:"name,attr"`
+	Type       string             `xml:"type,attr"`
+	Interface  string             `xml:"interface,attr"`
+	Version    string             `xml:"http://www.w3.org/ns/wsdl/soap version,attr"`
+	Soap11     *soapBinding       `xml:"http://schemas.xmlsoap.org/wsdl/soap/ binding"`
+	Soap12     *soapBinding       `xml:"http://schemas.xmlsoap.org/wsdl/soap12/ binding"`
+	Operations []bindingOperation `xml:"operation"`
+}
+
+type soapBinding struct {
+	Style string `xml:"style,attr"`
+}
+
+type bindingOperation struct {
+	Name   string         `xml:"name,attr"`
+	Ref    string         `xml:"ref,attr"`
+	Action string         `xml:"http://www.w3.org/ns/wsdl/soap action,attr"`
+	Soap11 *soapOperation `xml:"http://schemas.xmlsoap.org/wsdl/soap/ operation"`
+	Soap12 *soapOperation `xml:"http://schemas.xmlsoap.org/wsdl/soap12/ operation"`
+	Output struct {
+		Soap11Body *soapBody `xml:"http://schemas.xmlsoap.org/wsdl/soap/ body"`
+		Soap12Body *soapBody `xml:"http://schemas.xmlsoap.org/wsdl/soap12/ body"`
+	} `xml:"output"`
+}
+
+type soapOperation struct {
+	SoapAction string `xml:"soapAction,attr"`
+	Style      string `xml:"style,attr"`
+}
+
+type soapBody struct {
+	Namespace string `xml:"namespace,attr"`
+}
+
+// Parse reads the WSDL file at the given path. Imported WSDL documents
+// and XML schemas are resolved relative to the file.
+func Parse(wsdlFilePath string) (*Document, error) {
+	defs, err := readDefinitions(wsdlFilePath)
+	if err != nil {
+		return nil, err
+	}
+	schemas := newSchemaSet()
+	if err = addSchemas(schemas, wsdlFilePath, defs.Types.Schemas); err != nil {
+		return nil, err
+	}
+
+	// merge imported WSDL documents, such as an abstract definition
+	// imported by a concrete binding document
+	for _, imp := range defs.Imports {
+		if imp.Location == "" || isRemoteLocation(imp.Location) {
+			continue
+		}
+		importPath := filepath.Join(filepath.Dir(wsdlFilePath), imp.Location)
+		imported, err := readDefinitions(importPath)
+		if err != nil {
+			return nil, err
+		}
+		if err = addSchemas(schemas, importPath, imported.Types.Schemas); err != nil {
+			return nil, err
+		}
+		defs.Messages = append(defs.Messages, imported.Messages...)
+		defs.PortTypes = append(defs.PortTypes, imported.PortTypes...)
+		defs.Interfaces = append(defs.Interfaces, imported.Interfaces...)
+		defs.Bindings = append(defs.Bindings, imported.Bindings...)
+	}
+
+	logger.Tracef("parsed WSDL file %s with %d binding(s)", wsdlFilePath, len(defs.Bindings))
+	return &Document{definitions: *defs, schemas: schemas}, nil
+}
+
+func readDefinitions(wsdlFilePath string) (*definitions, error) {
+	content, err := os.ReadFile(wsdlFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read WSDL file: %s: %v", wsdlFilePath, err)
+	}
+	var defs definitions
+	if err = xml.Unmarshal(content, &defs); err != nil {
+		return nil, fmt.Errorf("failed to parse WSDL file: %s: %v", wsdlFilePath, err)
+	}
+	return &defs, nil
+}
+
+// Operations returns the operations of all SOAP bindings in the document.
+func (d *Document) Operations() []Operation {
+	var operations []Operation
+	for _, b := range d.definitions.Bindings {
+		soapVersion, defaultStyle, isSoap := getBindingSoapDetails(b)
+		if !isSoap {
+			logger.Debugf("skipping non-SOAP binding %s", b.Name)
+			continue
+		}
+		for _, bindingOp := range b.Operations {
+			op := Operation{
+				Binding:     b.Name,
+				Name:        bindingOp.Name,
+				SoapVersion: soapVersion,
+				Style:       defaultStyle,
+				namespace:   d.definitions.TargetNamespace,
+			}
+			if op.Name == "" {
+				op.Name = localName(bindingOp.Ref)
+			}
+			if soapOp := firstNonNil(bindingOp.Soap11, bindingOp.Soap12); soapOp != nil {
+				op.SoapAction = soapOp.SoapAction
+				if soapOp.Style != "" {
+					op.Style = soapOp.Style
+				}
+			} else {
+				op.SoapAction = bindingOp.Action
+			}
+			if body := firstNonNil(bindingOp.Output.Soap11Body, bindingOp.Output.Soap12Body); body != nil && body.Namespace != "" {
+				op.namespace = body.Namespace
+			}
+			op.output = d.findOutputParts(b, op.Name)
+			operations = append(operations, op)
+		}
+	}
+	return operations
+}
+
+// getBindingSoapDetails returns the SOAP version and default operation
+// style of the binding, or false if it is not a SOAP binding.
+func getBindingSoapDetails(b binding) (soapVersion string, style string, isSoap bool) {
+	switch {
+	case b.Soap11 != nil:
+		return "1.1", defaultString(b.Soap11.Style, "document"), true
+	case b.Soap12 != nil:
+		return "1.2", defaultString(b.Soap12.Style, "document"), true
+	case b.Type == wsdl20SoapNamespace:
+		return defaultString(b.Version, "1.2"), "document", true
+	default:
+		return "", "", false
+	}
+}
+
+// findOutputParts resolves the parts of the output message for the
+// operation, via the port type or interface of the binding.
+func (d *Document) findOutputParts(b binding, operationName string) []part {
+	if b.Interface != "" {
+		for _, iface := range d.definitions.Interfaces {
+			if iface.Name != localName(b.Interface) {
+				continue
+			}
+			for _, op := range iface.Operations {
+				if op.Name == operationName && op.Output.Element != "" {
+					return []part{{Name: localName(op.Output.Element), Element: op.Output.Element}}
+				}
+			}
+		}
+		return nil
+	}
+	for _, pt := range d.definitions.PortTypes {
+		if pt.Name != localName(b.Type) {
+			continue
+		}
+		for _, op := range pt.Operations {
+			if op.Name != operationName {
+				continue
+			}
+			for _, msg := range d.definitions.Messages {
+				if msg.Name == localName(op.Output.Message) {
+					return msg.Parts
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// localName strips the namespace prefix from a qualified name.
+func localName(qname string) string {
+	return qname[strings.LastIndex(qname, ":")+1:]
+}
+
+func isRemoteLocation(location string) bool {
+	return strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+}
+
+func defaultString(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+func firstNonNil[T any](values ...*T) *T {
+	for _, v := range values {
+		if v != nil {
+			return v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package wsdl
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	doc, err := Parse(filepath.Join("testdata", "petstore.wsdl"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	operations := doc.Operations()
+	if len(operations) != 3 {
+		t.Fatalf("Operations() wanted 3 operations, got: %d", len(operations))
+	}
+	tests := []struct {
+		binding     string
+		name        string
+		soapAction  string
+		soapVersion string
+		style       string
+	}{
+		{binding: "SoapBinding", name: "getPetById", soapAction: "getPetById", soapVersion: "1.1", style: "document"},
+		{binding: "SoapBinding", name: "countPets", soapAction: "countPets", soapVersion: "1.1", style: "rpc"},
+		{binding: "Soap12Binding", name: "getPetById", soapAction: "getPetById", soapVersion: "1.2", style: "document"},
+	}
+	for i, tt := range tests {
+		op := operations[i]
+		if op.Binding != tt.binding || op.Name != tt.name || op.SoapAction != tt.soapAction ||
+			op.SoapVersion != tt.soapVersion || op.Style != tt.style {
+			t.Errorf("Operations()[%d] = %+v, want %+v", i, op, tt)
+		}
+	}
+}
+
+func TestParse_wsdl2(t *testing.T) {
+	doc, err := Parse(filepath.Join("testdata", "order-service.wsdl2.xml"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	operations := doc.Operations()
+	if len(operations) != 1 {
+		t.Fatalf("Operations() wanted 1 operation, got: %d", len(operations))
+	}
+	op := operations[0]
+	if op.Binding != "OrderSoapBinding" || op.Name != "getOrder" || op.SoapAction != "getOrder" || op.SoapVersion != "1.2" {
+		t.Errorf("Operations()[0] = %+v", op)
+	}
+}
+
+func TestParse_missingFile(t *testing.T) {
+	if _, err := Parse(filepath.Join("testdata", "missing.wsdl")); err == nil {
+		t.Errorf("Parse() should fail for missing file")
+	}
+}
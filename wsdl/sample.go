@@ -0,0 +1,246 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wsdl
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// maxSampleDepth limits how far nested and recursive types are populated.
+const maxSampleDepth = 5
+
+var soapEnvelopeNamespaces = map[string]string{
+	"1.1": "http://schemas.xmlsoap.org/soap/envelope/",
+	"1.2": "http://www.w3.org/2003/05/soap-envelope",
+}
+
+// sampleNode is an element in a generated sample document.
+type sampleNode struct {
+	name      string
+	namespace string
+	text      string
+	children  []*sampleNode
+}
+
+// GenerateSampleResponse returns a SOAP envelope containing an example
+// response for the operation, populated from the XML schema types
+// of its output message.
+func (d *Document) GenerateSampleResponse(op Operation) ([]byte, error) {
+	envelopeNamespace, found := soapEnvelopeNamespaces[op.SoapVersion]
+	if !found {
+		return nil, fmt.Errorf("unsupported SOAP version %s for operation %s", op.SoapVersion, op.Name)
+	}
+
+	var bodyNodes []*sampleNode
+	if op.Style == "rpc" {
+		wrapper := &sampleNode{name: op.Name + "Response", namespace: op.namespace}
+		for _, p := range op.output {
+			wrapper.children = append(wrapper.children, d.buildPart(p))
+		}
+		bodyNodes = append(bodyNodes, wrapper)
+	} else {
+		for _, p := range op.output {
+			bodyNodes = append(bodyNodes, d.buildPart(p))
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	buf.WriteString(xml.Header)
+	fmt.Fprintf(buf, "<env:Envelope xmlns:env=\"%s\">\n", envelopeNamespace)
+	buf.WriteString("  <env:Header/>\n")
+	buf.WriteString("  <env:Body>\n")
+	for _, node := range bodyNodes {
+		writeSampleNode(buf, node, "", 2)
+	}
+	buf.WriteString("  </env:Body>\n")
+	buf.WriteString("</env:Envelope>\n")
+	return buf.Bytes(), nil
+}
+
+func (d *Document) buildPart(p part) *sampleNode {
+	if p.Element != "" {
+		if global, found := d.schemas.elements[localName(p.Element)]; found {
+			return d.buildElement(global.component, global.schema, true, 0)
+		}
+		logger.Warnf("element %s not found in WSDL types", p.Element)
+		return &sampleNode{name: localName(p.Element)}
+	}
+	node := &sampleNode{name: p.Name}
+	d.populateFromType(node, p.Type, 0)
+	return node
+}
+
+func (d *Document) buildElement(el *xsdElement, schema *xsdSchema, global bool, depth int) *sampleNode {
+	if el.Ref != "" {
+		if ref, found := d.schemas.elements[localName(el.Ref)]; found {
+			return d.buildElement(ref.component, ref.schema, true, depth)
+		}
+		return &sampleNode{name: localName(el.Ref)}
+	}
+
+	node := &sampleNode{name: el.Name}
+	if global || schema.ElementFormDefault == "qualified" {
+		node.namespace = schema.TargetNamespace
+	}
+	if depth >= maxSampleDepth {
+		return node
+	}
+
+	switch {
+	case el.ComplexType != nil:
+		d.populateFromComplexType(node, el.ComplexType, schema, depth)
+	case el.SimpleType != nil:
+		node.text = d.sampleSimpleValue(el.SimpleType, el.Name)
+	default:
+		d.populateFromType(node, el.Type, depth)
+	}
+	return node
+}
+
+// populateFromType sets the content of the node from the named type,
+// which is either a schema type or an XML Schema built-in type.
+func (d *Document) populateFromType(node *sampleNode, typeName string, depth int) {
+	if ct, found := d.schemas.complexTypes[localName(typeName)]; found {
+		d.populateFromComplexType(node, ct.component, ct.schema, depth)
+	} else if st, found := d.schemas.simpleTypes[localName(typeName)]; found {
+		node.text = d.sampleSimpleValue(st.component, node.name)
+	} else {
+		node.text = sampleBuiltinValue(typeName, node.name)
+	}
+}
+
+func (d *Document) populateFromComplexType(node *sampleNode, ct *xsdComplexType, schema *xsdSchema, depth int) {
+	if ct.SimpleContent != nil {
+		if derivation := firstNonNil(ct.SimpleContent.Extension, ct.SimpleContent.Restriction); derivation != nil {
+			d.populateFromType(node, derivation.Base, depth)
+		}
+		return
+	}
+	if ct.ComplexContent != nil {
+		if ext := ct.ComplexContent.Extension; ext != nil {
+			if base, found := d.schemas.complexTypes[localName(ext.Base)]; found {
+				d.populateFromComplexType(node, base.component, base.schema, depth)
+			}
+		}
+		if derivation := firstNonNil(ct.ComplexContent.Extension, ct.ComplexContent.Restriction); derivation != nil {
+			d.populateFromGroups(node, schema, depth, derivation.Sequence, derivation.All, derivation.Choice)
+		}
+		return
+	}
+	d.populateFromGroups(node, schema, depth, ct.Sequence, ct.All, ct.Choice)
+}
+
+func (d *Document) populateFromGroups(node *sampleNode, schema *xsdSchema, depth int, sequence *xsdGroup, all *xsdGroup, choice *xsdGroup) {
+	if sequence != nil {
+		d.populateFromGroup(node, sequence, schema, depth, false)
+	}
+	if all != nil {
+		d.populateFromGroup(node, all, schema, depth, false)
+	}
+	if choice != nil {
+		d.populateFromGroup(node, choice, schema, depth, true)
+	}
+}
+
+// populateFromGroup adds child elements for the members of the group.
+// Only the first member of a choice is used.
+func (d *Document) populateFromGroup(node *sampleNode, group *xsdGroup, schema *xsdSchema, depth int, choice bool) {
+	for i := range group.Elements {
+		node.children = append(node.children, d.buildElement(&group.Elements[i], schema, false, depth+1))
+		if choice {
+			return
+		}
+	}
+	for i := range group.Sequences {
+		d.populateFromGroup(node, &group.Sequences[i], schema, depth, false)
+		if choice {
+			return
+		}
+	}
+	for i := range group.Choices {
+		d.populateFromGroup(node, &group.Choices[i], schema, depth, true)
+	}
+}
+
+func (d *Document) sampleSimpleValue(st *xsdSimpleType, elementName string) string {
+	if st.Restriction == nil {
+		return elementName
+	}
+	if len(st.Restriction.Enumerations) > 0 {
+		return st.Restriction.Enumerations[0].Value
+	}
+	if base, found := d.schemas.simpleTypes[localName(st.Restriction.Base)]; found && base.component != st {
+		return d.sampleSimpleValue(base.component, elementName)
+	}
+	return sampleBuiltinValue(st.Restriction.Base, elementName)
+}
+
+// sampleBuiltinValue returns an example value for an XML Schema built-in
+// type. String-like types use the name of the element.
+func sampleBuiltinValue(typeName string, elementName string) string {
+	switch localName(typeName) {
+	case "boolean":
+		return "true"
+	case "int", "integer", "long", "short", "byte", "nonNegativeInteger", "positiveInteger",
+		"unsignedInt", "unsignedLong", "unsignedShort", "unsignedByte":
+		return "1"
+	case "decimal", "double", "float":
+		return "1.5"
+	case "date":
+		return "2020-01-01"
+	case "dateTime":
+		return "2020-01-01T00:00:00Z"
+	case "time":
+		return "00:00:00"
+	case "base64Binary":
+		return "c2FtcGxl"
+	case "anyURI":
+		return "http://example.com"
+	default:
+		return elementName
+	}
+}
+
+// writeSampleNode writes the node as indented XML. A default namespace
+// declaration is added whenever the namespace differs from the parent.
+func writeSampleNode(buf *bytes.Buffer, node *sampleNode, parentNamespace string, indent int) {
+	prefix := strings.Repeat("  ", indent)
+	buf.WriteString(prefix + "<" + node.name)
+	if node.namespace != parentNamespace {
+		buf.WriteString(" xmlns=\"")
+		_ = xml.EscapeText(buf, []byte(node.namespace))
+		buf.WriteString("\"")
+	}
+
+	switch {
+	case len(node.children) > 0:
+		buf.WriteString(">\n")
+		for _, child := range node.children {
+			writeSampleNode(buf, child, node.namespace, indent+1)
+		}
+		buf.WriteString(prefix + "</" + node.name + ">\n")
+	case node.text != "":
+		buf.WriteString(">")
+		_ = xml.EscapeText(buf, []byte(node.text))
+		buf.WriteString("</" + node.name + ">\n")
+	default:
+		buf.WriteString("/>\n")
+	}
+}
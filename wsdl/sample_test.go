@@ -0,0 +1,74 @@
+package wsdl
+
+import (
+	"encoding/xml"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateSampleResponse(t *testing.T) {
+	doc, err := Parse(filepath.Join("testdata", "petstore.wsdl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	operations := doc.Operations()
+
+	tests := []struct {
+		name     string
+		op       Operation
+		contains []string
+		excludes []string
+	}{
+		{
+			name: "document style soap 1.1",
+			op:   operations[0],
+			contains: []string{
+				`<env:Envelope xmlns:env="http://schemas.xmlsoap.org/soap/envelope/">`,
+				`<getPetByIdResponse xmlns="urn:com:example:petstore">`,
+				`<id>1</id>`,
+				`<name>name</name>`,
+				`<status>available</status>`,
+				`<breed>breed</breed>`,
+			},
+			excludes: []string{"<species>"},
+		},
+		{
+			name: "rpc style",
+			op:   operations[1],
+			contains: []string{
+				`<countPetsResponse xmlns="urn:com:example:petstore:rpc">`,
+				`<count xmlns="">1</count>`,
+			},
+		},
+		{
+			name: "document style soap 1.2",
+			op:   operations[2],
+			contains: []string{
+				`<env:Envelope xmlns:env="http://www.w3.org/2003/05/soap-envelope">`,
+				`<getPetByIdResponse xmlns="urn:com:example:petstore">`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sample, err := doc.GenerateSampleResponse(tt.op)
+			if err != nil {
+				t.Fatalf("GenerateSampleResponse() error = %v", err)
+			}
+			if err = xml.Unmarshal(sample, new(interface{})); err != nil {
+				t.Errorf("GenerateSampleResponse() should produce well-formed XML: %v", err)
+			}
+			for _, s := range tt.contains {
+				if !strings.Contains(string(sample), s) {
+					t.Errorf("GenerateSampleResponse() should contain %s, got:\n%s", s, sample)
+				}
+			}
+			for _, s := range tt.excludes {
+				if strings.Contains(string(sample), s) {
+					t.Errorf("GenerateSampleResponse() should not contain %s, got:\n%s", s, sample)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright © 2026 Pete Cornish <outofcoffee@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wsdl
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+type xsdSchema struct {
+	TargetNamespace    string           `xml:"targetNamespace,attr"`
+	ElementFormDefault string           `xml:"elementFormDefault,attr"`
+	Elements           []xsdElement     `xml:"element"`
+	ComplexTypes       []xsdComplexType `xml:"complexType"`
+	SimpleTypes        []xsdSimpleType  `xml:"simpleType"`
+	Imports            []xsdImport      `xml:"import"`
+	Includes           []xsdImport      `xml:"include"`
+}
+
+type xsdImport struct {
+	SchemaLocation string `xml:"schemaLocation,attr"`
+}
+
+type xsdElement struct {
+	Name        string          `xml:"name,attr"`
+	Type        string          `xml:"type,attr"`
+	Ref         string          `xml:"ref,attr"`
+	ComplexType *xsdComplexType `xml:"complexType"`
+	SimpleType  *xsdSimpleType  `xml:"simpleType"`
+}
+
+type xsdComplexType struct {
+	Name           string      `xml:"name,attr"`
+	Sequence       *xsdGroup   `xml:"sequence"`
+	All            *xsdGroup   `xml:"all"`
+	Choice         *xsdGroup   `xml:"choice"`
+	ComplexContent *xsdContent `xml:"complexContent"`
+	SimpleContent  *xsdContent `xml:"simpleContent"`
+}
+
+type xsdGroup struct {
+	Elements  []xsdElement `xml:"element"`
+	Sequences []xsdGroup   `xml:"sequence"`
+	Choices   []xsdGroup   `xml:"choice"`
+}
+
+type xsdContent struct {
+	Extension   *xsdDerivation `xml:"extension"`
+	Restriction *xsdDerivation `xml:"restriction"`
+}
+
+type xsdDerivation struct {
+	Base     string    `xml:"base,attr"`
+	Sequence *xsdGroup `xml:"sequence"`
+	All      *xsdGroup `xml:"all"`
+	Choice   *xsdGroup `xml:"choice"`
+}
+
+type xsdSimpleType struct {
+	Name        string `xml:"name,attr"`
+	Restriction *struct {
+		Base         string `xml:"base,attr"`
+		Enumerations []struct {
+			Value string `xml:"value,attr"`
+		} `xml:"enumeration"`
+	} `xml:"restriction"`
+}
+
+// schemaComponent associates a schema component with the
+// schema in which it is declared.
+type schemaComponent[T any] struct {
+	schema    *xsdSchema
+	component *T
+}
+
+// schemaSet indexes the global components of a set of schemas by their
+// local name. Namespaces are not considered when resolving references.
+type schemaSet struct {
+	elements     map[string]schemaComponent[xsdElement]
+	complexTypes map[string]schemaComponent[xsdComplexType]
+	simpleTypes  map[string]schemaComponent[xsdSimpleType]
+	loaded       map[string]bool
+}
+
+func newSchemaSet() *schemaSet {
+	return &schemaSet{
+		elements:     make(map[string]schemaComponent[xsdElement]),
+		complexTypes: make(map[string]schemaComponent[xsdComplexType]),
+		simpleTypes:  make(map[string]schemaComponent[xsdSimpleType]),
+		loaded:       make(map[string]bool),
+	}
+}
+
+// addSchemas indexes the given schemas, then loads any local schema
+// files they import or include, relative to the declaring file.
+func addSchemas(set *schemaSet, declaringFile string, schemas []xsdSchema) error {
+	for i := range schemas {
+		schema := &schemas[i]
+		for j := range schema.Elements {
+			set.elements[schema.Elements[j].Name] = schemaComponent[xsdElement]{schema, &schema.Elements[j]}
+		}
+		for j := range schema.ComplexTypes {
+			set.complexTypes[schema.ComplexTypes[j].Name] = schemaComponent[xsdComplexType]{schema, &schema.ComplexTypes[j]}
+		}
+		for j := range schema.SimpleTypes {
+			set.simpleTypes[schema.SimpleTypes[j].Name] = schemaComponent[xsdSimpleType]{schema, &schema.SimpleTypes[j]}
+		}
+
+		for _, imp := range append(schema.Imports, schema.Includes...) {
+			if imp.SchemaLocation == "" || isRemoteLocation(imp.SchemaLocation) {
+				continue
+			}
+			schemaPath := filepath.Join(filepath.Dir(declaringFile), imp.SchemaLocation)
+			if set.loaded[schemaPath] {
+				continue
+			}
+			set.loaded[schemaPath] = true
+
+			imported, err := readSchema(schemaPath)
+			if err != nil {
+				return err
+			}
+			if imported.TargetNamespace == "" {
+				// an included schema takes on the namespace of the including schema
+				imported.TargetNamespace = schema.TargetNamespace
+			}
+			if err = addSchemas(set, schemaPath, []xsdSchema{*imported}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func readSchema(schemaPath string) (*xsdSchema, error) {
+	content, err := os.ReadFile(schemaPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read XML schema: %s: %v", schemaPath, err)
+	}
+	var schema xsdSchema
+	if err = xml.Unmarshal(content, &schema); err != nil {
+		return nil, fmt.Errorf("failed to parse XML schema: %s: %v", schemaPath, err)
+	}
+	return &schema, nil
+}